@@ -50,6 +50,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/memorystorage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/tokenauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor"
@@ -100,7 +103,10 @@ func components() (component.Factories, error) {
 		hostobserver.NewFactory(),
 		httpforwarder.NewFactory(),
 		k8sobserver.NewFactory(),
+		memorystorage.NewFactory(),
 		oauth2clientauthextension.NewFactory(),
+		redisstorage.NewFactory(),
+		tokenauthextension.NewFactory(),
 	}
 
 	for _, ext := range factories.Extensions {
@@ -15,6 +15,9 @@
 package awscloudwatchlogsexporter
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -27,12 +30,19 @@ type Config struct {
 
 	// LogGroupName is the name of CloudWatch log group which defines group of log streams
 	// that share the same retention, monitoring, and access control settings.
+	// It can hold `{attribute}` placeholders replaced with the resource attribute values,
+	// e.g. /eks/{k8s.cluster.name}/{k8s.namespace.name}.
 	LogGroupName string `mapstructure:"log_group_name"`
 
 	// LogStreamName is the name of CloudWatch log stream which is a sequence of log events
-	// that share the same source.
+	// that share the same source. It can hold `{attribute}` placeholders, e.g. {k8s.pod.name}.
 	LogStreamName string `mapstructure:"log_stream_name"`
 
+	// LogRetention is the number of days the log events of the log groups created by the
+	// exporter are retained. The log groups that already exist are left untouched.
+	// Optional, the log events never expire by default.
+	LogRetention int64 `mapstructure:"log_retention"`
+
 	// Region is the AWS region where the logs are sent to.
 	// Optional.
 	Region string `mapstructure:"region"`
@@ -44,4 +54,24 @@ type Config struct {
 	Endpoint string `mapstructure:"endpoint"`
 }
 
+// https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutRetentionPolicy.html
+var validRetentionDays = map[int64]bool{
+	1: true, 3: true, 5: true, 7: true, 14: true, 30: true, 60: true, 90: true, 120: true, 150: true,
+	180: true, 365: true, 400: true, 545: true, 731: true, 1827: true, 3653: true,
+}
+
+// Validate checks if the exporter configuration is valid.
+func (config *Config) Validate() error {
+	if config.LogGroupName == "" {
+		return errors.New("'log_group_name' must be set")
+	}
+	if config.LogStreamName == "" {
+		return errors.New("'log_stream_name' must be set")
+	}
+	if config.LogRetention != 0 && !validRetentionDays[config.LogRetention] {
+		return fmt.Errorf("invalid value for 'log_retention': %d days is not a retention period supported by CloudWatch Logs", config.LogRetention)
+	}
+	return nil
+}
+
 // TODO(jbd): Add ARN role to config.
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: Config{LogGroupName: "/eks/{k8s.cluster.name}", LogStreamName: "{k8s.pod.name}", LogRetention: 14},
+		},
+		{
+			name:    "no log group",
+			config:  Config{LogStreamName: "stream"},
+			wantErr: "'log_group_name' must be set",
+		},
+		{
+			name:    "no log stream",
+			config:  Config{LogGroupName: "group"},
+			wantErr: "'log_stream_name' must be set",
+		},
+		{
+			name:    "invalid retention",
+			config:  Config{LogGroupName: "group", LogStreamName: "stream", LogRetention: 10},
+			wantErr: "invalid value for 'log_retention': 10 days is not a retention period supported by CloudWatch Logs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 )
@@ -35,10 +38,14 @@ type exporter struct {
 	logger *zap.Logger
 
 	startOnce sync.Once
-	client    *cloudwatchlogs.CloudWatchLogs // available after startOnce
+	client    cloudwatchlogsiface.CloudWatchLogsAPI // available after startOnce
 
-	seqTokenMu sync.Mutex
-	seqToken   string
+	// pushMu serializes the pushes, as each put to a stream requires the
+	// sequence token returned by the previous one.
+	pushMu sync.Mutex
+	// seqTokens holds the sequence tokens of the streams known to exist.
+	// A nil token is valid for the streams that were just created.
+	seqTokens map[streamKey]*string
 }
 
 func (e *exporter) Start(ctx context.Context, host component.Host) error {
@@ -58,22 +65,7 @@ func (e *exporter) Start(ctx context.Context, host component.Host) error {
 			return
 		}
 		e.client = cloudwatchlogs.New(sess)
-
-		e.logger.Debug("Retrieving Cloud Watch sequence token")
-		out, err := e.client.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
-			LogGroupName:        aws.String(e.config.LogGroupName),
-			LogStreamNamePrefix: aws.String(e.config.LogStreamName),
-		})
-		if err != nil {
-			startErr = err
-			return
-		}
-		if len(out.LogStreams) == 0 {
-			startErr = errors.New("cannot find log group and stream")
-			return
-		}
-		stream := out.LogStreams[0]
-		e.seqToken = *stream.UploadSequenceToken
+		e.seqTokens = make(map[streamKey]*string)
 	})
 	return startErr
 }
@@ -86,47 +78,153 @@ func (e *exporter) Shutdown(ctx context.Context) error {
 func (e *exporter) PushLogs(ctx context.Context, ld pdata.Logs) (err error) {
 	// TODO(jbd): Relax this once CW Logs support ingest
 	// without sequence tokens.
-	e.seqTokenMu.Lock()
-	defer e.seqTokenMu.Unlock()
+	e.pushMu.Lock()
+	defer e.pushMu.Unlock()
 
-	logEvents, _ := logsToCWLogs(e.logger, ld)
-	if len(logEvents) == 0 {
-		return nil
+	streams, _ := logsToCWLogs(e.logger, e.config, ld)
+
+	var errs, permanentErrs []error
+	failed := make(map[streamKey]bool)
+	for key, logEvents := range streams {
+		for _, batch := range batchLogEvents(logEvents) {
+			err := e.putLogEvents(key, batch)
+			if err == nil {
+				continue
+			}
+			if consumererror.IsPermanent(err) {
+				permanentErrs = append(permanentErrs, err)
+				continue
+			}
+			// the following batches of the stream are retried along with the failed one
+			errs = append(errs, err)
+			failed[key] = true
+			break
+		}
 	}
 
-	e.logger.Debug("Putting log events", zap.Int("num_of_events", len(logEvents)))
+	if len(failed) == 0 {
+		return consumererror.Combine(permanentErrs)
+	}
+	// a permanent error would prevent the failed streams from being retried
+	for _, err := range permanentErrs {
+		e.logger.Error("Dropping log events", zap.Error(err))
+	}
+	return consumererror.NewLogs(consumererror.Combine(errs), logsOfStreams(e.config, ld, failed))
+}
+
+// putLogEvents puts a batch of log events to a stream, creating the stream on its first use.
+func (e *exporter) putLogEvents(key streamKey, logEvents []*cloudwatchlogs.InputLogEvent) error {
+	token, known := e.seqTokens[key]
+	if !known {
+		if err := e.createStream(key); err != nil {
+			return err
+		}
+		e.seqTokens[key] = nil
+	}
+
+	e.logger.Debug("Putting log events",
+		zap.String("log_group_name", key.group),
+		zap.String("log_stream_name", key.stream),
+		zap.Int("num_of_events", len(logEvents)))
 	input := &cloudwatchlogs.PutLogEventsInput{
-		LogGroupName:  aws.String(e.config.LogGroupName),
-		LogStreamName: aws.String(e.config.LogStreamName),
+		LogGroupName:  aws.String(key.group),
+		LogStreamName: aws.String(key.stream),
 		LogEvents:     logEvents,
-		SequenceToken: aws.String(e.seqToken),
+		SequenceToken: token,
 	}
-	out, err := e.client.PutLogEvents(input)
-	if err != nil {
+
+	// the put is attempted again once if the stream state known by the exporter is stale
+	for attempt := 0; ; attempt++ {
+		out, err := e.client.PutLogEvents(input)
+		if err == nil {
+			e.seqTokens[key] = out.NextSequenceToken
+			if info := out.RejectedLogEventsInfo; info != nil {
+				// the rest of the batch was accepted, and the rejected events would be rejected again
+				return consumererror.Permanent(fmt.Errorf("log events rejected from stream %q of group %q: %s",
+					key.stream, key.group, info))
+			}
+			e.logger.Debug("Log events are successfully put")
+			return nil
+		}
+
+		var invalidToken *cloudwatchlogs.InvalidSequenceTokenException
+		var alreadyAccepted *cloudwatchlogs.DataAlreadyAcceptedException
+		switch {
+		case errors.As(err, &alreadyAccepted):
+			// the batch was put by a previous attempt whose response was lost
+			e.seqTokens[key] = alreadyAccepted.ExpectedSequenceToken
+			return nil
+		case errors.As(err, &invalidToken) && attempt == 0:
+			// the stream existed before the exporter started, or is written by another writer
+			input.SequenceToken = invalidToken.ExpectedSequenceToken
+			continue
+		case isAWSError(err, cloudwatchlogs.ErrCodeResourceNotFoundException) && attempt == 0:
+			// the stream or its group was deleted since it was created
+			if err := e.createStream(key); err != nil {
+				return err
+			}
+			input.SequenceToken = nil
+			continue
+		}
 		return err
 	}
-	if info := out.RejectedLogEventsInfo; info != nil {
-		return fmt.Errorf("log event rejected")
-	}
-	e.logger.Debug("Log events are successfully put")
+}
 
-	e.seqToken = *out.NextSequenceToken
-	return nil
+// createStream creates the log stream, along with its log group if it doesn't exist yet.
+func (e *exporter) createStream(key streamKey) error {
+	streamInput := &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(key.group),
+		LogStreamName: aws.String(key.stream),
+	}
+	_, err := e.client.CreateLogStream(streamInput)
+	if isAWSError(err, cloudwatchlogs.ErrCodeResourceNotFoundException) {
+		if err = e.createGroup(key.group); err != nil {
+			return err
+		}
+		_, err = e.client.CreateLogStream(streamInput)
+	}
+	if isAWSError(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+		return nil
+	}
+	return err
 }
 
-func logsToCWLogs(logger *zap.Logger, ld pdata.Logs) ([]*cloudwatchlogs.InputLogEvent, int) {
-	n := ld.ResourceLogs().Len()
-	if n == 0 {
-		return []*cloudwatchlogs.InputLogEvent{}, 0
+// createGroup creates the log group, setting its retention policy if configured.
+func (e *exporter) createGroup(group string) error {
+	e.logger.Debug("Creating log group", zap.String("log_group_name", group))
+	_, err := e.client.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String(group),
+	})
+	if isAWSError(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+		// the group was created by another writer, which owns its retention policy
+		return nil
 	}
+	if err != nil || e.config.LogRetention == 0 {
+		return err
+	}
+
+	_, err = e.client.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String(group),
+		RetentionInDays: aws.Int64(e.config.LogRetention),
+	})
+	return err
+}
+
+func isAWSError(err error, code string) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == code
+}
 
+// logsToCWLogs converts the logs to CloudWatch log events, grouped by the stream they are put to.
+func logsToCWLogs(logger *zap.Logger, config *Config, ld pdata.Logs) (map[streamKey][]*cloudwatchlogs.InputLogEvent, int) {
+	out := make(map[streamKey][]*cloudwatchlogs.InputLogEvent)
 	var dropped int
-	out := make([]*cloudwatchlogs.InputLogEvent, 0) // TODO(jbd): set a better capacity
 
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		rl := rls.At(i)
 		resourceAttrs := attrsValue(rl.Resource().Attributes())
+		key := resourceStreamKey(config, rl.Resource())
 
 		ills := rl.InstrumentationLibraryLogs()
 		for j := 0; j < ills.Len(); j++ {
@@ -138,8 +236,11 @@ func logsToCWLogs(logger *zap.Logger, ld pdata.Logs) ([]*cloudwatchlogs.InputLog
 				if err != nil {
 					logger.Debug("Failed to convert to CloudWatch Log", zap.Error(err))
 					dropped++
+				} else if eventBytes(event) > maxEventBytes {
+					logger.Debug("Dropping CloudWatch Log larger than the maximum event size", zap.Int("size", eventBytes(event)))
+					dropped++
 				} else {
-					out = append(out, event)
+					out[key] = append(out[key], event)
 				}
 			}
 		}
@@ -147,6 +248,19 @@ func logsToCWLogs(logger *zap.Logger, ld pdata.Logs) ([]*cloudwatchlogs.InputLog
 	return out, dropped
 }
 
+// logsOfStreams returns the resource logs of ld that are put to the given streams.
+func logsOfStreams(config *Config, ld pdata.Logs, keys map[streamKey]bool) pdata.Logs {
+	out := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if keys[resourceStreamKey(config, rl.Resource())] {
+			rl.CopyTo(out.ResourceLogs().AppendEmpty())
+		}
+	}
+	return out
+}
+
 type cwLogBody struct {
 	Name                   string                 `json:"name,omitempty"`
 	Body                   interface{}            `json:"body,omitempty"`
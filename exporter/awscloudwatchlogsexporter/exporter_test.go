@@ -15,12 +15,25 @@
 package awscloudwatchlogsexporter
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
 )
 
 func TestLogToCWLog(t *testing.T) {
@@ -200,3 +213,270 @@ func TestAttrValue(t *testing.T) {
 		})
 	}
 }
+
+func TestPushLogs(t *testing.T) {
+	setFakeCredentials(t)
+
+	fake := newFakeCloudWatchLogs()
+	fake.groups["/eks/prod/existing"] = &fakeLogGroup{
+		streams: map[string]*fakeLogStream{"pod-b": {token: 5}},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	exp := &exporter{
+		config: &Config{
+			LogGroupName:  "/eks/{k8s.cluster.name}/{k8s.namespace.name}",
+			LogStreamName: "{k8s.pod.name}",
+			LogRetention:  7,
+			Region:        "us-east-1",
+			Endpoint:      server.URL,
+		},
+		logger: zap.NewNop(),
+	}
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	now := time.Now()
+	ld := pdata.NewLogs()
+	addTestLogs(ld, map[string]string{"k8s.cluster.name": "prod", "k8s.namespace.name": "default", "k8s.pod.name": "pod-a"}, now.Add(time.Second), now)
+	addTestLogs(ld, map[string]string{"k8s.cluster.name": "prod", "k8s.namespace.name": "existing", "k8s.pod.name": "pod-b"}, now)
+	addTestLogs(ld, map[string]string{"k8s.cluster.name": "prod", "k8s.namespace.name": "default"}, now)
+
+	require.NoError(t, exp.PushLogs(context.Background(), ld))
+	require.NoError(t, exp.PushLogs(context.Background(), ld))
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	created := fake.groups["/eks/prod/default"]
+	require.NotNil(t, created)
+	assert.EqualValues(t, 7, created.retention)
+	require.Contains(t, created.streams, "pod-a")
+	require.Contains(t, created.streams, undefinedName)
+	assert.Len(t, created.streams["pod-a"].events, 4)
+	assert.Len(t, created.streams[undefinedName].events, 2)
+	assert.True(t, *created.streams["pod-a"].events[0].Timestamp <= *created.streams["pod-a"].events[1].Timestamp)
+
+	existing := fake.groups["/eks/prod/existing"]
+	assert.EqualValues(t, 0, existing.retention)
+	assert.Len(t, existing.streams["pod-b"].events, 2)
+	assert.EqualValues(t, 7, existing.streams["pod-b"].token)
+}
+
+func TestPushLogs_RecreatesDeletedStream(t *testing.T) {
+	setFakeCredentials(t)
+
+	fake := newFakeCloudWatchLogs()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	exp := &exporter{
+		config: &Config{
+			LogGroupName:  "group",
+			LogStreamName: "stream",
+			Region:        "us-east-1",
+			Endpoint:      server.URL,
+		},
+		logger: zap.NewNop(),
+	}
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	addTestLogs(ld, nil, time.Now())
+	require.NoError(t, exp.PushLogs(context.Background(), ld))
+
+	fake.mu.Lock()
+	delete(fake.groups, "group")
+	fake.mu.Unlock()
+
+	require.NoError(t, exp.PushLogs(context.Background(), ld))
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	require.Contains(t, fake.groups, "group")
+	assert.Len(t, fake.groups["group"].streams["stream"].events, 1)
+}
+
+func TestPushLogs_ReturnsFailedStreams(t *testing.T) {
+	setFakeCredentials(t)
+
+	fake := newFakeCloudWatchLogs()
+	fake.groups["group"] = &fakeLogGroup{
+		streams: map[string]*fakeLogStream{
+			"ok":        {},
+			"failing":   {errCode: cloudwatchlogs.ErrCodeInvalidParameterException},
+			"rejecting": {rejectEvents: true},
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	exp := &exporter{
+		config: &Config{
+			LogGroupName:  "group",
+			LogStreamName: "{stream}",
+			Region:        "us-east-1",
+			Endpoint:      server.URL,
+		},
+		logger: zap.NewNop(),
+	}
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	for _, stream := range []string{"ok", "failing", "rejecting"} {
+		addTestLogs(ld, map[string]string{"stream": stream}, time.Now())
+	}
+
+	err := exp.PushLogs(context.Background(), ld)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	var logsErr consumererror.Logs
+	require.True(t, consumererror.AsLogs(err, &logsErr))
+	failed := logsErr.GetLogs()
+	require.Equal(t, 1, failed.ResourceLogs().Len())
+	stream, _ := failed.ResourceLogs().At(0).Resource().Attributes().Get("stream")
+	assert.Equal(t, "failing", stream.StringVal())
+
+	rejected := pdata.NewLogs()
+	addTestLogs(rejected, map[string]string{"stream": "rejecting"}, time.Now())
+	err = exp.PushLogs(context.Background(), rejected)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Len(t, fake.groups["group"].streams["ok"].events, 1)
+}
+
+func addTestLogs(ld pdata.Logs, resourceAttrs map[string]string, timestamps ...time.Time) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	for k, v := range resourceAttrs {
+		rl.Resource().Attributes().InsertString(k, v)
+	}
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, ts := range timestamps {
+		record := logs.AppendEmpty()
+		record.SetTimestamp(pdata.TimestampFromTime(ts))
+		record.Body().SetStringVal("hello world")
+	}
+}
+
+func setFakeCredentials(t *testing.T) {
+	for k, v := range map[string]string{
+		"AWS_ACCESS_KEY_ID":     "key",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+	} {
+		prev, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		k := k
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(k, prev)
+			} else {
+				os.Unsetenv(k)
+			}
+		})
+	}
+}
+
+// fakeCloudWatchLogs implements the subset of the CloudWatch Logs API used by the exporter,
+// with the same sequence token semantics.
+type fakeCloudWatchLogs struct {
+	mu     sync.Mutex
+	groups map[string]*fakeLogGroup
+}
+
+type fakeLogGroup struct {
+	retention int64
+	streams   map[string]*fakeLogStream
+}
+
+type fakeLogStream struct {
+	// token is the sequence token expected by the next put, 0 for new streams.
+	token  int
+	events []*cloudwatchlogs.InputLogEvent
+	// errCode fails the puts with the given error code.
+	errCode string
+	// rejectEvents rejects the events of the puts as too old.
+	rejectEvents bool
+}
+
+func newFakeCloudWatchLogs() *fakeCloudWatchLogs {
+	return &fakeCloudWatchLogs{groups: make(map[string]*fakeLogGroup)}
+}
+
+func (f *fakeCloudWatchLogs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var input struct {
+		LogGroupName    string
+		LogStreamName   string
+		RetentionInDays int64
+		SequenceToken   *string
+		LogEvents       []*cloudwatchlogs.InputLogEvent
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeError := func(code string, extra string) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"__type":%q,"message":"fake error"%s}`, code, extra)
+	}
+
+	group := f.groups[input.LogGroupName]
+	action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "Logs_20140328.")
+	switch action {
+	case "CreateLogGroup":
+		if group != nil {
+			writeError(cloudwatchlogs.ErrCodeResourceAlreadyExistsException, "")
+			return
+		}
+		f.groups[input.LogGroupName] = &fakeLogGroup{streams: make(map[string]*fakeLogStream)}
+	case "PutRetentionPolicy":
+		if group == nil {
+			writeError(cloudwatchlogs.ErrCodeResourceNotFoundException, "")
+			return
+		}
+		group.retention = input.RetentionInDays
+	case "CreateLogStream":
+		if group == nil {
+			writeError(cloudwatchlogs.ErrCodeResourceNotFoundException, "")
+			return
+		}
+		if group.streams[input.LogStreamName] != nil {
+			writeError(cloudwatchlogs.ErrCodeResourceAlreadyExistsException, "")
+			return
+		}
+		group.streams[input.LogStreamName] = &fakeLogStream{}
+	case "PutLogEvents":
+		if group == nil || group.streams[input.LogStreamName] == nil {
+			writeError(cloudwatchlogs.ErrCodeResourceNotFoundException, "")
+			return
+		}
+		stream := group.streams[input.LogStreamName]
+		if stream.errCode != "" {
+			writeError(stream.errCode, "")
+			return
+		}
+		if stream.token != 0 && (input.SequenceToken == nil || *input.SequenceToken != fmt.Sprint(stream.token)) {
+			writeError(cloudwatchlogs.ErrCodeInvalidSequenceTokenException, fmt.Sprintf(`,"expectedSequenceToken":"%d"`, stream.token))
+			return
+		}
+		stream.token++
+		if stream.rejectEvents {
+			fmt.Fprintf(w, `{"nextSequenceToken":"%d","rejectedLogEventsInfo":{"tooOldLogEventEndIndex":%d}}`, stream.token, len(input.LogEvents))
+			return
+		}
+		stream.events = append(stream.events, input.LogEvents...)
+		fmt.Fprintf(w, `{"nextSequenceToken":"%d"}`, stream.token)
+		return
+	default:
+		http.Error(w, "unsupported action "+action, http.StatusBadRequest)
+		return
+	}
+	fmt.Fprint(w, `{}`)
+}
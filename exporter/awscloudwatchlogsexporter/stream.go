@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	// https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutLogEvents.html
+	maxEventsPerBatch   = 10000
+	maxBatchBytes       = 1024 * 1024
+	maxEventBytes       = 256 * 1024
+	perEventHeaderBytes = 26
+	maxBatchSpan        = 24 * time.Hour
+
+	// undefinedName replaces the placeholders of the resource attributes that can't be found.
+	undefinedName = "undefined"
+)
+
+var namePlaceholder = regexp.MustCompile(`{[^{}]+}`)
+
+// streamKey identifies a log stream within its log group.
+type streamKey struct {
+	group  string
+	stream string
+}
+
+// resourceStreamKey returns the stream the logs of the resource are put to.
+func resourceStreamKey(config *Config, resource pdata.Resource) streamKey {
+	return streamKey{
+		group:  resolveName(config.LogGroupName, resource.Attributes()),
+		stream: resolveName(config.LogStreamName, resource.Attributes()),
+	}
+}
+
+// resolveName replaces the `{attribute}` placeholders of a log group or stream name with
+// the values of the resource attributes.
+func resolveName(name string, attrs pdata.AttributeMap) string {
+	if !strings.Contains(name, "{") {
+		return name
+	}
+	return namePlaceholder.ReplaceAllStringFunc(name, func(placeholder string) string {
+		value, ok := attrs.Get(placeholder[1 : len(placeholder)-1])
+		if !ok {
+			return undefinedName
+		}
+		var s string
+		if value.Type() == pdata.AttributeValueTypeString {
+			s = value.StringVal()
+		} else if v := attrValue(value); v != nil {
+			s = fmt.Sprint(v)
+		}
+		if s == "" {
+			return undefinedName
+		}
+		return s
+	})
+}
+
+// eventBytes returns the size of the event as accounted by the PutLogEvents limits.
+func eventBytes(event *cloudwatchlogs.InputLogEvent) int {
+	return len(*event.Message) + perEventHeaderBytes
+}
+
+// batchLogEvents sorts the events of a stream chronologically, as required by PutLogEvents,
+// and splits them into batches within the PutLogEvents limits.
+func batchLogEvents(events []*cloudwatchlogs.InputLogEvent) [][]*cloudwatchlogs.InputLogEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		return *events[i].Timestamp < *events[j].Timestamp
+	})
+
+	var batches [][]*cloudwatchlogs.InputLogEvent
+	var batch []*cloudwatchlogs.InputLogEvent
+	var batchBytes int
+	for _, event := range events {
+		size := eventBytes(event)
+		if len(batch) > 0 && (len(batch) == maxEventsPerBatch ||
+			batchBytes+size > maxBatchBytes ||
+			*event.Timestamp-*batch[0].Timestamp >= maxBatchSpan.Milliseconds()) {
+			batches = append(batches, batch)
+			batch = nil
+			batchBytes = 0
+		}
+		batch = append(batch, event)
+		batchBytes += size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestResolveName(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("k8s.cluster.name", "prod")
+	attrs.InsertString("k8s.namespace.name", "default")
+	attrs.InsertString("empty", "")
+	attrs.InsertInt("shard", 3)
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{name: "static", tmpl: "/eks/logs", want: "/eks/logs"},
+		{name: "placeholders", tmpl: "/eks/{k8s.cluster.name}/{k8s.namespace.name}", want: "/eks/prod/default"},
+		{name: "int attribute", tmpl: "shard-{shard}", want: "shard-3"},
+		{name: "missing attribute", tmpl: "{k8s.pod.name}", want: "undefined"},
+		{name: "empty attribute", tmpl: "{empty}", want: "undefined"},
+		{name: "unclosed placeholder", tmpl: "{k8s.cluster.name", want: "{k8s.cluster.name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveName(tt.tmpl, attrs))
+		})
+	}
+}
+
+func TestBatchLogEvents(t *testing.T) {
+	event := func(ts time.Duration, size int) *cloudwatchlogs.InputLogEvent {
+		return &cloudwatchlogs.InputLogEvent{
+			Timestamp: aws.Int64(ts.Milliseconds()),
+			Message:   aws.String(strings.Repeat("x", size)),
+		}
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		batches := batchLogEvents([]*cloudwatchlogs.InputLogEvent{
+			event(2*time.Millisecond, 1),
+			event(1*time.Millisecond, 1),
+			event(3*time.Millisecond, 1),
+		})
+		assert.Len(t, batches, 1)
+		var timestamps []int64
+		for _, e := range batches[0] {
+			timestamps = append(timestamps, *e.Timestamp)
+		}
+		assert.Equal(t, []int64{1, 2, 3}, timestamps)
+	})
+
+	t.Run("event count", func(t *testing.T) {
+		var events []*cloudwatchlogs.InputLogEvent
+		for i := 0; i < maxEventsPerBatch+1; i++ {
+			events = append(events, event(time.Millisecond, 1))
+		}
+		batches := batchLogEvents(events)
+		assert.Len(t, batches, 2)
+		assert.Len(t, batches[0], maxEventsPerBatch)
+		assert.Len(t, batches[1], 1)
+	})
+
+	t.Run("payload size", func(t *testing.T) {
+		var events []*cloudwatchlogs.InputLogEvent
+		for i := 0; i < 5; i++ {
+			events = append(events, event(time.Millisecond, maxEventBytes-perEventHeaderBytes))
+		}
+		batches := batchLogEvents(events)
+		assert.Len(t, batches, 2)
+		assert.Len(t, batches[0], 4)
+		assert.Len(t, batches[1], 1)
+	})
+
+	t.Run("time span", func(t *testing.T) {
+		batches := batchLogEvents([]*cloudwatchlogs.InputLogEvent{
+			event(0, 1),
+			event(time.Hour, 1),
+			event(25*time.Hour, 1),
+		})
+		assert.Len(t, batches, 2)
+		assert.Len(t, batches[0], 2)
+		assert.Len(t, batches[1], 1)
+	})
+}
@@ -15,6 +15,7 @@
 package awsemfexporter
 
 import (
+	"math"
 	"time"
 
 	"go.opentelemetry.io/collector/model/pdata"
@@ -23,6 +24,9 @@ import (
 	aws "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics"
 )
 
+// maxHistogramValues is the maximum number of values (and counts) of a CloudWatch distribution
+const maxHistogramValues = 100
+
 var deltaMetricCalculator = aws.NewFloat64DeltaCalculator()
 var summaryMetricCalculator = aws.NewMetricCalculator(calculateSummaryDelta)
 
@@ -150,15 +154,87 @@ func (dps histogramDataPointSlice) At(i int) (dataPoint, bool) {
 	timestamp := unixNanoToMilliseconds(metric.Timestamp())
 
 	return dataPoint{
-		value: &cWMetricStats{
-			Count: metric.Count(),
-			Sum:   metric.Sum(),
-		},
+		value:       convertHistogram(metric),
 		labels:      labels,
 		timestampMs: timestamp,
 	}, true
 }
 
+// convertHistogram converts the buckets of the HistogramDataPoint into a CloudWatch distribution,
+// so that CloudWatch can compute percentiles. Each non-empty bucket is represented by the midpoint
+// of its bounds, the unbounded first and last buckets by their finite bound. Min and Max are the
+// bounds of the outermost non-empty buckets, as the data point does not record the actual extremes,
+// so they are only approximations. Adjacent buckets are merged when there are more than
+// maxHistogramValues non-empty buckets. Data points without valid buckets fall back to their
+// count and sum.
+func convertHistogram(metric pdata.HistogramDataPoint) interface{} {
+	bounds := metric.ExplicitBounds()
+	counts := metric.BucketCounts()
+	stats := &cWMetricStats{
+		Count: metric.Count(),
+		Sum:   metric.Sum(),
+	}
+	if len(bounds) == 0 || len(counts) != len(bounds)+1 {
+		return stats
+	}
+
+	histogram := &cWMetricHistogram{
+		Values: []float64{},
+		Counts: []float64{},
+		Max:    math.Inf(-1),
+		Min:    math.Inf(1),
+		Count:  metric.Count(),
+		Sum:    metric.Sum(),
+	}
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		lower := bounds[0]
+		if i > 0 {
+			lower = bounds[i-1]
+		}
+		upper := bounds[len(bounds)-1]
+		if i < len(bounds) {
+			upper = bounds[i]
+		}
+		histogram.Values = append(histogram.Values, (lower+upper)/2)
+		histogram.Counts = append(histogram.Counts, float64(count))
+		histogram.Min = math.Min(histogram.Min, lower)
+		histogram.Max = math.Max(histogram.Max, upper)
+	}
+	if len(histogram.Values) == 0 {
+		return stats
+	}
+	histogram.Values, histogram.Counts = mergeHistogramValues(histogram.Values, histogram.Counts, maxHistogramValues)
+	return histogram
+}
+
+// mergeHistogramValues merges runs of adjacent values into at most maxValues values, each one
+// being the mean of the merged values weighted by their counts.
+func mergeHistogramValues(values []float64, counts []float64, maxValues int) ([]float64, []float64) {
+	if len(values) <= maxValues {
+		return values, counts
+	}
+	size := (len(values) + maxValues - 1) / maxValues
+	mergedValues := make([]float64, 0, maxValues)
+	mergedCounts := make([]float64, 0, maxValues)
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+		var total, weighted float64
+		for i := start; i < end; i++ {
+			total += counts[i]
+			weighted += values[i] * counts[i]
+		}
+		mergedValues = append(mergedValues, weighted/total)
+		mergedCounts = append(mergedCounts, total)
+	}
+	return mergedValues, mergedCounts
+}
+
 // At retrieves the SummaryDataPoint at the given index.
 func (dps summaryDataPointSlice) At(i int) (dataPoint, bool) {
 	metric := dps.SummaryDataPointSlice.At(i)
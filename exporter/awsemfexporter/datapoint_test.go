@@ -425,6 +425,141 @@ func TestHistogramDataPointSliceAt(t *testing.T) {
 	assert.Equal(t, expectedDP, dp)
 }
 
+func TestConvertHistogram(t *testing.T) {
+	testCases := []struct {
+		testName string
+		bounds   []float64
+		counts   []uint64
+		expected interface{}
+	}{
+		{
+			"buckets",
+			[]float64{0, 10, 20},
+			[]uint64{1, 2, 0, 3},
+			&cWMetricHistogram{
+				Values: []float64{0, 5, 20},
+				Counts: []float64{1, 2, 3},
+				Max:    20,
+				Min:    0,
+				Count:  6,
+				Sum:    40,
+			},
+		},
+		{
+			"inner buckets only",
+			[]float64{1, 2, 4},
+			[]uint64{0, 2, 4, 0},
+			&cWMetricHistogram{
+				Values: []float64{1.5, 3},
+				Counts: []float64{2, 4},
+				Max:    4,
+				Min:    1,
+				Count:  6,
+				Sum:    40,
+			},
+		},
+		{
+			"empty buckets",
+			[]float64{0, 10},
+			[]uint64{0, 0, 0},
+			&cWMetricStats{
+				Count: 6,
+				Sum:   40,
+			},
+		},
+		{
+			"no bounds",
+			nil,
+			[]uint64{6},
+			&cWMetricStats{
+				Count: 6,
+				Sum:   40,
+			},
+		},
+		{
+			"mismatched buckets",
+			[]float64{0, 10},
+			[]uint64{1, 2},
+			&cWMetricStats{
+				Count: 6,
+				Sum:   40,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			dp := pdata.NewHistogramDataPoint()
+			dp.SetCount(6)
+			dp.SetSum(40)
+			dp.SetExplicitBounds(tc.bounds)
+			dp.SetBucketCounts(tc.counts)
+
+			assert.Equal(t, tc.expected, convertHistogram(dp))
+		})
+	}
+}
+
+func TestConvertHistogramMaxValues(t *testing.T) {
+	bounds := make([]float64, 250)
+	counts := make([]uint64, 251)
+	for i := range bounds {
+		bounds[i] = float64(i)
+		counts[i] = 1
+	}
+	counts[250] = 1
+
+	dp := pdata.NewHistogramDataPoint()
+	dp.SetCount(251)
+	dp.SetSum(31375)
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(counts)
+
+	histogram := convertHistogram(dp).(*cWMetricHistogram)
+	assert.Len(t, histogram.Values, 84)
+	assert.Len(t, histogram.Counts, 84)
+	total := 0.0
+	for _, count := range histogram.Counts {
+		total += count
+	}
+	assert.Equal(t, float64(251), total)
+	assert.Equal(t, float64(0), histogram.Min)
+	assert.Equal(t, float64(249), histogram.Max)
+}
+
+func TestMergeHistogramValues(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		values         []float64
+		counts         []float64
+		expectedValues []float64
+		expectedCounts []float64
+	}{
+		{
+			"below maximum",
+			[]float64{1, 2},
+			[]float64{3, 4},
+			[]float64{1, 2},
+			[]float64{3, 4},
+		},
+		{
+			"weighted by counts",
+			[]float64{1, 2, 3, 4, 5},
+			[]float64{3, 1, 2, 2, 5},
+			[]float64{1.25, 3.5, 5},
+			[]float64{4, 4, 5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			values, counts := mergeHistogramValues(tc.values, tc.counts, 3)
+			assert.Equal(t, tc.expectedValues, values)
+			assert.Equal(t, tc.expectedCounts, counts)
+		})
+	}
+}
+
 func TestSummaryDataPointSliceAt(t *testing.T) {
 	setupDataPointCache()
 
@@ -34,7 +34,8 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithMetrics(createMetricsExporter))
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
 }
 
 // CreateDefaultConfig creates the default configuration for exporter.
@@ -63,3 +64,13 @@ func createMetricsExporter(_ context.Context,
 
 	return newEmfExporter(expCfg, params)
 }
+
+// createLogsExporter creates a logs exporter based on this config.
+func createLogsExporter(_ context.Context,
+	params component.ExporterCreateSettings,
+	config config.Exporter) (component.LogsExporter, error) {
+
+	expCfg := config.(*Config)
+
+	return newEmfLogsExporter(expCfg, params)
+}
@@ -61,3 +61,17 @@ func TestCreateMetricsExporter(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, exporter)
 }
+
+func TestCreateLogsExporter(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	exporter, err := factory.CreateLogsExporter(ctx, componenttest.NewNopExporterCreateSettings(), cfg.Exporters[config.NewIDWithName(typeStr, "1")])
+	assert.Nil(t, err)
+	assert.NotNil(t, exporter)
+}
@@ -100,9 +100,13 @@ func TestAddToGroupedMetric(t *testing.T) {
 			[]*metricspb.Metric{generateTestHistogram("foo")},
 			map[string]*metricInfo{
 				"foo": {
-					value: &cWMetricStats{
-						Count: 18,
-						Sum:   35.0,
+					value: &cWMetricHistogram{
+						Values: []float64{0, 5, 10},
+						Counts: []float64{5, 6, 7},
+						Max:    10,
+						Min:    0,
+						Count:  18,
+						Sum:    35.0,
 					},
 					unit: "Seconds",
 				},
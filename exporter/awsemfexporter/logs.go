@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// cWLogEntry is the JSON representation of a log record sent to CloudWatch Logs.
+type cWLogEntry struct {
+	Body           interface{}            `json:"body"`
+	Name           string                 `json:"name,omitempty"`
+	SeverityNumber int32                  `json:"severity_number,omitempty"`
+	SeverityText   string                 `json:"severity_text,omitempty"`
+	TraceID        string                 `json:"trace_id,omitempty"`
+	SpanID         string                 `json:"span_id,omitempty"`
+	Flags          uint32                 `json:"flags,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+	Resource       map[string]interface{} `json:"resource,omitempty"`
+}
+
+// newEmfLogsExporter creates a new logs exporter using exporterhelper
+func newEmfLogsExporter(
+	config config.Exporter,
+	set component.ExporterCreateSettings,
+) (component.LogsExporter, error) {
+	exp, err := newEmfPusher(config, set)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		config,
+		set,
+		exp.(*emfExporter).pushLogsData,
+		exporterhelper.WithShutdown(exp.(*emfExporter).Shutdown),
+	)
+}
+
+func (emf *emfExporter) pushLogsData(_ context.Context, ld pdata.Logs) error {
+	expConfig := emf.config.(*Config)
+	defaultLogStream := fmt.Sprintf("otel-stream-%s", emf.collectorID)
+	outputDestination := expConfig.OutputDestination
+
+	var errs []error
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		logGroup, logStream := getLogsLogInfo(rl.Resource(), expConfig)
+		if logStream == "" {
+			logStream = defaultLogStream
+		}
+		resource := attributesToMap(rl.Resource().Attributes())
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				logEvent, err := translateLogRecordToLogEvent(logs.At(k), resource)
+				if err != nil {
+					errs = append(errs, consumererror.Permanent(err))
+					continue
+				}
+				// Currently we only support two options for "OutputDestination".
+				if strings.EqualFold(outputDestination, outputDestinationStdout) {
+					fmt.Println(*logEvent.inputLogEvent.Message)
+				} else if strings.EqualFold(outputDestination, outputDestinationCloudWatch) {
+					emfPusher := emf.getPusher(logGroup, logStream)
+					if emfPusher != nil {
+						returnError := emfPusher.addLogEntry(logEvent)
+						if returnError != nil {
+							return wrapErrorIfBadRequest(&returnError)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if strings.EqualFold(outputDestination, outputDestinationCloudWatch) {
+		for _, emfPusher := range emf.listPushers() {
+			returnError := emfPusher.forceFlush()
+			if returnError != nil {
+				err := wrapErrorIfBadRequest(&returnError)
+				emf.logger.Error("Error force flushing logs.", zap.Error(err))
+				return err
+			}
+		}
+	}
+
+	return consumererror.Combine(errs)
+}
+
+// getLogsLogInfo retrieves the log group and log stream names of the logs of the given resource.
+// The log group defaults to /logs/<namespace>, the namespace being resolved as for metrics.
+func getLogsLogInfo(resource pdata.Resource, config *Config) (logGroup, logStream string) {
+	if len(config.LogGroupName) > 0 {
+		logGroup = replacePatterns(config.LogGroupName, resource.Attributes(), config.logger)
+	} else {
+		logGroup = fmt.Sprintf("/logs/%s", getResourceNamespace(resource, config.Namespace))
+	}
+	if len(config.LogStreamName) > 0 {
+		logStream = replacePatterns(config.LogStreamName, resource.Attributes(), config.logger)
+	}
+	return
+}
+
+// translateLogRecordToLogEvent converts the log record to a JSON CloudWatch log event.
+func translateLogRecordToLogEvent(lr pdata.LogRecord, resource map[string]interface{}) (*logEvent, error) {
+	entry := cWLogEntry{
+		Body:           attributeValueToRaw(lr.Body()),
+		Name:           lr.Name(),
+		SeverityNumber: int32(lr.SeverityNumber()),
+		SeverityText:   lr.SeverityText(),
+		Flags:          lr.Flags(),
+		Attributes:     attributesToMap(lr.Attributes()),
+		Resource:       resource,
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		entry.TraceID = traceID.HexString()
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		entry.SpanID = spanID.HexString()
+	}
+
+	message, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log record: %w", err)
+	}
+
+	generatedTime := time.Now()
+	if lr.Timestamp() != 0 {
+		generatedTime = lr.Timestamp().AsTime()
+	}
+	logEvent := newLogEvent(generatedTime.UnixNano()/int64(time.Millisecond), string(message))
+	logEvent.logGeneratedTime = generatedTime
+
+	return logEvent, nil
+}
+
+// attributesToMap converts the attributes to a map of raw values, nil if there are none.
+func attributesToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = attributeValueToRaw(v)
+		return true
+	})
+	return m
+}
+
+func attributeValueToRaw(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		return v.StringVal()
+	case pdata.AttributeValueTypeInt:
+		return v.IntVal()
+	case pdata.AttributeValueTypeDouble:
+		return v.DoubleVal()
+	case pdata.AttributeValueTypeBool:
+		return v.BoolVal()
+	case pdata.AttributeValueTypeMap:
+		return attributesToMap(v.MapVal())
+	case pdata.AttributeValueTypeArray:
+		arr := v.ArrayVal()
+		values := make([]interface{}, arr.Len())
+		for i := 0; i < arr.Len(); i++ {
+			values[i] = attributeValueToRaw(arr.At(i))
+		}
+		return values
+	}
+	return nil
+}
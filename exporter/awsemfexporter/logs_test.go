@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+// capturingPusher records the log events added to it.
+type capturingPusher struct {
+	logEvents []*logEvent
+	flushes   int
+}
+
+func (p *capturingPusher) addLogEntry(logEvent *logEvent) error {
+	p.logEvents = append(p.logEvents, logEvent)
+	return nil
+}
+
+func (p *capturingPusher) forceFlush() error {
+	p.flushes++
+	return nil
+}
+
+func createTestLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("aws.ecs.cluster.name", "test-cluster-name")
+	rl.Resource().Attributes().InsertString("aws.ecs.task.id", "test-task-id")
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetName("test-log")
+	lr.Body().SetStringVal("hello world")
+	lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	lr.SetSeverityText("Info")
+	lr.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	lr.SetTimestamp(pdata.Timestamp(1596151098037 * 1e6))
+	lr.Attributes().InsertInt("status", 200)
+	return ld
+}
+
+func TestPushLogsData(t *testing.T) {
+	factory := NewFactory()
+	expCfg := factory.CreateDefaultConfig().(*Config)
+	expCfg.Region = "us-west-2"
+	expCfg.MaxRetries = 0
+	expCfg.LogGroupName = "/aws/ecs/{ClusterName}/application"
+	expCfg.LogStreamName = "{TaskId}"
+	exp, err := newEmfPusher(expCfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+
+	logPusher := &capturingPusher{}
+	exp.(*emfExporter).groupStreamToPusherMap["/aws/ecs/test-cluster-name/application"] = map[string]pusher{
+		"test-task-id": logPusher,
+	}
+
+	require.NoError(t, exp.(*emfExporter).pushLogsData(context.Background(), createTestLogs()))
+
+	require.Len(t, logPusher.logEvents, 1)
+	assert.Equal(t, 1, logPusher.flushes)
+	assert.Equal(t, int64(1596151098037), *logPusher.logEvents[0].inputLogEvent.Timestamp)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(*logPusher.logEvents[0].inputLogEvent.Message), &entry))
+	assert.Equal(t, map[string]interface{}{
+		"body":            "hello world",
+		"name":            "test-log",
+		"severity_number": float64(pdata.SeverityNumberINFO),
+		"severity_text":   "Info",
+		"trace_id":        "0102030405060708090a0b0c0d0e0f10",
+		"span_id":         "0102030405060708",
+		"attributes":      map[string]interface{}{"status": float64(200)},
+		"resource": map[string]interface{}{
+			"aws.ecs.cluster.name": "test-cluster-name",
+			"aws.ecs.task.id":      "test-task-id",
+		},
+	}, entry)
+}
+
+func TestPushLogsDataWithErr(t *testing.T) {
+	factory := NewFactory()
+	expCfg := factory.CreateDefaultConfig().(*Config)
+	expCfg.Region = "us-west-2"
+	expCfg.MaxRetries = 0
+	expCfg.LogGroupName = "test-logGroupName"
+	expCfg.LogStreamName = "test-logStreamName"
+	exp, err := newEmfPusher(expCfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+
+	logPusher := new(mockPusher)
+	logPusher.On("addLogEntry", nil).Return("some error").Once()
+	logPusher.On("addLogEntry", nil).Return("").Twice()
+	logPusher.On("forceFlush", nil).Return("some error").Once()
+	logPusher.On("forceFlush", nil).Return("").Once()
+	exp.(*emfExporter).groupStreamToPusherMap["test-logGroupName"] = map[string]pusher{"test-logStreamName": logPusher}
+
+	ld := createTestLogs()
+	err = exp.(*emfExporter).pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Error(t, exp.(*emfExporter).pushLogsData(context.Background(), ld))
+	assert.NoError(t, exp.(*emfExporter).pushLogsData(context.Background(), ld))
+}
+
+func TestGetLogsLogInfo(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		namespace         string
+		logGroupName      string
+		logStreamName     string
+		expectedLogGroup  string
+		expectedLogStream string
+	}{
+		{
+			"default",
+			"",
+			"",
+			"",
+			"/logs/myNS/myService",
+			"",
+		},
+		{
+			"namespace",
+			"myNamespace",
+			"",
+			"",
+			"/logs/myNamespace",
+			"",
+		},
+		{
+			"placeholders",
+			"",
+			"/aws/ecs/{ClusterName}",
+			"{TaskId}",
+			"/aws/ecs/test-cluster-name",
+			"test-task-id",
+		},
+		{
+			"missing placeholder",
+			"",
+			"/aws/eks/{NodeName}",
+			"stream",
+			"/aws/eks/undefined",
+			"stream",
+		},
+	}
+
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(conventions.AttributeServiceName, "myService")
+	resource.Attributes().InsertString(conventions.AttributeServiceNamespace, "myNS")
+	resource.Attributes().InsertString("aws.ecs.cluster.name", "test-cluster-name")
+	resource.Attributes().InsertString("aws.ecs.task.id", "test-task-id")
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			config := &Config{
+				Namespace:     tc.namespace,
+				LogGroupName:  tc.logGroupName,
+				LogStreamName: tc.logStreamName,
+				logger:        zap.NewNop(),
+			}
+			logGroup, logStream := getLogsLogInfo(resource, config)
+			assert.Equal(t, tc.expectedLogGroup, logGroup)
+			assert.Equal(t, tc.expectedLogStream, logStream)
+		})
+	}
+}
+
+func TestTranslateLogRecordToLogEvent(t *testing.T) {
+	lr := pdata.NewLogRecord()
+	body := pdata.NewAttributeValueMap()
+	body.MapVal().InsertString("message", "hello")
+	values := pdata.NewAttributeValueArray()
+	values.ArrayVal().AppendEmpty().SetBoolVal(true)
+	values.ArrayVal().AppendEmpty().SetDoubleVal(1.5)
+	body.MapVal().Insert("values", values)
+	body.CopyTo(lr.Body())
+
+	logEvent, err := translateLogRecordToLogEvent(lr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"body":{"message":"hello","values":[true,1.5]}}`, *logEvent.inputLogEvent.Message)
+	// Log records without timestamp are stamped with the time they are exported at.
+	assert.NotZero(t, *logEvent.inputLogEvent.Timestamp)
+}
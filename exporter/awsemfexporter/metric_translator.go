@@ -66,6 +66,16 @@ type cWMetricStats struct {
 	Sum   float64
 }
 
+// cWMetricHistogram is a CloudWatch distribution, where each of the Values is observed Counts times.
+type cWMetricHistogram struct {
+	Values []float64
+	Counts []float64
+	Max    float64
+	Min    float64
+	Count  uint64
+	Sum    float64
+}
+
 type groupedMetricMetadata struct {
 	namespace   string
 	timestampMs int64
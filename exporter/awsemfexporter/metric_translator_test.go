@@ -431,9 +431,13 @@ func TestTranslateOtToGroupedMetric(t *testing.T) {
 	}
 	timerMetrics := map[string]*metricInfo{
 		"spanTimer": {
-			value: &cWMetricStats{
-				Count: 5,
-				Sum:   15,
+			value: &cWMetricHistogram{
+				Values: []float64{5, 10},
+				Counts: []float64{4, 1},
+				Max:    10,
+				Min:    0,
+				Count:  5,
+				Sum:    15,
 			},
 			unit: "Seconds",
 		},
@@ -562,6 +566,34 @@ func TestTranslateCWMetricToEMF(t *testing.T) {
 	assert.Equal(t, readFromFile("testdata/testTranslateCWMetricToEMF.json"), *inputLogEvent.inputLogEvent.Message, "Expect to be equal")
 }
 
+func TestTranslateCWMetricToEMFHistogram(t *testing.T) {
+	met := &cWMetrics{
+		timestampMs: int64(1596151098037),
+		fields: map[string]interface{}{
+			"spanName": "test",
+			"spanTimer": &cWMetricHistogram{
+				Values: []float64{5, 10},
+				Counts: []float64{4, 1},
+				Max:    10,
+				Min:    0,
+				Count:  5,
+				Sum:    15,
+			},
+		},
+		measurements: []cWMeasurement{{
+			Namespace:  "test-emf",
+			Dimensions: [][]string{{"spanName"}},
+			Metrics: []map[string]string{{
+				"Name": "spanTimer",
+				"Unit": "Seconds",
+			}},
+		}},
+	}
+	inputLogEvent := translateCWMetricToEMF(met, &Config{logger: zap.NewNop()})
+
+	assert.Contains(t, *inputLogEvent.inputLogEvent.Message, `"spanTimer":{"Values":[5,10],"Counts":[4,1],"Max":10,"Min":0,"Count":5,"Sum":15}`)
+}
+
 func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 	timestamp := int64(1596151098037)
 	namespace := "Namespace"
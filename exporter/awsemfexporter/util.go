@@ -65,9 +65,14 @@ func replace(s, pattern string, value pdata.AttributeValue, logger *zap.Logger)
 
 // getNamespace retrieves namespace for given set of metrics from user config.
 func getNamespace(rm *pdata.ResourceMetrics, namespace string) string {
+	return getResourceNamespace(rm.Resource(), namespace)
+}
+
+// getResourceNamespace retrieves namespace for the given resource from user config.
+func getResourceNamespace(resource pdata.Resource, namespace string) string {
 	if len(namespace) == 0 {
-		serviceName, svcNameOk := rm.Resource().Attributes().Get(conventions.AttributeServiceName)
-		serviceNamespace, svcNsOk := rm.Resource().Attributes().Get(conventions.AttributeServiceNamespace)
+		serviceName, svcNameOk := resource.Attributes().Get(conventions.AttributeServiceName)
+		serviceNamespace, svcNsOk := resource.Attributes().Get(conventions.AttributeServiceNamespace)
 		if svcNameOk && svcNsOk && serviceName.Type() == pdata.AttributeValueTypeString && serviceNamespace.Type() == pdata.AttributeValueTypeString {
 			namespace = fmt.Sprintf("%s/%s", serviceNamespace.StringVal(), serviceName.StringVal())
 		} else if svcNameOk && serviceName.Type() == pdata.AttributeValueTypeString {
@@ -15,7 +15,14 @@
 package awskinesisexporter
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/compress"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/producer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/translate"
 )
 
 // AWSConfig contains AWS specific configuration such as awskinesis stream, region, etc.
@@ -26,6 +33,19 @@ type AWSConfig struct {
 	Role            string `mapstructure:"role"`
 }
 
+// Encoding defines how the telemetry data is written in the records.
+type Encoding struct {
+	// Name is the encoding of the record payloads, either otlp_proto, otlp_json,
+	// jaeger_proto, jaeger_proto_batch or zipkin_json. The jaeger and zipkin encodings only
+	// support traces. Defaults to jaeger_proto for traces, and otlp_proto for metrics and logs.
+	// The jaeger_proto spans are aggregated and compressed by the Kinesis producer library.
+	Name string `mapstructure:"name"`
+
+	// Compression is applied to each record payload, either none, gzip, zlib or flate.
+	// Not supported by the jaeger_proto encoding.
+	Compression string `mapstructure:"compression"`
+}
+
 // KPLConfig contains awskinesis producer library related config to controls things
 // like aggregation, batching, connections, retries, etc.
 type KPLConfig struct {
@@ -42,9 +62,15 @@ type KPLConfig struct {
 
 // Config contains the main configuration options for the awskinesis exporter
 type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"`
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	AWS      AWSConfig `mapstructure:"aws"`
+	Encoding Encoding  `mapstructure:"encoding"`
 
-	AWS AWSConfig `mapstructure:"aws"`
+	// The Kinesis producer library settings, used by the jaeger_proto traces.
 	KPL KPLConfig `mapstructure:"kpl"`
 
 	QueueSize            int `mapstructure:"queue_size"`
@@ -52,4 +78,35 @@ type Config struct {
 	MaxBytesPerBatch     int `mapstructure:"max_bytes_per_batch"`
 	MaxBytesPerSpan      int `mapstructure:"max_bytes_per_span"`
 	FlushIntervalSeconds int `mapstructure:"flush_interval_seconds"`
+
+	// PartitionKey selects the partition key of the records: "traceID" (traces and logs only),
+	// "service" for the service name, or the name of a resource attribute.
+	// The records are spread randomly over the shards if empty, or if the resource attribute is missing.
+	// Not supported by the jaeger_proto encoding, which partitions the spans by trace ID.
+	PartitionKey string `mapstructure:"partition_key"`
+
+	// MaxRecordsPerBatch is the maximum number of records put to the stream in a single request.
+	MaxRecordsPerBatch int `mapstructure:"max_records_per_batch"`
+
+	// MaxRecordSize is the maximum size of the records, larger records are dropped.
+	MaxRecordSize int `mapstructure:"max_record_size"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Encoding.Name != "" && c.Encoding.Name != translate.JaegerProto {
+		if _, err := translate.NewEncoder(c.Encoding.Name); err != nil {
+			return err
+		}
+	}
+	if _, err := compress.NewCompressor(c.Encoding.Compression); err != nil {
+		return err
+	}
+	if c.MaxRecordsPerBatch < 1 || c.MaxRecordsPerBatch > producer.MaxRecordsPerBatch {
+		return fmt.Errorf("max_records_per_batch must be between 1 and %d", producer.MaxRecordsPerBatch)
+	}
+	if c.MaxRecordSize < 1 || c.MaxRecordSize > producer.MaxRecordSize {
+		return fmt.Errorf("max_record_size must be between 1 and %d", producer.MaxRecordSize)
+	}
+	return nil
 }
@@ -17,6 +17,7 @@ package awskinesisexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -41,9 +43,15 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, e,
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+			TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
+			QueueSettings:    exporterhelper.DefaultQueueSettings(),
+			RetrySettings:    exporterhelper.DefaultRetrySettings(),
 			AWS: AWSConfig{
 				Region: "us-west-2",
 			},
+			Encoding: Encoding{
+				Compression: "none",
+			},
 			KPL: KPLConfig{
 				BatchSize:            5242880,
 				BatchCount:           1000,
@@ -57,6 +65,9 @@ func TestDefaultConfig(t *testing.T) {
 			FlushIntervalSeconds: 5,
 			MaxBytesPerBatch:     100000,
 			MaxBytesPerSpan:      900000,
+
+			MaxRecordsPerBatch: 500,
+			MaxRecordSize:      1048576,
 		},
 	)
 }
@@ -74,15 +85,26 @@ func TestConfig(t *testing.T) {
 
 	e := cfg.Exporters[config.NewID(typeStr)]
 
+	queueSettings := exporterhelper.DefaultQueueSettings()
+	queueSettings.Enabled = false
+	retrySettings := exporterhelper.DefaultRetrySettings()
+	retrySettings.Enabled = false
 	assert.Equal(t, e,
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+			TimeoutSettings:  exporterhelper.TimeoutSettings{Timeout: 20 * time.Second},
+			QueueSettings:    queueSettings,
+			RetrySettings:    retrySettings,
 			AWS: AWSConfig{
 				StreamName:      "test-stream",
 				KinesisEndpoint: "awskinesis.mars-1.aws.galactic",
 				Region:          "mars-1",
 				Role:            "arn:test-role",
 			},
+			Encoding: Encoding{
+				Name:        "otlp_json",
+				Compression: "gzip",
+			},
 			KPL: KPLConfig{
 				AggregateBatchCount:  10,
 				AggregateBatchSize:   11,
@@ -100,10 +122,42 @@ func TestConfig(t *testing.T) {
 			FlushIntervalSeconds: 3,
 			MaxBytesPerBatch:     4,
 			MaxBytesPerSpan:      5,
+
+			PartitionKey:       "service",
+			MaxRecordsPerBatch: 10,
+			MaxRecordSize:      1000,
 		},
 	)
 }
 
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{name: "default", modify: func(*Config) {}},
+		{name: "jaeger encoding", modify: func(c *Config) { c.Encoding.Name = "jaeger_proto" }},
+		{name: "jaeger batch encoding", modify: func(c *Config) { c.Encoding.Name = "jaeger_proto_batch" }},
+		{name: "unknown encoding", modify: func(c *Config) { c.Encoding.Name = "avro" }, wantErr: `unknown encoding "avro"`},
+		{name: "unknown compression", modify: func(c *Config) { c.Encoding.Compression = "lz4" }, wantErr: `unknown compression format "lz4"`},
+		{name: "too many records per batch", modify: func(c *Config) { c.MaxRecordsPerBatch = 501 }, wantErr: "max_records_per_batch must be between 1 and 500"},
+		{name: "record size too large", modify: func(c *Config) { c.MaxRecordSize = 2 << 20 }, wantErr: "max_record_size must be between 1 and 1048576"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createDefaultConfig().(*Config)
+			tt.modify(c)
+			err := c.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestConfigCheck(t *testing.T) {
 	cfg := (NewFactory()).CreateDefaultConfig()
 	assert.NoError(t, configcheck.ValidateConfig(cfg))
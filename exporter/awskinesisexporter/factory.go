@@ -16,12 +16,15 @@ package awskinesisexporter
 
 import (
 	"context"
+	"errors"
 
 	awskinesis "github.com/signalfx/opencensus-go-exporter-kinesis"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/compress"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/producer"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/translate"
 )
 
@@ -31,20 +34,31 @@ const (
 	exportFormat = "jaeger-proto"
 )
 
+var errJaegerProtoSettings = errors.New("partition_key and compression are not supported by the jaeger_proto encoding of the traces, " +
+	"the spans being partitioned by trace ID and compressed by the Kinesis producer library")
+
 // NewFactory creates a factory for Kinesis exporter.
 func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithTraces(createTracesExporter))
+		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
 }
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
+		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		RetrySettings:    exporterhelper.DefaultRetrySettings(),
 		AWS: AWSConfig{
 			Region: "us-west-2",
 		},
+		Encoding: Encoding{
+			Compression: compress.None,
+		},
 		KPL: KPLConfig{
 			BatchSize:            5242880,
 			BatchCount:           1000,
@@ -58,6 +72,9 @@ func createDefaultConfig() config.Exporter {
 		FlushIntervalSeconds: 5,
 		MaxBytesPerBatch:     100000,
 		MaxBytesPerSpan:      900000,
+
+		MaxRecordsPerBatch: producer.MaxRecordsPerBatch,
+		MaxRecordSize:      producer.MaxRecordSize,
 	}
 }
 
@@ -67,6 +84,31 @@ func createTracesExporter(
 	config config.Exporter,
 ) (component.TracesExporter, error) {
 	c := config.(*Config)
+	if c.Encoding.Name == "" || c.Encoding.Name == translate.JaegerProto {
+		return createJaegerTracesExporter(c, params)
+	}
+	exp, err := newRecordExporter(c, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewTracesExporter(
+		c,
+		params,
+		exp.pushTraces,
+		exporterhelper.WithTimeout(c.TimeoutSettings),
+		exporterhelper.WithQueue(c.QueueSettings),
+		exporterhelper.WithRetry(c.RetrySettings),
+	)
+}
+
+// createJaegerTracesExporter creates the exporter of the jaeger_proto traces, writing the spans
+// with the Kinesis producer library, which aggregates and compresses them by trace ID.
+func createJaegerTracesExporter(c *Config, params component.ExporterCreateSettings) (component.TracesExporter, error) {
+	if c.PartitionKey != "" || (c.Encoding.Compression != "" && c.Encoding.Compression != compress.None) {
+		return nil, errJaegerProtoSettings
+	}
+
 	k, err := awskinesis.NewExporter(&awskinesis.Options{
 		Name:               c.ID().String(),
 		StreamName:         c.AWS.StreamName,
@@ -101,3 +143,48 @@ func createTracesExporter(
 		logger:     params.Logger,
 	}, nil
 }
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateSettings,
+	config config.Exporter,
+) (component.MetricsExporter, error) {
+	c := config.(*Config)
+	if c.PartitionKey == partitionByTraceID {
+		return nil, errTraceIDPartitionForMetrics
+	}
+	exp, err := newRecordExporter(c, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		c,
+		params,
+		exp.pushMetrics,
+		exporterhelper.WithTimeout(c.TimeoutSettings),
+		exporterhelper.WithQueue(c.QueueSettings),
+		exporterhelper.WithRetry(c.RetrySettings),
+	)
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateSettings,
+	config config.Exporter,
+) (component.LogsExporter, error) {
+	c := config.(*Config)
+	exp, err := newRecordExporter(c, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		c,
+		params,
+		exp.pushLogs,
+		exporterhelper.WithTimeout(c.TimeoutSettings),
+		exporterhelper.WithQueue(c.QueueSettings),
+		exporterhelper.WithRetry(c.RetrySettings),
+	)
+}
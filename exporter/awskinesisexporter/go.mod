@@ -3,6 +3,8 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskin
 go 1.16
 
 require (
+	github.com/aws/aws-sdk-go v1.40.8
+	github.com/jaegertracing/jaeger v1.24.0
 	github.com/mattn/go-colorable v0.1.7 // indirect
 	github.com/signalfx/opencensus-go-exporter-kinesis v0.6.3
 	github.com/stretchr/testify v1.7.0
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// Supported compression formats.
+const (
+	None  = "none"
+	Gzip  = "gzip"
+	Zlib  = "zlib"
+	Flate = "flate"
+)
+
+// Compressor compresses the payload of each record.
+type Compressor interface {
+	Compress(in []byte) ([]byte, error)
+}
+
+// NewCompressor returns the compressor for the format, no compression being
+// applied if the format is empty.
+func NewCompressor(format string) (Compressor, error) {
+	switch format {
+	case "", None:
+		return noop{}, nil
+	case Gzip:
+		return writerCompressor(func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		}), nil
+	case Zlib:
+		return writerCompressor(func(w io.Writer) (io.WriteCloser, error) {
+			return zlib.NewWriter(w), nil
+		}), nil
+	case Flate:
+		return writerCompressor(func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.DefaultCompression)
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown compression format %q", format)
+}
+
+type noop struct{}
+
+func (noop) Compress(in []byte) ([]byte, error) {
+	return in, nil
+}
+
+type writerCompressor func(w io.Writer) (io.WriteCloser, error)
+
+func (newWriter writerCompressor) Compress(in []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := newWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(in); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/compress"
+)
+
+func TestCompressors(t *testing.T) {
+	payload := bytes.Repeat([]byte("telemetry data "), 100)
+
+	tests := []struct {
+		format    string
+		newReader func(r io.Reader) (io.Reader, error)
+	}{
+		{format: "", newReader: func(r io.Reader) (io.Reader, error) { return r, nil }},
+		{format: compress.None, newReader: func(r io.Reader) (io.Reader, error) { return r, nil }},
+		{format: compress.Gzip, newReader: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{format: compress.Zlib, newReader: func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }},
+		{format: compress.Flate, newReader: func(r io.Reader) (io.Reader, error) { return flate.NewReader(r), nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			c, err := compress.NewCompressor(tt.format)
+			require.NoError(t, err)
+
+			compressed, err := c.Compress(payload)
+			require.NoError(t, err)
+
+			r, err := tt.newReader(bytes.NewReader(compressed))
+			require.NoError(t, err)
+			decompressed, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, payload, decompressed)
+		})
+	}
+}
+
+func TestUnknownCompressor(t *testing.T) {
+	_, err := compress.NewCompressor("lz4")
+	assert.EqualError(t, err, `unknown compression format "lz4"`)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	// https://docs.aws.amazon.com/kinesis/latest/APIReference/API_PutRecords.html
+	MaxRecordsPerBatch = 500
+	MaxBytesPerBatch   = 5 * 1024 * 1024
+	MaxRecordSize      = 1024 * 1024
+)
+
+// Producer puts records to a Kinesis stream, in batches within the PutRecords limits.
+type Producer struct {
+	client             kinesisiface.KinesisAPI
+	stream             *string
+	maxRecordsPerBatch int
+}
+
+// New creates a producer putting records to the stream, at most maxRecordsPerBatch at a time.
+func New(client kinesisiface.KinesisAPI, stream string, maxRecordsPerBatch int) *Producer {
+	if maxRecordsPerBatch <= 0 || maxRecordsPerBatch > MaxRecordsPerBatch {
+		maxRecordsPerBatch = MaxRecordsPerBatch
+	}
+	return &Producer{
+		client:             client,
+		stream:             aws.String(stream),
+		maxRecordsPerBatch: maxRecordsPerBatch,
+	}
+}
+
+// Put puts the records to the stream, and returns the records that could not be put
+// along with the error, so that only those are retried.
+func (p *Producer) Put(ctx context.Context, records []*kinesis.PutRecordsRequestEntry) ([]*kinesis.PutRecordsRequestEntry, error) {
+	var failed []*kinesis.PutRecordsRequestEntry
+	var errs []error
+	for _, batch := range p.batch(records) {
+		batchFailed, err := p.putBatch(ctx, batch)
+		if err != nil {
+			failed = append(failed, batchFailed...)
+			errs = append(errs, err)
+		}
+	}
+	return failed, consumererror.Combine(errs)
+}
+
+func (p *Producer) batch(records []*kinesis.PutRecordsRequestEntry) [][]*kinesis.PutRecordsRequestEntry {
+	var batches [][]*kinesis.PutRecordsRequestEntry
+	var batch []*kinesis.PutRecordsRequestEntry
+	var batchBytes int
+	for _, record := range records {
+		size := len(record.Data) + len(aws.StringValue(record.PartitionKey))
+		if len(batch) > 0 && (len(batch) == p.maxRecordsPerBatch || batchBytes+size > MaxBytesPerBatch) {
+			batches = append(batches, batch)
+			batch = nil
+			batchBytes = 0
+		}
+		batch = append(batch, record)
+		batchBytes += size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// putBatch puts a batch of records, and returns the records that failed,
+// either all of them if the request failed, or those with an error code.
+func (p *Producer) putBatch(ctx context.Context, batch []*kinesis.PutRecordsRequestEntry) ([]*kinesis.PutRecordsRequestEntry, error) {
+	out, err := p.client.PutRecordsWithContext(ctx, &kinesis.PutRecordsInput{
+		StreamName: p.stream,
+		Records:    batch,
+	})
+	if err != nil {
+		return batch, err
+	}
+	if aws.Int64Value(out.FailedRecordCount) == 0 {
+		return nil, nil
+	}
+
+	// the results are in the order of the records of the request
+	var failed []*kinesis.PutRecordsRequestEntry
+	var firstErr *kinesis.PutRecordsResultEntry
+	for i, result := range out.Records {
+		if result.ErrorCode == nil || i >= len(batch) {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = result
+		}
+		failed = append(failed, batch[i])
+	}
+	if firstErr == nil {
+		// the failed records can't be identified
+		return batch, fmt.Errorf("failed to put %d of %d records", aws.Int64Value(out.FailedRecordCount), len(batch))
+	}
+	// the error of the first failed record is reported, the others usually failing for the same reason
+	return failed, fmt.Errorf("failed to put %d of %d records: %s: %s",
+		len(failed), len(batch), aws.StringValue(firstErr.ErrorCode), aws.StringValue(firstErr.ErrorMessage))
+}
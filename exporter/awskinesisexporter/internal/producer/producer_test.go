@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/producer"
+)
+
+type mockKinesis struct {
+	kinesisiface.KinesisAPI
+
+	inputs  []*kinesis.PutRecordsInput
+	respond func(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error)
+}
+
+func (m *mockKinesis) PutRecordsWithContext(_ aws.Context, input *kinesis.PutRecordsInput, _ ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	m.inputs = append(m.inputs, input)
+	if m.respond != nil {
+		return m.respond(input)
+	}
+	return &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int64(0)}, nil
+}
+
+func records(n int, size int) []*kinesis.PutRecordsRequestEntry {
+	var out []*kinesis.PutRecordsRequestEntry
+	for i := 0; i < n; i++ {
+		out = append(out, &kinesis.PutRecordsRequestEntry{
+			Data:         bytes.Repeat([]byte{'x'}, size),
+			PartitionKey: aws.String("key"),
+		})
+	}
+	return out
+}
+
+func TestPutBatchesByCount(t *testing.T) {
+	client := &mockKinesis{}
+	p := producer.New(client, "stream", 10)
+
+	failed, err := p.Put(context.Background(), records(25, 1))
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+	require.Len(t, client.inputs, 3)
+	assert.Len(t, client.inputs[0].Records, 10)
+	assert.Len(t, client.inputs[2].Records, 5)
+	assert.Equal(t, "stream", aws.StringValue(client.inputs[0].StreamName))
+}
+
+func TestPutBatchesBySize(t *testing.T) {
+	client := &mockKinesis{}
+	p := producer.New(client, "stream", 0)
+
+	_, err := p.Put(context.Background(), records(6, producer.MaxRecordSize-3))
+	require.NoError(t, err)
+	require.Len(t, client.inputs, 2)
+	assert.Len(t, client.inputs[0].Records, 5)
+	assert.Len(t, client.inputs[1].Records, 1)
+}
+
+func TestPutFailures(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		client := &mockKinesis{respond: func(*kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+			return nil, errors.New("boom")
+		}}
+		input := records(2, 1)
+		failed, err := producer.New(client, "stream", 0).Put(context.Background(), input)
+		assert.EqualError(t, err, "boom")
+		assert.Equal(t, input, failed)
+	})
+
+	t.Run("failed records", func(t *testing.T) {
+		client := &mockKinesis{respond: func(*kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+			return &kinesis.PutRecordsOutput{
+				FailedRecordCount: aws.Int64(1),
+				Records: []*kinesis.PutRecordsResultEntry{
+					{SequenceNumber: aws.String("1")},
+					{ErrorCode: aws.String("ProvisionedThroughputExceededException"), ErrorMessage: aws.String("slow down")},
+				},
+			}, nil
+		}}
+		input := records(2, 1)
+		failed, err := producer.New(client, "stream", 0).Put(context.Background(), input)
+		assert.EqualError(t, err, "failed to put 1 of 2 records: ProvisionedThroughputExceededException: slow down")
+		assert.Equal(t, input[1:], failed)
+	})
+
+	t.Run("successful batches", func(t *testing.T) {
+		client := &mockKinesis{respond: func(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+			if aws.StringValue(input.Records[0].PartitionKey) == "failing" {
+				return nil, errors.New("boom")
+			}
+			return &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int64(0)}, nil
+		}}
+		input := records(4, 1)
+		input[2].PartitionKey = aws.String("failing")
+		failed, err := producer.New(client, "stream", 2).Put(context.Background(), input)
+		assert.EqualError(t, err, "boom")
+		assert.Equal(t, input[2:], failed)
+	})
+}
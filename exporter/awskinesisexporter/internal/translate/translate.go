@@ -16,10 +16,20 @@ package translate
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
+// Supported encodings of the record payloads.
+const (
+	OTLPProto        = "otlp_proto"
+	OTLPJSON         = "otlp_json"
+	JaegerProto      = "jaeger_proto"
+	JaegerProtoBatch = "jaeger_proto_batch"
+	ZipkinJSON       = "zipkin_json"
+)
+
 var (
 	// ErrUnsupportedEncodedType is used when the encoder type does not support the type of encoding
 	ErrUnsupportedEncodedType = errors.New("unsupported type to encode")
@@ -34,3 +44,29 @@ type ExportWriter interface {
 
 	WriteLogs(ld pdata.Logs) error
 }
+
+// Encoder transforms the telemetry data into the payloads of Kinesis records.
+// Depending on the encoding, the data is encoded as a single payload or split into several ones.
+type Encoder interface {
+	EncodeMetrics(md pdata.Metrics) ([][]byte, error)
+
+	EncodeTraces(td pdata.Traces) ([][]byte, error)
+
+	EncodeLogs(ld pdata.Logs) ([][]byte, error)
+}
+
+// NewEncoder returns the encoder of the given encoding. The jaeger_proto spans are
+// written by the kinesis exporter through the JaegerExporter instead.
+func NewEncoder(encoding string) (Encoder, error) {
+	switch encoding {
+	case OTLPProto:
+		return otlpProtoEncoder(), nil
+	case OTLPJSON:
+		return otlpJSONEncoder(), nil
+	case JaegerProtoBatch:
+		return jaegerBatchEncoder{}, nil
+	case ZipkinJSON:
+		return zipkinEncoder(), nil
+	}
+	return nil, fmt.Errorf("unknown encoding %q", encoding)
+}
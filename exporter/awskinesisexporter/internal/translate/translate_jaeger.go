@@ -1,10 +1,10 @@
-// Copyright  OpenTelemetry Authors
+// Copyright The OpenTelemetry Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//      http://www.apache.org/licenses/LICENSE-2.0
+//     http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -55,3 +55,35 @@ func (j *jaeger) WriteTraces(td pdata.Traces) error {
 
 func (j *jaeger) WriteMetrics(_ pdata.Metrics) error { return ErrUnsupportedEncodedType }
 func (j *jaeger) WriteLogs(_ pdata.Logs) error       { return ErrUnsupportedEncodedType }
+
+// jaegerBatchEncoder encodes each resource of the traces as a Jaeger protobuf batch,
+// holding the process and the spans.
+type jaegerBatchEncoder struct{}
+
+// Ensure the jaeger batch encoder meets the interface at compile time.
+var _ Encoder = jaegerBatchEncoder{}
+
+func (jaegerBatchEncoder) EncodeTraces(td pdata.Traces) ([][]byte, error) {
+	batches, err := jaegertranslator.InternalTracesToJaegerProto(td)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads := make([][]byte, 0, len(batches))
+	for _, batch := range batches {
+		payload, err := batch.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+func (jaegerBatchEncoder) EncodeMetrics(_ pdata.Metrics) ([][]byte, error) {
+	return nil, ErrUnsupportedEncodedType
+}
+
+func (jaegerBatchEncoder) EncodeLogs(_ pdata.Logs) ([][]byte, error) {
+	return nil, ErrUnsupportedEncodedType
+}
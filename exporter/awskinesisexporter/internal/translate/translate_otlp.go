@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// otlpEncoder encodes the data as a single OTLP export request payload.
+type otlpEncoder struct {
+	metrics pdata.MetricsMarshaler
+	traces  pdata.TracesMarshaler
+	logs    pdata.LogsMarshaler
+}
+
+// Ensure the otlp encoder meets the interface at compile time.
+var _ Encoder = (*otlpEncoder)(nil)
+
+func otlpProtoEncoder() Encoder {
+	return &otlpEncoder{
+		metrics: otlp.NewProtobufMetricsMarshaler(),
+		traces:  otlp.NewProtobufTracesMarshaler(),
+		logs:    otlp.NewProtobufLogsMarshaler(),
+	}
+}
+
+func otlpJSONEncoder() Encoder {
+	return &otlpEncoder{
+		metrics: otlp.NewJSONMetricsMarshaler(),
+		traces:  otlp.NewJSONTracesMarshaler(),
+		logs:    otlp.NewJSONLogsMarshaler(),
+	}
+}
+
+func (o *otlpEncoder) EncodeMetrics(md pdata.Metrics) ([][]byte, error) {
+	return single(o.metrics.MarshalMetrics(md))
+}
+
+func (o *otlpEncoder) EncodeTraces(td pdata.Traces) ([][]byte, error) {
+	return single(o.traces.MarshalTraces(td))
+}
+
+func (o *otlpEncoder) EncodeLogs(ld pdata.Logs) ([][]byte, error) {
+	return single(o.logs.MarshalLogs(ld))
+}
+
+func single(payload []byte, err error) ([][]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{payload}, nil
+}
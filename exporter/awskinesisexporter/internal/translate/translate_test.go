@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate_test
+
+import (
+	"testing"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/trace/zipkinv2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/translate"
+)
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	for _, service := range []string{"frontend", "checkout"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("GET /")
+		span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	}
+	return td
+}
+
+func TestUnknownEncoding(t *testing.T) {
+	_, err := translate.NewEncoder("avro")
+	assert.EqualError(t, err, `unknown encoding "avro"`)
+
+	// the jaeger_proto spans are written by the JaegerExporter
+	_, err = translate.NewEncoder(translate.JaegerProto)
+	assert.EqualError(t, err, `unknown encoding "jaeger_proto"`)
+}
+
+func TestOTLPEncoders(t *testing.T) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().SetName("log")
+
+	tests := []struct {
+		encoding string
+		traces   pdata.TracesUnmarshaler
+		metrics  pdata.MetricsUnmarshaler
+		logs     pdata.LogsUnmarshaler
+	}{
+		{
+			encoding: translate.OTLPProto,
+			traces:   otlp.NewProtobufTracesUnmarshaler(),
+			metrics:  otlp.NewProtobufMetricsUnmarshaler(),
+			logs:     otlp.NewProtobufLogsUnmarshaler(),
+		},
+		{
+			encoding: translate.OTLPJSON,
+			traces:   otlp.NewJSONTracesUnmarshaler(),
+			metrics:  otlp.NewJSONMetricsUnmarshaler(),
+			logs:     otlp.NewJSONLogsUnmarshaler(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			encoder, err := translate.NewEncoder(tt.encoding)
+			require.NoError(t, err)
+
+			payloads, err := encoder.EncodeTraces(testTraces())
+			require.NoError(t, err)
+			require.Len(t, payloads, 1)
+			td, err := tt.traces.UnmarshalTraces(payloads[0])
+			require.NoError(t, err)
+			assert.Equal(t, testTraces(), td)
+
+			payloads, err = encoder.EncodeMetrics(md)
+			require.NoError(t, err)
+			require.Len(t, payloads, 1)
+			gotMetrics, err := tt.metrics.UnmarshalMetrics(payloads[0])
+			require.NoError(t, err)
+			assert.Equal(t, md, gotMetrics)
+
+			payloads, err = encoder.EncodeLogs(ld)
+			require.NoError(t, err)
+			require.Len(t, payloads, 1)
+			gotLogs, err := tt.logs.UnmarshalLogs(payloads[0])
+			require.NoError(t, err)
+			assert.Equal(t, ld, gotLogs)
+		})
+	}
+}
+
+func TestJaegerBatchEncoder(t *testing.T) {
+	encoder, err := translate.NewEncoder(translate.JaegerProtoBatch)
+	require.NoError(t, err)
+
+	payloads, err := encoder.EncodeTraces(testTraces())
+	require.NoError(t, err)
+	require.Len(t, payloads, 2)
+
+	var batch model.Batch
+	require.NoError(t, batch.Unmarshal(payloads[1]))
+	assert.Equal(t, "checkout", batch.Process.ServiceName)
+	require.Len(t, batch.Spans, 1)
+	assert.Equal(t, "GET /", batch.Spans[0].OperationName)
+
+	_, err = encoder.EncodeMetrics(pdata.NewMetrics())
+	assert.Equal(t, translate.ErrUnsupportedEncodedType, err)
+	_, err = encoder.EncodeLogs(pdata.NewLogs())
+	assert.Equal(t, translate.ErrUnsupportedEncodedType, err)
+}
+
+func TestZipkinEncoder(t *testing.T) {
+	encoder, err := translate.NewEncoder(translate.ZipkinJSON)
+	require.NoError(t, err)
+
+	payloads, err := encoder.EncodeTraces(testTraces())
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+
+	td, err := zipkinv2.NewJSONTracesUnmarshaler(false).UnmarshalTraces(payloads[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+
+	_, err = encoder.EncodeMetrics(pdata.NewMetrics())
+	assert.Equal(t, translate.ErrUnsupportedEncodedType, err)
+	_, err = encoder.EncodeLogs(pdata.NewLogs())
+	assert.Equal(t, translate.ErrUnsupportedEncodedType, err)
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/trace/zipkinv2"
+)
+
+// zipkin encodes the traces as a Zipkin v2 JSON list of spans.
+type zipkin struct {
+	marshaler pdata.TracesMarshaler
+}
+
+// Ensure the zipkin encoder meets the interface at compile time.
+var _ Encoder = (*zipkin)(nil)
+
+func zipkinEncoder() Encoder {
+	return &zipkin{marshaler: zipkinv2.NewJSONTracesMarshaler()}
+}
+
+func (z *zipkin) EncodeTraces(td pdata.Traces) ([][]byte, error) {
+	return single(z.marshaler.MarshalTraces(td))
+}
+
+func (z *zipkin) EncodeMetrics(_ pdata.Metrics) ([][]byte, error) {
+	return nil, ErrUnsupportedEncodedType
+}
+
+func (z *zipkin) EncodeLogs(_ pdata.Logs) ([][]byte, error) {
+	return nil, ErrUnsupportedEncodedType
+}
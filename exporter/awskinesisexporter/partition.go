@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"math/rand"
+	"strconv"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	partitionByTraceID = "traceID"
+	partitionByService = "service"
+
+	// https://docs.aws.amazon.com/kinesis/latest/APIReference/API_PutRecordsRequestEntry.html
+	maxPartitionKeyLength = 256
+)
+
+// randomKey returns a random partition key, spreading the records evenly over the shards.
+func randomKey() string {
+	return strconv.FormatUint(rand.Uint64(), 16)
+}
+
+// resourceKey returns the partition key of the data of a resource,
+// or a random one if the resource doesn't hold the attribute.
+func resourceKey(partitionKey string, resource pdata.Resource) string {
+	attribute := partitionKey
+	if partitionKey == partitionByService {
+		attribute = conventions.AttributeServiceName
+	}
+
+	value, ok := resource.Attributes().Get(attribute)
+	if !ok {
+		return randomKey()
+	}
+	key := tracetranslator.AttributeValueToString(value)
+	if key == "" {
+		return randomKey()
+	}
+	if len(key) > maxPartitionKeyLength {
+		key = key[:maxPartitionKeyLength]
+	}
+	return key
+}
+
+// splitTraces splits the traces by partition key.
+func splitTraces(partitionKey string, td pdata.Traces) map[string]pdata.Traces {
+	switch partitionKey {
+	case "":
+		return map[string]pdata.Traces{randomKey(): td}
+	case partitionByTraceID:
+		return splitTracesByTraceID(td)
+	}
+
+	out := make(map[string]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		key := resourceKey(partitionKey, rs.Resource())
+		traces, ok := out[key]
+		if !ok {
+			traces = pdata.NewTraces()
+			out[key] = traces
+		}
+		rs.CopyTo(traces.ResourceSpans().AppendEmpty())
+	}
+	return out
+}
+
+func splitTracesByTraceID(td pdata.Traces) map[string]pdata.Traces {
+	// the spans without trace ID share a single random key
+	untracedKey := randomKey()
+
+	out := make(map[string]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			// the spans of each trace are copied along with their resource and library
+			dests := make(map[string]pdata.SpanSlice)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				key := untracedKey
+				if traceID := span.TraceID(); !traceID.IsEmpty() {
+					key = traceID.HexString()
+				}
+				dest, ok := dests[key]
+				if !ok {
+					traces, ok := out[key]
+					if !ok {
+						traces = pdata.NewTraces()
+						out[key] = traces
+					}
+					destRS := traces.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(destRS.Resource())
+					destILS := destRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(destILS.InstrumentationLibrary())
+					dest = destILS.Spans()
+					dests[key] = dest
+				}
+				span.CopyTo(dest.AppendEmpty())
+			}
+		}
+	}
+	return out
+}
+
+// splitMetrics splits the metrics by partition key.
+func splitMetrics(partitionKey string, md pdata.Metrics) map[string]pdata.Metrics {
+	if partitionKey == "" {
+		return map[string]pdata.Metrics{randomKey(): md}
+	}
+
+	out := make(map[string]pdata.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		key := resourceKey(partitionKey, rm.Resource())
+		metrics, ok := out[key]
+		if !ok {
+			metrics = pdata.NewMetrics()
+			out[key] = metrics
+		}
+		rm.CopyTo(metrics.ResourceMetrics().AppendEmpty())
+	}
+	return out
+}
+
+// splitLogs splits the logs by partition key.
+func splitLogs(partitionKey string, ld pdata.Logs) map[string]pdata.Logs {
+	switch partitionKey {
+	case "":
+		return map[string]pdata.Logs{randomKey(): ld}
+	case partitionByTraceID:
+		return splitLogsByTraceID(ld)
+	}
+
+	out := make(map[string]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		key := resourceKey(partitionKey, rl.Resource())
+		logs, ok := out[key]
+		if !ok {
+			logs = pdata.NewLogs()
+			out[key] = logs
+		}
+		rl.CopyTo(logs.ResourceLogs().AppendEmpty())
+	}
+	return out
+}
+
+func splitLogsByTraceID(ld pdata.Logs) map[string]pdata.Logs {
+	// the records without trace share a single random key
+	untracedKey := randomKey()
+
+	out := make(map[string]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			dests := make(map[string]pdata.LogSlice)
+			records := ill.Logs()
+			for k := 0; k < records.Len(); k++ {
+				record := records.At(k)
+				key := untracedKey
+				if traceID := record.TraceID(); !traceID.IsEmpty() {
+					key = traceID.HexString()
+				}
+				dest, ok := dests[key]
+				if !ok {
+					logs, ok := out[key]
+					if !ok {
+						logs = pdata.NewLogs()
+						out[key] = logs
+					}
+					destRL := logs.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(destRL.Resource())
+					destILL := destRL.InstrumentationLibraryLogs().AppendEmpty()
+					ill.InstrumentationLibrary().CopyTo(destILL.InstrumentationLibrary())
+					dest = destILL.Logs()
+					dests[key] = dest
+				}
+				record.CopyTo(dest.AppendEmpty())
+			}
+		}
+	}
+	return out
+}
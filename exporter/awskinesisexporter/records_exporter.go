@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/compress"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/producer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/translate"
+)
+
+var (
+	errTraceIDPartitionForMetrics = errors.New("metrics can't be partitioned by trace ID")
+	errJaegerProtoTracesOnly      = errors.New("the jaeger_proto encoding only supports traces")
+)
+
+// recordExporter encodes the telemetry data into records put to an AWS Kinesis stream with the
+// PutRecords API. It exports the metrics, the logs and the traces not encoded as jaeger_proto.
+type recordExporter struct {
+	producer      *producer.Producer
+	encoder       translate.Encoder
+	compressor    compress.Compressor
+	partitionKey  string
+	maxRecordSize int
+	logger        *zap.Logger
+}
+
+// newRecordExporter creates an exporter encoding the data as otlp_proto if no encoding is configured.
+func newRecordExporter(c *Config, logger *zap.Logger) (*recordExporter, error) {
+	client, err := newKinesisClient(c)
+	if err != nil {
+		return nil, err
+	}
+	return newRecordExporterWithClient(c, client, logger)
+}
+
+func newRecordExporterWithClient(c *Config, client kinesisiface.KinesisAPI, logger *zap.Logger) (*recordExporter, error) {
+	encoding := c.Encoding.Name
+	if encoding == "" {
+		encoding = translate.OTLPProto
+	}
+	if encoding == translate.JaegerProto {
+		return nil, errJaegerProtoTracesOnly
+	}
+	encoder, err := translate.NewEncoder(encoding)
+	if err != nil {
+		return nil, err
+	}
+	compressor, err := compress.NewCompressor(c.Encoding.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordExporter{
+		producer:      producer.New(client, c.AWS.StreamName, c.MaxRecordsPerBatch),
+		encoder:       encoder,
+		compressor:    compressor,
+		partitionKey:  c.PartitionKey,
+		maxRecordSize: c.MaxRecordSize,
+		logger:        logger,
+	}, nil
+}
+
+func newKinesisClient(c *Config) (*kinesis.Kinesis, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(c.AWS.Region)})
+	if err != nil {
+		return nil, err
+	}
+
+	awsConfig := &aws.Config{}
+	if c.AWS.KinesisEndpoint != "" {
+		awsConfig.Endpoint = aws.String(c.AWS.KinesisEndpoint)
+	}
+	if c.AWS.Role != "" {
+		awsConfig.Credentials = stscreds.NewCredentials(sess, c.AWS.Role)
+	}
+	return kinesis.New(sess, awsConfig), nil
+}
+
+func (e *recordExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	splits := splitTraces(e.partitionKey, td)
+	var records []*kinesis.PutRecordsRequestEntry
+	for key, traces := range splits {
+		payloads, err := e.encoder.EncodeTraces(traces)
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		if records, err = e.appendRecords(records, key, payloads); err != nil {
+			return consumererror.Permanent(err)
+		}
+	}
+
+	failedKeys, err := e.put(ctx, records)
+	if err != nil {
+		failed := pdata.NewTraces()
+		for key := range failedKeys {
+			splits[key].ResourceSpans().MoveAndAppendTo(failed.ResourceSpans())
+		}
+		return consumererror.NewTraces(err, failed)
+	}
+	return nil
+}
+
+func (e *recordExporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
+	splits := splitMetrics(e.partitionKey, md)
+	var records []*kinesis.PutRecordsRequestEntry
+	for key, metrics := range splits {
+		payloads, err := e.encoder.EncodeMetrics(metrics)
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		if records, err = e.appendRecords(records, key, payloads); err != nil {
+			return consumererror.Permanent(err)
+		}
+	}
+
+	failedKeys, err := e.put(ctx, records)
+	if err != nil {
+		failed := pdata.NewMetrics()
+		for key := range failedKeys {
+			splits[key].ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+		}
+		return consumererror.NewMetrics(err, failed)
+	}
+	return nil
+}
+
+func (e *recordExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	splits := splitLogs(e.partitionKey, ld)
+	var records []*kinesis.PutRecordsRequestEntry
+	for key, logs := range splits {
+		payloads, err := e.encoder.EncodeLogs(logs)
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		if records, err = e.appendRecords(records, key, payloads); err != nil {
+			return consumererror.Permanent(err)
+		}
+	}
+
+	failedKeys, err := e.put(ctx, records)
+	if err != nil {
+		failed := pdata.NewLogs()
+		for key := range failedKeys {
+			splits[key].ResourceLogs().MoveAndAppendTo(failed.ResourceLogs())
+		}
+		return consumererror.NewLogs(err, failed)
+	}
+	return nil
+}
+
+// put puts the records to the stream, and returns the partition keys of the records that failed.
+// The data of those keys is retried, including the records of the keys that were put, if any.
+func (e *recordExporter) put(ctx context.Context, records []*kinesis.PutRecordsRequestEntry) (map[string]bool, error) {
+	failed, err := e.producer.Put(ctx, records)
+	if err != nil {
+		failedKeys := make(map[string]bool)
+		for _, record := range failed {
+			failedKeys[aws.StringValue(record.PartitionKey)] = true
+		}
+		return failedKeys, err
+	}
+	return nil, nil
+}
+
+// appendRecords compresses the payloads into records with the given partition key.
+func (e *recordExporter) appendRecords(records []*kinesis.PutRecordsRequestEntry, key string, payloads [][]byte) ([]*kinesis.PutRecordsRequestEntry, error) {
+	for _, payload := range payloads {
+		data, err := e.compressor.Compress(payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > e.maxRecordSize {
+			e.logger.Warn("Dropping record larger than the maximum record size",
+				zap.Int("size", len(data)),
+				zap.Int("max_record_size", e.maxRecordSize))
+			continue
+		}
+		records = append(records, &kinesis.PutRecordsRequestEntry{
+			Data:         data,
+			PartitionKey: aws.String(key),
+		})
+	}
+	return records, nil
+}
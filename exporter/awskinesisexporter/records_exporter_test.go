@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/translate"
+)
+
+type mockKinesis struct {
+	kinesisiface.KinesisAPI
+
+	records []*kinesis.PutRecordsRequestEntry
+	// failingKey fails the records with the given partition key.
+	failingKey string
+}
+
+func (m *mockKinesis) PutRecordsWithContext(_ aws.Context, input *kinesis.PutRecordsInput, _ ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	out := &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int64(0)}
+	for _, record := range input.Records {
+		if aws.StringValue(record.PartitionKey) == m.failingKey {
+			*out.FailedRecordCount++
+			out.Records = append(out.Records, &kinesis.PutRecordsResultEntry{
+				ErrorCode:    aws.String(kinesis.ErrCodeProvisionedThroughputExceededException),
+				ErrorMessage: aws.String("slow down"),
+			})
+			continue
+		}
+		m.records = append(m.records, record)
+		out.Records = append(out.Records, &kinesis.PutRecordsResultEntry{SequenceNumber: aws.String("1")})
+	}
+	return out, nil
+}
+
+func (m *mockKinesis) recordsByKey() map[string][]byte {
+	out := make(map[string][]byte)
+	for _, record := range m.records {
+		out[aws.StringValue(record.PartitionKey)] = record.Data
+	}
+	return out
+}
+
+func newTestExporter(t *testing.T, modify func(c *Config)) (*recordExporter, *mockKinesis) {
+	c := createDefaultConfig().(*Config)
+	c.AWS.StreamName = "test-stream"
+	modify(c)
+	require.NoError(t, c.Validate())
+
+	client := &mockKinesis{}
+	exp, err := newRecordExporterWithClient(c, client, zap.NewNop())
+	require.NoError(t, err)
+	return exp, client
+}
+
+func TestPushTracesByTraceID(t *testing.T) {
+	exp, client := newTestExporter(t, func(c *Config) {
+		c.PartitionKey = partitionByTraceID
+		c.Encoding.Compression = "gzip"
+	})
+
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, id := range []byte{1, 2, 1} {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{id}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{id}))
+	}
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	records := client.recordsByKey()
+	require.Len(t, records, 2)
+	data, ok := records[pdata.NewTraceID([16]byte{1}).HexString()]
+	require.True(t, ok)
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	payload, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	traces, err := otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces(payload)
+	require.NoError(t, err)
+	assert.Equal(t, 2, traces.SpanCount())
+}
+
+func TestPushMetricsByService(t *testing.T) {
+	exp, client := newTestExporter(t, func(c *Config) {
+		c.PartitionKey = partitionByService
+		c.Encoding.Name = "otlp_json"
+	})
+
+	md := pdata.NewMetrics()
+	for _, service := range []string{"frontend", "checkout", "frontend"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", service)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	}
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+
+	records := client.recordsByKey()
+	require.Len(t, records, 2)
+	metrics, err := otlp.NewJSONMetricsUnmarshaler().UnmarshalMetrics(records["frontend"])
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.ResourceMetrics().Len())
+}
+
+func TestPushLogsByAttribute(t *testing.T) {
+	exp, client := newTestExporter(t, func(c *Config) {
+		c.PartitionKey = "k8s.pod.name"
+	})
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("k8s.pod.name", "pod-a")
+	rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().SetName("log")
+	// the logs of resources without the attribute get a random key
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().SetName("log")
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+
+	records := client.recordsByKey()
+	require.Len(t, records, 2)
+	assert.Contains(t, records, "pod-a")
+}
+
+func TestPushReturnsFailedData(t *testing.T) {
+	exp, client := newTestExporter(t, func(c *Config) {
+		c.PartitionKey = partitionByService
+	})
+	client.failingKey = "checkout"
+
+	md := pdata.NewMetrics()
+	for _, service := range []string{"frontend", "checkout"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", service)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	}
+
+	err := exp.pushMetrics(context.Background(), md)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	var metricsErr consumererror.Metrics
+	require.True(t, consumererror.AsMetrics(err, &metricsErr))
+	failed := metricsErr.GetMetrics()
+	require.Equal(t, 1, failed.ResourceMetrics().Len())
+	service, _ := failed.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.StringVal())
+	assert.Contains(t, client.recordsByKey(), "frontend")
+}
+
+func TestRecordExporterEncodings(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+
+	// the data is encoded as otlp_proto by default
+	exp, err := newRecordExporterWithClient(c, &mockKinesis{}, zap.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, exp.pushLogs(context.Background(), pdata.NewLogs()))
+
+	// the jaeger_proto spans are written with the Kinesis producer library
+	c.Encoding.Name = translate.JaegerProto
+	_, err = newRecordExporterWithClient(c, &mockKinesis{}, zap.NewNop())
+	assert.Equal(t, errJaegerProtoTracesOnly, err)
+}
+
+func TestPushUnsupportedEncoding(t *testing.T) {
+	exp, client := newTestExporter(t, func(c *Config) {
+		c.Encoding.Name = "zipkin_json"
+	})
+
+	err := exp.pushLogs(context.Background(), pdata.NewLogs())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Empty(t, client.records)
+}
+
+func TestDropsOversizedRecords(t *testing.T) {
+	exp, client := newTestExporter(t, func(c *Config) {
+		c.MaxRecordSize = 10
+	})
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("a span with a long name")
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	assert.Empty(t, client.records)
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.AWS.StreamName = "test-stream"
+	c.Encoding.Name = translate.OTLPProto
+	set := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	te, err := factory.CreateTracesExporter(context.Background(), set, c)
+	require.NoError(t, err)
+	assert.NotNil(t, te)
+
+	me, err := factory.CreateMetricsExporter(context.Background(), set, c)
+	require.NoError(t, err)
+	assert.NotNil(t, me)
+
+	le, err := factory.CreateLogsExporter(context.Background(), set, c)
+	require.NoError(t, err)
+	assert.NotNil(t, le)
+
+	c.PartitionKey = partitionByTraceID
+	_, err = factory.CreateMetricsExporter(context.Background(), set, c)
+	assert.Equal(t, errTraceIDPartitionForMetrics, err)
+
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestCreateJaegerTracesExporterSettings(t *testing.T) {
+	factory := NewFactory()
+	set := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	c := factory.CreateDefaultConfig().(*Config)
+	c.PartitionKey = partitionByService
+	_, err := factory.CreateTracesExporter(context.Background(), set, c)
+	assert.Equal(t, errJaegerProtoSettings, err)
+
+	c = factory.CreateDefaultConfig().(*Config)
+	c.Encoding.Name = translate.JaegerProto
+	c.Encoding.Compression = "gzip"
+	_, err = factory.CreateTracesExporter(context.Background(), set, c)
+	assert.Equal(t, errJaegerProtoSettings, err)
+
+	_, err = factory.CreateLogsExporter(context.Background(), set, c)
+	assert.Equal(t, errJaegerProtoTracesOnly, err)
+}
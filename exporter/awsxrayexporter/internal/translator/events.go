@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+)
+
+// eventsMetadataKey is the key of the span events in the default metadata namespace.
+const eventsMetadataKey = "otel.events"
+
+// makeEvents converts the span events, other than exceptions which are recorded in the cause, to
+// metadata values.
+func makeEvents(events pdata.SpanEventSlice) []interface{} {
+	var converted []interface{}
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Name() == semconventions.AttributeExceptionEventName {
+			continue
+		}
+
+		value := map[string]interface{}{
+			"name":      event.Name(),
+			"timestamp": timestampToFloatSeconds(event.Timestamp()),
+		}
+		if event.Attributes().Len() > 0 {
+			attributes := make(map[string]interface{}, event.Attributes().Len())
+			event.Attributes().Range(func(key string, value pdata.AttributeValue) bool {
+				if metaVal := metadataValue(value); metaVal != nil {
+					attributes[key] = metaVal
+				}
+				return true
+			})
+			value["attributes"] = attributes
+		}
+		converted = append(converted, value)
+	}
+	return converted
+}
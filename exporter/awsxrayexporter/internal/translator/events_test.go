@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestSpanEventsAsMetadata(t *testing.T) {
+	span := constructServerSpan(newSegmentID(), "/orders", pdata.StatusCodeError, "ERROR", nil)
+	timestamp := time.Unix(1600000000, 500000000)
+
+	event := span.Events().AppendEmpty()
+	event.SetName("cache miss")
+	event.SetTimestamp(pdata.TimestampFromTime(timestamp))
+	event.Attributes().InsertString("cache.key", "order-42")
+
+	exception := span.Events().AppendEmpty()
+	exception.SetName(semconventions.AttributeExceptionEventName)
+	exception.Attributes().InsertString(semconventions.AttributeExceptionType, "java.lang.IllegalStateException")
+	exception.Attributes().InsertString(semconventions.AttributeExceptionMessage, "bad state")
+
+	empty := span.Events().AppendEmpty()
+	empty.SetName("retry")
+	empty.SetTimestamp(pdata.TimestampFromTime(timestamp))
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":       "cache miss",
+			"timestamp":  1600000000.5,
+			"attributes": map[string]interface{}{"cache.key": "order-42"},
+		},
+		map[string]interface{}{
+			"name":      "retry",
+			"timestamp": 1600000000.5,
+		},
+	}, segment.Metadata["default"][eventsMetadataKey])
+	// The exception is still recorded as the cause.
+	assert.Len(t, segment.Cause.Exceptions, 1)
+}
+
+func TestSpanWithOnlyExceptionEvents(t *testing.T) {
+	span := constructServerSpan(newSegmentID(), "/orders", pdata.StatusCodeError, "ERROR", nil)
+	exception := span.Events().AppendEmpty()
+	exception.SetName(semconventions.AttributeExceptionEventName)
+	exception.Attributes().InsertString(semconventions.AttributeExceptionMessage, "bad state")
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, segment.Metadata["default"], eventsMetadataKey)
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// makeSpanLinks converts the links of a span, e.g. to the span which produced a consumed message, to
+// X-Ray links. Links to traces that X-Ray does not accept are dropped.
+func makeSpanLinks(links pdata.SpanLinkSlice) []awsxray.SpanLinkData {
+	var spanLinks []awsxray.SpanLinkData
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		traceID, err := convertToAmazonTraceID(link.TraceID())
+		if err != nil {
+			continue
+		}
+
+		spanLink := awsxray.SpanLinkData{
+			TraceID: awsxray.String(traceID),
+			SpanID:  awsxray.String(link.SpanID().HexString()),
+		}
+		if link.Attributes().Len() > 0 {
+			spanLink.Attributes = make(map[string]interface{}, link.Attributes().Len())
+			link.Attributes().Range(func(key string, value pdata.AttributeValue) bool {
+				if metaVal := metadataValue(value); metaVal != nil {
+					spanLink.Attributes[key] = metaVal
+				}
+				return true
+			})
+		}
+		spanLinks = append(spanLinks, spanLink)
+	}
+	return spanLinks
+}
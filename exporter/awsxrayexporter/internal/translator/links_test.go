@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestSpanLinks(t *testing.T) {
+	span := constructServerSpan(newSegmentID(), "/orders", pdata.StatusCodeOk, "OK", nil)
+
+	traceID := newTraceID()
+	spanID := newSegmentID()
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(traceID)
+	link.SetSpanID(spanID)
+	link.Attributes().InsertString("messaging.operation", "process")
+	link.Attributes().InsertInt("retries", 2)
+
+	segment, err := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Len(t, segment.Links, 1)
+	expectedTraceID, _ := convertToAmazonTraceID(traceID)
+	assert.Equal(t, expectedTraceID, *segment.Links[0].TraceID)
+	assert.Equal(t, spanID.HexString(), *segment.Links[0].SpanID)
+	assert.Equal(t, map[string]interface{}{
+		"messaging.operation": "process",
+		"retries":             int64(2),
+	}, segment.Links[0].Attributes)
+
+	jsonStr, err := MakeSegmentDocumentString(span, constructDefaultResource(), nil, false)
+	assert.NoError(t, err)
+	assert.Contains(t, jsonStr, `"links":[{"trace_id":"`+expectedTraceID+`","id":"`+spanID.HexString()+`"`)
+}
+
+func TestSpanLinksWithInvalidTraceID(t *testing.T) {
+	var r [16]byte
+	// Too old for X-Ray.
+	binary.BigEndian.PutUint32(r[0:4], uint32(time.Now().Add(-60*24*time.Hour).Unix()))
+	r[15] = 1
+
+	links := pdata.NewSpanLinkSlice()
+	link := links.AppendEmpty()
+	link.SetTraceID(pdata.NewTraceID(r))
+	link.SetSpanID(newSegmentID())
+	valid := links.AppendEmpty()
+	valid.SetTraceID(newTraceID())
+	valid.SetSpanID(newSegmentID())
+
+	spanLinks := makeSpanLinks(links)
+
+	assert.Len(t, spanLinks, 1)
+	assert.Equal(t, valid.SpanID().HexString(), *spanLinks[0].SpanID)
+	assert.Nil(t, spanLinks[0].Attributes)
+}
+
+func TestSpanWithoutLinks(t *testing.T) {
+	span := constructServerSpan(newSegmentID(), "/orders", pdata.StatusCodeOk, "OK", nil)
+
+	segment, err := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Nil(t, segment.Links)
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	messagingSystemSQS = "sqs"
+	messagingSystemSNS = "sns"
+)
+
+// makeMessaging maps the messaging attributes of producer and consumer spans. Producer spans are
+// named after the service receiving the message, i.e. SQS and SNS for AWS or the destination for any
+// other messaging system, so that X-Ray draws the queue or topic as a downstream node. Spans sending
+// to or receiving from SQS and SNS also get the queue URL and the API operation of the call.
+func makeMessaging(span pdata.Span) (name string, namespace string, queueURL string, operation string) {
+	if span.Kind() != pdata.SpanKindProducer && span.Kind() != pdata.SpanKindConsumer {
+		return "", "", "", ""
+	}
+
+	var (
+		attributes     = span.Attributes()
+		system         string
+		destination    string
+		url            string
+		messagingOpVal string
+	)
+	if value, ok := attributes.Get(semconventions.AttributeMessagingSystem); ok {
+		system = value.StringVal()
+	}
+	if system == "" {
+		// Not a messaging span.
+		return "", "", "", ""
+	}
+	if value, ok := attributes.Get(semconventions.AttributeMessagingDestination); ok {
+		destination = value.StringVal()
+	}
+	if value, ok := attributes.Get(semconventions.AttributeMessagingURL); ok {
+		url = value.StringVal()
+	}
+	if value, ok := attributes.Get(semconventions.AttributeMessagingOperation); ok {
+		messagingOpVal = value.StringVal()
+	}
+
+	switch normalizeMessagingSystem(system) {
+	case messagingSystemSQS:
+		name, namespace, queueURL = "SQS", "aws", url
+		if span.Kind() == pdata.SpanKindProducer {
+			operation = "SendMessage"
+		} else if messagingOpVal == "" || messagingOpVal == "receive" {
+			// A "process" span handles a message that was already received, it is not an API call.
+			operation = "ReceiveMessage"
+		}
+	case messagingSystemSNS:
+		name, namespace = "SNS", "aws"
+		if span.Kind() == pdata.SpanKindProducer {
+			operation = "Publish"
+		}
+	default:
+		name, namespace = destination, "remote"
+		if name == "" {
+			name = system
+		}
+	}
+
+	if span.Kind() != pdata.SpanKindProducer {
+		// Consumer spans are segments named after the local service.
+		name, namespace = "", ""
+	}
+	return name, namespace, queueURL, operation
+}
+
+// normalizeMessagingSystem maps the different names instrumentations use for SQS and SNS, e.g.
+// AmazonSQS, aws_sqs or aws.sqs, to a single one.
+func normalizeMessagingSystem(system string) string {
+	system = strings.ToLower(system)
+	for _, prefix := range []string{"amazon", "aws_", "aws."} {
+		system = strings.TrimPrefix(system, prefix)
+	}
+	return system
+}
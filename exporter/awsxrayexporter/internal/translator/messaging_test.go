@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+)
+
+const testQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+
+func constructMessagingSpan(kind pdata.SpanKind, attributes map[string]interface{}) pdata.Span {
+	span := constructClientSpan(newSegmentID(), "orders send", pdata.StatusCodeUnset, "", attributes)
+	span.SetKind(kind)
+	return span
+}
+
+func TestSQSProducerSpan(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindProducer, map[string]interface{}{
+		semconventions.AttributeMessagingSystem:      "AmazonSQS",
+		semconventions.AttributeMessagingDestination: "orders",
+		semconventions.AttributeMessagingURL:         testQueueURL,
+	})
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SQS", *segment.Name)
+	assert.Equal(t, "aws", *segment.Namespace)
+	assert.Equal(t, "subsegment", *segment.Type)
+	assert.Equal(t, testQueueURL, *segment.AWS.QueueURL)
+	assert.Equal(t, "SendMessage", *segment.AWS.Operation)
+	assert.Equal(t, "orders", segment.Metadata["default"][semconventions.AttributeMessagingDestination])
+}
+
+func TestSQSProducerSpanWithAWSAttributes(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindProducer, map[string]interface{}{
+		semconventions.AttributeMessagingSystem: "aws_sqs",
+		semconventions.AttributeMessagingURL:    "https://sqs.us-east-1.amazonaws.com/123456789012/other",
+		"aws.queue_url":                         testQueueURL,
+		"aws.operation":                         "SendMessageBatch",
+	})
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testQueueURL, *segment.AWS.QueueURL)
+	assert.Equal(t, "SendMessageBatch", *segment.AWS.Operation)
+}
+
+func TestSQSConsumerSpan(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindConsumer, map[string]interface{}{
+		semconventions.AttributeMessagingSystem:    "aws.sqs",
+		semconventions.AttributeMessagingURL:       testQueueURL,
+		semconventions.AttributeMessagingOperation: "receive",
+	})
+
+	segment, err := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "signup_aggregator", *segment.Name)
+	assert.Nil(t, segment.Namespace)
+	assert.Nil(t, segment.Type)
+	assert.NotNil(t, segment.ParentID)
+	assert.Equal(t, testQueueURL, *segment.AWS.QueueURL)
+	assert.Equal(t, "ReceiveMessage", *segment.AWS.Operation)
+	assert.Equal(t, "receive", segment.Metadata["default"][semconventions.AttributeMessagingOperation])
+	assert.NotNil(t, segment.Service)
+}
+
+func TestSQSProcessSpan(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindConsumer, map[string]interface{}{
+		semconventions.AttributeMessagingSystem:    "AmazonSQS",
+		semconventions.AttributeMessagingURL:       testQueueURL,
+		semconventions.AttributeMessagingOperation: "process",
+	})
+
+	segment, err := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testQueueURL, *segment.AWS.QueueURL)
+	assert.Nil(t, segment.AWS.Operation)
+}
+
+func TestSNSProducerSpan(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindProducer, map[string]interface{}{
+		semconventions.AttributeMessagingSystem:      "AmazonSNS",
+		semconventions.AttributeMessagingDestination: "arn:aws:sns:us-east-1:123456789012:orders",
+	})
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SNS", *segment.Name)
+	assert.Equal(t, "aws", *segment.Namespace)
+	assert.Nil(t, segment.AWS.QueueURL)
+	assert.Equal(t, "Publish", *segment.AWS.Operation)
+}
+
+func TestKafkaProducerSpan(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindProducer, map[string]interface{}{
+		semconventions.AttributeMessagingSystem:      "kafka",
+		semconventions.AttributeMessagingDestination: "orders",
+	})
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", *segment.Name)
+	assert.Equal(t, "remote", *segment.Namespace)
+	assert.Nil(t, segment.AWS.QueueURL)
+	assert.Nil(t, segment.AWS.Operation)
+}
+
+func TestProducerSpanWithPeerService(t *testing.T) {
+	span := constructMessagingSpan(pdata.SpanKindProducer, map[string]interface{}{
+		semconventions.AttributePeerService:          "order-queue",
+		semconventions.AttributeMessagingSystem:      "rabbitmq",
+		semconventions.AttributeMessagingDestination: "orders",
+	})
+
+	segment, err := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "order-queue", *segment.Name)
+	assert.Nil(t, segment.Namespace)
+}
+
+func TestNormalizeMessagingSystem(t *testing.T) {
+	for _, system := range []string{"AmazonSQS", "aws_sqs", "aws.sqs", "sqs"} {
+		assert.Equal(t, messagingSystemSQS, normalizeMessagingSystem(system), system)
+	}
+	for _, system := range []string{"AmazonSNS", "aws_sns", "aws.sns", "sns"} {
+		assert.Equal(t, messagingSystemSNS, normalizeMessagingSystem(system), system)
+	}
+	assert.Equal(t, "kafka", normalizeMessagingSystem("kafka"))
+}
@@ -83,7 +83,7 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 	var segmentType string
 
 	storeResource := true
-	if span.Kind() != pdata.SpanKindServer &&
+	if !isSegmentKind(span.Kind()) &&
 		!span.ParentSpanID().IsEmpty() {
 		segmentType = "subsegment"
 		// We only store the resource information for segments, the local root.
@@ -106,10 +106,36 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		service                                            = makeService(resource)
 		sqlfiltered, sql                                   = makeSQL(awsfiltered)
 		user, annotations, metadata                        = makeXRayAttributes(sqlfiltered, resource, storeResource, indexedAttrs, indexAllAttrs)
+		msgName, msgNamespace, queueURL, msgOperation      = makeMessaging(span)
+		events                                             = makeEvents(span.Events())
+		links                                              = makeSpanLinks(span.Links())
 		name                                               string
 		namespace                                          string
 	)
 
+	if queueURL != "" || msgOperation != "" {
+		if aws == nil {
+			aws = &awsxray.AWSData{}
+		}
+		// Explicit AWS attributes, e.g. from the AWS SDK instrumentation, take precedence.
+		if aws.QueueURL == nil {
+			aws.QueueURL = awsxray.String(queueURL)
+		}
+		if aws.Operation == nil {
+			aws.Operation = awsxray.String(msgOperation)
+		}
+	}
+
+	if len(events) > 0 {
+		if metadata == nil {
+			metadata = map[string]map[string]interface{}{}
+		}
+		if metadata["default"] == nil {
+			metadata["default"] = map[string]interface{}{}
+		}
+		metadata["default"][eventsMetadataKey] = events
+	}
+
 	// X-Ray segment names are service names, unlike span names which are methods. Try to find a service name.
 
 	attributes := span.Attributes()
@@ -129,6 +155,12 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		}
 	}
 
+	if name == "" {
+		// Messages are sent to a queue or topic, which is the downstream service of a producer span.
+		name = msgName
+		namespace = msgNamespace
+	}
+
 	if name == "" {
 		if dbInstance, ok := attributes.Get(semconventions.AttributeDBName); ok {
 			// For database queries, the segment name convention is <db name>@<db host>
@@ -143,8 +175,8 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		}
 	}
 
-	if name == "" && span.Kind() == pdata.SpanKindServer {
-		// Only for a server span, we can use the resource.
+	if name == "" && isSegmentKind(span.Kind()) {
+		// Only for a server or consumer span, we can use the resource.
 		if service, ok := resource.Attributes().Get(semconventions.AttributeServiceName); ok {
 			name = service.StringVal()
 		}
@@ -197,9 +229,17 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		Annotations: annotations,
 		Metadata:    metadata,
 		Type:        awsxray.String(segmentType),
+		Links:       links,
 	}, nil
 }
 
+// isSegmentKind reports whether spans of the kind start the work of a service, so they are X-Ray
+// segments rather than subsegments. Consumer spans are included so that the handling of a message
+// is a segment of the consuming service, linked to the producer, instead of a dangling subsegment.
+func isSegmentKind(kind pdata.SpanKind) bool {
+	return kind == pdata.SpanKindServer || kind == pdata.SpanKindConsumer
+}
+
 // newSegmentID generates a new valid X-Ray SegmentID
 func newSegmentID() pdata.SpanID {
 	var r [8]byte
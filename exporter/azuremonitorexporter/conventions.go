@@ -30,6 +30,8 @@ const (
 	attributeRPCGRPCStatusCode     string = "rpc.grpc.status_code"
 	attributeOtelStatusCode        string = "otel.status_code"
 	attributeOtelStatusDescription string = "otel.status_description"
+	attributeOtelLogName           string = "otel.log_name"
+	attributeOtelSeverityText      string = "otel.severity_text"
 )
 
 // NetworkAttributes is the set of known network attributes
@@ -42,7 +42,9 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithTraces(f.createTracesExporter))
+		exporterhelper.WithTraces(f.createTracesExporter),
+		exporterhelper.WithLogs(f.createLogsExporter),
+		exporterhelper.WithMetrics(f.createMetricsExporter))
 }
 
 // Implements the interface from go.opentelemetry.io/collector/exporter/factory.go
@@ -74,7 +76,37 @@ func (f *factory) createTracesExporter(
 	return newTracesExporter(exporterConfig, tc, set)
 }
 
-// Configures the transport channel.
+func (f *factory) createLogsExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.LogsExporter, error) {
+	exporterConfig, ok := cfg.(*Config)
+
+	if !ok {
+		return nil, errUnexpectedConfigurationType
+	}
+
+	tc := f.getTransportChannel(exporterConfig, set.Logger)
+	return newLogsExporter(exporterConfig, tc, set)
+}
+
+func (f *factory) createMetricsExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	exporterConfig, ok := cfg.(*Config)
+
+	if !ok {
+		return nil, errUnexpectedConfigurationType
+	}
+
+	tc := f.getTransportChannel(exporterConfig, set.Logger)
+	return newMetricsExporter(exporterConfig, tc, set)
+}
+
+// Configures the transport channel shared by the traces, logs and metrics exporters.
 // This method is not thread-safe
 func (f *factory) getTransportChannel(exporterConfig *Config, logger *zap.Logger) transportChannel {
 
@@ -62,3 +62,62 @@ func TestCreateTracesExporterUsingBadConfig(t *testing.T) {
 	assert.Nil(t, exporter)
 	assert.NotNil(t, err)
 }
+
+func TestCreateLogsExporterUsingSpecificTransportChannel(t *testing.T) {
+	// mock transport channel creation
+	f := factory{tChannel: &mockTransportChannel{}}
+	ctx := context.Background()
+	params := componenttest.NewNopExporterCreateSettings()
+	exporter, err := f.createLogsExporter(ctx, params, createDefaultConfig())
+	assert.NotNil(t, exporter)
+	assert.Nil(t, err)
+}
+
+func TestCreateLogsExporterUsingBadConfig(t *testing.T) {
+	f := factory{}
+	ctx := context.Background()
+	params := componenttest.NewNopExporterCreateSettings()
+
+	exporter, err := f.createLogsExporter(ctx, params, &badConfig{})
+	assert.Nil(t, exporter)
+	assert.NotNil(t, err)
+}
+
+func TestCreateMetricsExporterUsingSpecificTransportChannel(t *testing.T) {
+	// mock transport channel creation
+	f := factory{tChannel: &mockTransportChannel{}}
+	ctx := context.Background()
+	params := componenttest.NewNopExporterCreateSettings()
+	exporter, err := f.createMetricsExporter(ctx, params, createDefaultConfig())
+	assert.NotNil(t, exporter)
+	assert.Nil(t, err)
+}
+
+func TestCreateMetricsExporterUsingBadConfig(t *testing.T) {
+	f := factory{}
+	ctx := context.Background()
+	params := componenttest.NewNopExporterCreateSettings()
+
+	exporter, err := f.createMetricsExporter(ctx, params, &badConfig{})
+	assert.Nil(t, exporter)
+	assert.NotNil(t, err)
+}
+
+func TestCreateExportersShareTransportChannel(t *testing.T) {
+	// All the exporters created by a factory share the same batching transport channel
+	f := factory{}
+	ctx := context.Background()
+	params := componenttest.NewNopExporterCreateSettings()
+	cfg := createDefaultConfig()
+
+	_, err := f.createTracesExporter(ctx, params, cfg)
+	assert.Nil(t, err)
+	tChannel := f.tChannel
+	assert.NotNil(t, tChannel)
+
+	_, err = f.createLogsExporter(ctx, params, cfg)
+	assert.Nil(t, err)
+	_, err = f.createMetricsExporter(ctx, params, cfg)
+	assert.Nil(t, err)
+	assert.Same(t, tChannel, f.tChannel)
+}
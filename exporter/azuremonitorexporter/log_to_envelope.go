@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+// Transforms a tuple of pdata.Resource, pdata.InstrumentationLibrary, pdata.LogRecord into an AppInsights contracts.Envelope.
+// Log records carrying exception attributes are mapped to ExceptionData, all other log records to MessageData.
+func logRecordToEnvelope(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	logRecord pdata.LogRecord,
+	logger *zap.Logger) *contracts.Envelope {
+
+	envelope := contracts.NewEnvelope()
+	envelope.Tags = make(map[string]string)
+	envelope.Time = toTime(logRecord.Timestamp()).Format(time.RFC3339Nano)
+
+	if traceID := logRecord.TraceID(); !traceID.IsEmpty() {
+		envelope.Tags[contracts.OperationId] = traceID.HexString()
+	}
+	if spanID := logRecord.SpanID(); !spanID.IsEmpty() {
+		envelope.Tags[contracts.OperationParentId] = spanID.HexString()
+	}
+
+	data := contracts.NewData()
+	var dataSanitizeFunc func() []string
+	var dataProperties map[string]string
+
+	severityLevel := severityNumberToSeverityLevel(logRecord.SeverityNumber())
+	attributeMap := logRecord.Attributes()
+
+	if isExceptionLogRecord(attributeMap) {
+		exceptionData := logRecordToExceptionData(logRecord, severityLevel)
+		dataProperties = exceptionData.Properties
+		dataSanitizeFunc = exceptionData.Sanitize
+		envelope.Name = exceptionData.EnvelopeName("")
+		data.BaseData = exceptionData
+		data.BaseType = exceptionData.BaseType()
+	} else {
+		messageData := contracts.NewMessageData()
+		messageData.Message = tracetranslator.AttributeValueToString(logRecord.Body())
+		messageData.SeverityLevel = severityLevel
+		messageData.Properties = make(map[string]string)
+
+		// MessageData has no measurements, so all the attributes are copied as properties
+		attributeMap.Range(func(k string, v pdata.AttributeValue) bool {
+			messageData.Properties[k] = tracetranslator.AttributeValueToString(v)
+			return true
+		})
+
+		dataProperties = messageData.Properties
+		dataSanitizeFunc = messageData.Sanitize
+		envelope.Name = messageData.EnvelopeName("")
+		data.BaseData = messageData
+		data.BaseType = messageData.BaseType()
+	}
+
+	if logRecord.Name() != "" {
+		dataProperties[attributeOtelLogName] = logRecord.Name()
+	}
+	if logRecord.SeverityText() != "" {
+		dataProperties[attributeOtelSeverityText] = logRecord.SeverityText()
+	}
+
+	envelope.Data = data
+	resourceAttributes := resource.Attributes()
+	applyResourcesToDataProperties(dataProperties, resourceAttributes)
+	applyInstrumentationLibraryValueToDataProperties(dataProperties, instrumentationLibrary)
+	applyCloudTagsToEnvelope(envelope, resourceAttributes)
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(dataSanitizeFunc, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope
+}
+
+// Maps a LogRecord carrying exception attributes to AppInsights ExceptionData
+func logRecordToExceptionData(logRecord pdata.LogRecord, severityLevel contracts.SeverityLevel) *contracts.ExceptionData {
+	// See https://github.com/microsoft/ApplicationInsights-Go/blob/master/appinsights/contracts/exceptiondata.go
+	data := contracts.NewExceptionData()
+	data.SeverityLevel = severityLevel
+	data.Properties = make(map[string]string)
+	data.Measurements = make(map[string]float64)
+
+	details := contracts.NewExceptionDetails()
+	attributeMap := logRecord.Attributes()
+	copyAndMapAttributes(attributeMap, data.Properties, data.Measurements, func(k string, v pdata.AttributeValue) {
+		switch k {
+		case conventions.AttributeExceptionType:
+			details.TypeName = v.StringVal()
+		case conventions.AttributeExceptionMessage:
+			details.Message = v.StringVal()
+		case conventions.AttributeExceptionStacktrace:
+			details.Stack = v.StringVal()
+		}
+	})
+
+	// Fall back on the log body when the exception message is not set
+	if details.Message == "" {
+		details.Message = tracetranslator.AttributeValueToString(logRecord.Body())
+	}
+	details.HasFullStack = details.Stack != ""
+	data.Exceptions = []*contracts.ExceptionDetails{details}
+
+	return data
+}
+
+// A LogRecord is considered an exception if it has either the exception type or the exception message attribute
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/exceptions.md
+func isExceptionLogRecord(attributeMap pdata.AttributeMap) bool {
+	if _, exists := attributeMap.Get(conventions.AttributeExceptionType); exists {
+		return true
+	}
+
+	_, exists := attributeMap.Get(conventions.AttributeExceptionMessage)
+	return exists
+}
+
+// Maps the LogRecord SeverityNumber to the AppInsights SeverityLevel. Unspecified severities are mapped to Information.
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/logs/data-model.md#field-severitynumber
+func severityNumberToSeverityLevel(severityNumber pdata.SeverityNumber) contracts.SeverityLevel {
+	switch {
+	case severityNumber == pdata.SeverityNumberUNDEFINED:
+		return contracts.Information
+	case severityNumber < pdata.SeverityNumberINFO:
+		return contracts.Verbose
+	case severityNumber < pdata.SeverityNumberWARN:
+		return contracts.Information
+	case severityNumber < pdata.SeverityNumberERROR:
+		return contracts.Warning
+	case severityNumber < pdata.SeverityNumberFATAL:
+		return contracts.Error
+	default:
+		return contracts.Critical
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMessageDataEnvelopeName   = "Microsoft.ApplicationInsights.Message"
+	defaultExceptionDataEnvelopeName = "Microsoft.ApplicationInsights.Exception"
+	defaultLogName                   = "mylog"
+	defaultLogMessage                = "something happened"
+)
+
+var (
+	defaultLogTime = pdata.Timestamp(1626105600000000000)
+)
+
+// Tests the mapping of a plain LogRecord to MessageData
+func TestLogRecordToMessageData(t *testing.T) {
+	logRecord := getDefaultLogRecord()
+	logRecord.SetSeverityNumber(pdata.SeverityNumberWARN2)
+	logRecord.SetSeverityText("Warning")
+
+	envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+	commonLogEnvelopeValidations(t, envelope, defaultMessageDataEnvelopeName)
+	assert.Equal(t, defaultTraceIDAsHex, envelope.Tags[contracts.OperationId])
+	assert.Equal(t, defaultSpanIDAsHex, envelope.Tags[contracts.OperationParentId])
+
+	data := envelope.Data.(*contracts.Data).BaseData.(*contracts.MessageData)
+	assert.Equal(t, defaultLogMessage, data.Message)
+	assert.Equal(t, contracts.Warning, data.SeverityLevel)
+	assert.Equal(t, defaultLogName, data.Properties[attributeOtelLogName])
+	assert.Equal(t, "Warning", data.Properties[attributeOtelSeverityText])
+	assert.Equal(t, "bar", data.Properties["foo"])
+	assert.Equal(t, "42", data.Properties["answer"])
+	assert.Equal(t, defaultServiceName, data.Properties[conventions.AttributeServiceName])
+	assert.Equal(t, defaultInstrumentationLibraryName, data.Properties[instrumentationLibraryName])
+	assert.Equal(t, defaultInstrumentationLibraryVersion, data.Properties[instrumentationLibraryVersion])
+}
+
+// Tests the mapping of a LogRecord without trace context
+func TestLogRecordToMessageDataNoTraceContext(t *testing.T) {
+	logRecord := getDefaultLogRecord()
+	logRecord.SetTraceID(pdata.NewTraceID([16]byte{}))
+	logRecord.SetSpanID(pdata.NewSpanID([8]byte{}))
+
+	envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+	commonLogEnvelopeValidations(t, envelope, defaultMessageDataEnvelopeName)
+	assert.NotContains(t, envelope.Tags, contracts.OperationId)
+	assert.NotContains(t, envelope.Tags, contracts.OperationParentId)
+
+	data := envelope.Data.(*contracts.Data).BaseData.(*contracts.MessageData)
+	assert.Equal(t, contracts.Information, data.SeverityLevel)
+}
+
+// Tests the mapping of a LogRecord with exception attributes to ExceptionData
+func TestLogRecordToExceptionData(t *testing.T) {
+	logRecord := getDefaultLogRecord()
+	logRecord.SetSeverityNumber(pdata.SeverityNumberERROR)
+	logRecord.Attributes().InsertString(conventions.AttributeExceptionType, "java.lang.NullPointerException")
+	logRecord.Attributes().InsertString(conventions.AttributeExceptionStacktrace, "at com.example.Foo.bar(Foo.java:42)")
+
+	envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+	commonLogEnvelopeValidations(t, envelope, defaultExceptionDataEnvelopeName)
+
+	data := envelope.Data.(*contracts.Data).BaseData.(*contracts.ExceptionData)
+	assert.Equal(t, contracts.Error, data.SeverityLevel)
+	require.Len(t, data.Exceptions, 1)
+	assert.Equal(t, "java.lang.NullPointerException", data.Exceptions[0].TypeName)
+	assert.Equal(t, defaultLogMessage, data.Exceptions[0].Message)
+	assert.Equal(t, "at com.example.Foo.bar(Foo.java:42)", data.Exceptions[0].Stack)
+	assert.True(t, data.Exceptions[0].HasFullStack)
+	assertAttributesCopiedToPropertiesOrMeasurements(t, logRecord.Attributes(), data.Properties, data.Measurements)
+	assert.Equal(t, defaultLogName, data.Properties[attributeOtelLogName])
+}
+
+// Tests the mapping of a LogRecord with an exception message attribute to ExceptionData
+func TestLogRecordToExceptionDataWithMessage(t *testing.T) {
+	logRecord := getDefaultLogRecord()
+	logRecord.Attributes().InsertString(conventions.AttributeExceptionMessage, "division by zero")
+
+	envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+	commonLogEnvelopeValidations(t, envelope, defaultExceptionDataEnvelopeName)
+
+	data := envelope.Data.(*contracts.Data).BaseData.(*contracts.ExceptionData)
+	require.Len(t, data.Exceptions, 1)
+	assert.Equal(t, "division by zero", data.Exceptions[0].Message)
+	assert.False(t, data.Exceptions[0].HasFullStack)
+}
+
+func TestSeverityNumberToSeverityLevel(t *testing.T) {
+	tests := []struct {
+		severityNumber pdata.SeverityNumber
+		want           contracts.SeverityLevel
+	}{
+		{pdata.SeverityNumberUNDEFINED, contracts.Information},
+		{pdata.SeverityNumberTRACE, contracts.Verbose},
+		{pdata.SeverityNumberDEBUG4, contracts.Verbose},
+		{pdata.SeverityNumberINFO, contracts.Information},
+		{pdata.SeverityNumberINFO4, contracts.Information},
+		{pdata.SeverityNumberWARN, contracts.Warning},
+		{pdata.SeverityNumberWARN4, contracts.Warning},
+		{pdata.SeverityNumberERROR, contracts.Error},
+		{pdata.SeverityNumberERROR4, contracts.Error},
+		{pdata.SeverityNumberFATAL, contracts.Critical},
+		{pdata.SeverityNumberFATAL4, contracts.Critical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severityNumber.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, severityNumberToSeverityLevel(tt.severityNumber))
+		})
+	}
+}
+
+func commonLogEnvelopeValidations(t *testing.T, envelope *contracts.Envelope, expectedEnvelopeName string) {
+	assert.NotNil(t, envelope)
+	assert.Equal(t, expectedEnvelopeName, envelope.Name)
+	assert.Equal(t, toTime(defaultLogTime).Format(time.RFC3339Nano), envelope.Time)
+	assert.Equal(t, defaultServiceNamespace+"."+defaultServiceName, envelope.Tags[contracts.CloudRole])
+	assert.Equal(t, defaultServiceInstance, envelope.Tags[contracts.CloudRoleInstance])
+	assert.NotNil(t, envelope.Data)
+}
+
+// Returns a default LogRecord with trace context
+func getDefaultLogRecord() pdata.LogRecord {
+	logRecord := pdata.NewLogRecord()
+	logRecord.SetName(defaultLogName)
+	logRecord.SetTimestamp(defaultLogTime)
+	logRecord.SetTraceID(pdata.NewTraceID(defaultTraceID))
+	logRecord.SetSpanID(pdata.NewSpanID(defaultSpanID))
+	logRecord.Body().SetStringVal(defaultLogMessage)
+	logRecord.Attributes().InsertString("foo", "bar")
+	logRecord.Attributes().InsertInt("answer", 42)
+	return logRecord
+}
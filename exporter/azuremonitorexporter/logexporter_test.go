@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// Tests the export onLogData callback with no log records
+func TestExporterLogDataCallbackNoLogs(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	exporter := getLogExporter(defaultConfig, mockTransportChannel)
+
+	logs := pdata.NewLogs()
+
+	assert.NoError(t, exporter.onLogData(context.Background(), logs))
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 0)
+}
+
+// Tests the export onLogData callback with multiple log records
+func TestExporterLogDataCallbackMultipleLogs(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.InstrumentationKey = "ikey"
+	mockTransportChannel := &mockTransportChannel{}
+	mockTransportChannel.On("Send", mock.MatchedBy(func(envelope *contracts.Envelope) bool {
+		return envelope.IKey == "ikey"
+	}))
+	exporter := getLogExporter(config, mockTransportChannel)
+
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	getResource().CopyTo(rl.Resource())
+	ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+	getInstrumentationLibrary().CopyTo(ill.InstrumentationLibrary())
+	getDefaultLogRecord().CopyTo(ill.Logs().AppendEmpty())
+	getDefaultLogRecord().CopyTo(ill.Logs().AppendEmpty())
+
+	assert.NoError(t, exporter.onLogData(context.Background(), logs))
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 2)
+}
+
+func getLogExporter(config *Config, transportChannel transportChannel) *logExporter {
+	return &logExporter{
+		config,
+		transportChannel,
+		zap.NewNop(),
+	}
+}
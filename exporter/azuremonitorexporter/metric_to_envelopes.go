@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// Transforms a tuple of pdata.Resource, pdata.InstrumentationLibrary, pdata.Metric into AppInsights contracts.Envelopes.
+// Each data point of the metric is mapped to its own MetricData envelope, with the data point labels as properties.
+func metricToEnvelopes(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	metric pdata.Metric,
+	logger *zap.Logger) []*contracts.Envelope {
+
+	var envelopes []*contracts.Envelope
+	addEnvelope := func(timestamp pdata.Timestamp, labels pdata.StringMap, dataPoint *contracts.DataPoint) {
+		envelopes = append(envelopes, dataPointToEnvelope(resource, instrumentationLibrary, timestamp, labels, dataPoint, logger))
+	}
+
+	name := metric.Name()
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		addIntDataPoints(name, metric.IntGauge().DataPoints(), addEnvelope)
+	case pdata.MetricDataTypeGauge:
+		addNumberDataPoints(name, metric.Gauge().DataPoints(), addEnvelope)
+	case pdata.MetricDataTypeIntSum:
+		addIntDataPoints(name, metric.IntSum().DataPoints(), addEnvelope)
+	case pdata.MetricDataTypeSum:
+		addNumberDataPoints(name, metric.Sum().DataPoints(), addEnvelope)
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dataPoint := newAggregationDataPoint(name, dp.Sum(), dp.Count())
+			dataPoint.Min, dataPoint.Max = histogramMinMax(dp)
+			addEnvelope(dp.Timestamp(), dp.LabelsMap(), dataPoint)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dataPoint := newAggregationDataPoint(name, dp.Sum(), dp.Count())
+
+			// The 0 and 1 quantiles, when present, are the min and max values
+			quantiles := dp.QuantileValues()
+			for j := 0; j < quantiles.Len(); j++ {
+				switch q := quantiles.At(j); q.Quantile() {
+				case 0:
+					dataPoint.Min = q.Value()
+				case 1:
+					dataPoint.Max = q.Value()
+				}
+			}
+			addEnvelope(dp.Timestamp(), dp.LabelsMap(), dataPoint)
+		}
+	default:
+		logger.Debug("Unsupported metric type", zap.String("metric", name), zap.String("type", metric.DataType().String()))
+	}
+
+	return envelopes
+}
+
+func addIntDataPoints(name string, dps pdata.IntDataPointSlice, addEnvelope func(pdata.Timestamp, pdata.StringMap, *contracts.DataPoint)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		addEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurementDataPoint(name, float64(dp.Value())))
+	}
+}
+
+func addNumberDataPoints(name string, dps pdata.NumberDataPointSlice, addEnvelope func(pdata.Timestamp, pdata.StringMap, *contracts.DataPoint)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		var value float64
+		switch dp.Type() {
+		case pdata.MetricValueTypeInt:
+			value = float64(dp.IntVal())
+		case pdata.MetricValueTypeDouble:
+			value = dp.DoubleVal()
+		}
+		addEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurementDataPoint(name, value))
+	}
+}
+
+// Single measurement, e.g. a gauge or a sum value
+func newMeasurementDataPoint(name string, value float64) *contracts.DataPoint {
+	dataPoint := contracts.NewDataPoint()
+	dataPoint.Name = name
+	dataPoint.Kind = contracts.Measurement
+	dataPoint.Value = value
+	dataPoint.Count = 1
+	return dataPoint
+}
+
+// Pre-aggregated measurements, e.g. a histogram or a summary. The value is the sum of the measurements.
+func newAggregationDataPoint(name string, sum float64, count uint64) *contracts.DataPoint {
+	dataPoint := contracts.NewDataPoint()
+	dataPoint.Name = name
+	dataPoint.Kind = contracts.Aggregation
+	dataPoint.Value = sum
+	dataPoint.Count = int(count)
+	return dataPoint
+}
+
+// The histogram does not record its min and max values, so they are approximated by the bounds of the
+// outermost non-empty buckets, the unbounded first and last buckets by their finite bound. Without valid
+// buckets, both are 0.
+func histogramMinMax(dp pdata.HistogramDataPoint) (float64, float64) {
+	bounds := dp.ExplicitBounds()
+	counts := dp.BucketCounts()
+	if len(bounds) == 0 || len(counts) != len(bounds)+1 {
+		return 0, 0
+	}
+
+	first, last := -1, -1
+	for i, count := range counts {
+		if count > 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return 0, 0
+	}
+
+	min := bounds[0]
+	if first > 0 {
+		min = bounds[first-1]
+	}
+	max := bounds[len(bounds)-1]
+	if last < len(bounds) {
+		max = bounds[last]
+	}
+	return min, max
+}
+
+func dataPointToEnvelope(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	timestamp pdata.Timestamp,
+	labels pdata.StringMap,
+	dataPoint *contracts.DataPoint,
+	logger *zap.Logger) *contracts.Envelope {
+
+	envelope := contracts.NewEnvelope()
+	envelope.Tags = make(map[string]string)
+	envelope.Time = toTime(timestamp).Format(time.RFC3339Nano)
+
+	// See https://github.com/microsoft/ApplicationInsights-Go/blob/master/appinsights/contracts/metricdata.go
+	metricData := contracts.NewMetricData()
+	metricData.Metrics = []*contracts.DataPoint{dataPoint}
+	metricData.Properties = make(map[string]string)
+	labels.Range(func(k string, v string) bool {
+		metricData.Properties[k] = v
+		return true
+	})
+
+	data := contracts.NewData()
+	data.BaseData = metricData
+	data.BaseType = metricData.BaseType()
+	envelope.Name = metricData.EnvelopeName("")
+	envelope.Data = data
+
+	resourceAttributes := resource.Attributes()
+	applyResourcesToDataProperties(metricData.Properties, resourceAttributes)
+	applyInstrumentationLibraryValueToDataProperties(metricData.Properties, instrumentationLibrary)
+	applyCloudTagsToEnvelope(envelope, resourceAttributes)
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(metricData.Sanitize, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMetricDataEnvelopeName = "Microsoft.ApplicationInsights.Metric"
+	defaultMetricName             = "mymetric"
+)
+
+var (
+	defaultMetricTime = pdata.Timestamp(1626105600000000000)
+)
+
+func TestMetricToEnvelopesGauges(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName(defaultMetricName)
+	metric.SetDataType(pdata.MetricDataTypeGauge)
+	dps := metric.Gauge().DataPoints()
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(defaultMetricTime)
+	dp.SetDoubleVal(1.5)
+	dp.LabelsMap().Insert("foo", "bar")
+	dp = dps.AppendEmpty()
+	dp.SetTimestamp(defaultMetricTime)
+	dp.SetIntVal(3)
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 2)
+
+	data := commonMetricEnvelopeValidations(t, envelopes[0])
+	assert.Equal(t, contracts.Measurement, data.Metrics[0].Kind)
+	assert.Equal(t, 1.5, data.Metrics[0].Value)
+	assert.Equal(t, 1, data.Metrics[0].Count)
+	assert.Equal(t, "bar", data.Properties["foo"])
+
+	data = commonMetricEnvelopeValidations(t, envelopes[1])
+	assert.Equal(t, float64(3), data.Metrics[0].Value)
+	assert.NotContains(t, data.Properties, "foo")
+}
+
+func TestMetricToEnvelopesIntSum(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName(defaultMetricName)
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	dp := metric.IntSum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(defaultMetricTime)
+	dp.SetValue(7)
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 1)
+
+	data := commonMetricEnvelopeValidations(t, envelopes[0])
+	assert.Equal(t, contracts.Measurement, data.Metrics[0].Kind)
+	assert.Equal(t, float64(7), data.Metrics[0].Value)
+}
+
+func TestMetricToEnvelopesHistogram(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName(defaultMetricName)
+	metric.SetDataType(pdata.MetricDataTypeHistogram)
+	dp := metric.Histogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(defaultMetricTime)
+	dp.SetCount(4)
+	dp.SetSum(10)
+	dp.SetExplicitBounds([]float64{1, 5})
+	dp.SetBucketCounts([]uint64{1, 2, 1})
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 1)
+
+	data := commonMetricEnvelopeValidations(t, envelopes[0])
+	assert.Equal(t, contracts.Aggregation, data.Metrics[0].Kind)
+	assert.Equal(t, float64(10), data.Metrics[0].Value)
+	assert.Equal(t, 4, data.Metrics[0].Count)
+	assert.Equal(t, float64(1), data.Metrics[0].Min)
+	assert.Equal(t, float64(5), data.Metrics[0].Max)
+}
+
+func TestHistogramMinMax(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		bounds   []float64
+		counts   []uint64
+		min, max float64
+	}{
+		{"all buckets", []float64{1, 5}, []uint64{1, 2, 1}, 1, 5},
+		{"inner buckets", []float64{1, 2, 4, 8}, []uint64{0, 2, 0, 3, 0}, 1, 8},
+		{"first bucket only", []float64{1, 5}, []uint64{3, 0, 0}, 1, 1},
+		{"empty buckets", []float64{1, 5}, []uint64{0, 0, 0}, 0, 0},
+		{"no bounds", nil, []uint64{4}, 0, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := pdata.NewHistogramDataPoint()
+			dp.SetExplicitBounds(tt.bounds)
+			dp.SetBucketCounts(tt.counts)
+
+			min, max := histogramMinMax(dp)
+			assert.Equal(t, tt.min, min)
+			assert.Equal(t, tt.max, max)
+		})
+	}
+}
+
+func TestMetricToEnvelopesSummary(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName(defaultMetricName)
+	metric.SetDataType(pdata.MetricDataTypeSummary)
+	dp := metric.Summary().DataPoints().AppendEmpty()
+	dp.SetTimestamp(defaultMetricTime)
+	dp.SetCount(3)
+	dp.SetSum(6)
+	for _, q := range [][2]float64{{0, 1}, {0.5, 2}, {1, 3}} {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q[0])
+		qv.SetValue(q[1])
+	}
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 1)
+
+	data := commonMetricEnvelopeValidations(t, envelopes[0])
+	assert.Equal(t, contracts.Aggregation, data.Metrics[0].Kind)
+	assert.Equal(t, float64(6), data.Metrics[0].Value)
+	assert.Equal(t, 3, data.Metrics[0].Count)
+	assert.Equal(t, float64(1), data.Metrics[0].Min)
+	assert.Equal(t, float64(3), data.Metrics[0].Max)
+}
+
+func TestMetricToEnvelopesNone(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName(defaultMetricName)
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	assert.Empty(t, envelopes)
+}
+
+// Validate common stuff across any Metric -> MetricData translation and returns the MetricData
+func commonMetricEnvelopeValidations(t *testing.T, envelope *contracts.Envelope) *contracts.MetricData {
+	assert.NotNil(t, envelope)
+	assert.Equal(t, defaultMetricDataEnvelopeName, envelope.Name)
+	assert.Equal(t, toTime(defaultMetricTime).Format(time.RFC3339Nano), envelope.Time)
+	assert.Equal(t, defaultServiceNamespace+"."+defaultServiceName, envelope.Tags[contracts.CloudRole])
+	assert.Equal(t, defaultServiceInstance, envelope.Tags[contracts.CloudRoleInstance])
+
+	data := envelope.Data.(*contracts.Data).BaseData.(*contracts.MetricData)
+	require.Len(t, data.Metrics, 1)
+	assert.Equal(t, defaultMetricName, data.Metrics[0].Name)
+	assert.Equal(t, defaultServiceName, data.Properties[conventions.AttributeServiceName])
+	assert.Equal(t, defaultInstrumentationLibraryName, data.Properties[instrumentationLibraryName])
+	return data
+}
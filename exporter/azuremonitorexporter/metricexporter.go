@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type metricExporter struct {
+	config           *Config
+	transportChannel transportChannel
+	logger           *zap.Logger
+}
+
+func (exporter *metricExporter) onMetricData(context context.Context, metricData pdata.Metrics) error {
+	resourceMetrics := metricData.ResourceMetrics()
+
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		resource := rm.Resource()
+		instrumentationLibraryMetricsSlice := rm.InstrumentationLibraryMetrics()
+
+		for j := 0; j < instrumentationLibraryMetricsSlice.Len(); j++ {
+			instrumentationLibraryMetrics := instrumentationLibraryMetricsSlice.At(j)
+			instrumentationLibrary := instrumentationLibraryMetrics.InstrumentationLibrary()
+			metrics := instrumentationLibraryMetrics.Metrics()
+
+			for k := 0; k < metrics.Len(); k++ {
+				for _, envelope := range metricToEnvelopes(resource, instrumentationLibrary, metrics.At(k), exporter.logger) {
+					// apply the instrumentation key to the envelope
+					envelope.IKey = exporter.config.InstrumentationKey
+
+					// This is a fire and forget operation
+					exporter.transportChannel.Send(envelope)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func newMetricsExporter(config *Config, transportChannel transportChannel, set component.ExporterCreateSettings) (component.MetricsExporter, error) {
+	exporter := &metricExporter{
+		config:           config,
+		transportChannel: transportChannel,
+		logger:           set.Logger,
+	}
+
+	return exporterhelper.NewMetricsExporter(config, set, exporter.onMetricData)
+}
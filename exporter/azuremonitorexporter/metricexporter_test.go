@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// Tests the export onMetricData callback with no metrics
+func TestExporterMetricDataCallbackNoMetrics(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	exporter := getMetricExporter(defaultConfig, mockTransportChannel)
+
+	metrics := pdata.NewMetrics()
+
+	assert.NoError(t, exporter.onMetricData(context.Background(), metrics))
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 0)
+}
+
+// Tests the export onMetricData callback sends one envelope per data point
+func TestExporterMetricDataCallbackMultipleDataPoints(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.InstrumentationKey = "ikey"
+	mockTransportChannel := &mockTransportChannel{}
+	mockTransportChannel.On("Send", mock.MatchedBy(func(envelope *contracts.Envelope) bool {
+		return envelope.IKey == "ikey"
+	}))
+	exporter := getMetricExporter(config, mockTransportChannel)
+
+	metrics := pdata.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	getResource().CopyTo(rm.Resource())
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	getInstrumentationLibrary().CopyTo(ilm.InstrumentationLibrary())
+
+	gauge := ilm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(1)
+	gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(2)
+
+	histogram := ilm.Metrics().AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().SetCount(1)
+
+	assert.NoError(t, exporter.onMetricData(context.Background(), metrics))
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 3)
+}
+
+func getMetricExporter(config *Config, transportChannel transportChannel) *metricExporter {
+	return &metricExporter{
+		config,
+		transportChannel,
+		zap.NewNop(),
+	}
+}
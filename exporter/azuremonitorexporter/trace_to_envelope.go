@@ -114,14 +114,28 @@ func spanToEnvelope(
 
 	envelope.Data = data
 	resourceAttributes := resource.Attributes()
+	applyResourcesToDataProperties(dataProperties, resourceAttributes)
+	applyInstrumentationLibraryValueToDataProperties(dataProperties, instrumentationLibrary)
+	applyCloudTagsToEnvelope(envelope, resourceAttributes)
 
-	// Copy all the resource labels into the base data properties. Resource values are always strings
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(dataSanitizeFunc, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope, nil
+}
+
+// Copies all the resource labels into the base data properties. Resource values are always strings
+func applyResourcesToDataProperties(dataProperties map[string]string, resourceAttributes pdata.AttributeMap) {
 	resourceAttributes.Range(func(k string, v pdata.AttributeValue) bool {
 		dataProperties[k] = v.StringVal()
 		return true
 	})
+}
 
-	// Copy the instrumentation properties
+// Copies the instrumentation library name and version into the base data properties
+func applyInstrumentationLibraryValueToDataProperties(dataProperties map[string]string, instrumentationLibrary pdata.InstrumentationLibrary) {
 	if instrumentationLibrary.Name() != "" {
 		dataProperties[instrumentationLibraryName] = instrumentationLibrary.Name()
 	}
@@ -129,9 +143,11 @@ func spanToEnvelope(
 	if instrumentationLibrary.Version() != "" {
 		dataProperties[instrumentationLibraryVersion] = instrumentationLibrary.Version()
 	}
+}
 
-	// Extract key service.* labels from the Resource labels and construct CloudRole and CloudRoleInstance envelope tags
-	// https://github.com/open-telemetry/opentelemetry-specification/tree/main/specification/resource/semantic_conventions
+// Extracts key service.* labels from the Resource labels and constructs CloudRole and CloudRoleInstance envelope tags
+// https://github.com/open-telemetry/opentelemetry-specification/tree/main/specification/resource/semantic_conventions
+func applyCloudTagsToEnvelope(envelope *contracts.Envelope, resourceAttributes pdata.AttributeMap) {
 	if serviceName, serviceNameExists := resourceAttributes.Get(conventions.AttributeServiceName); serviceNameExists {
 		cloudRole := serviceName.StringVal()
 
@@ -145,13 +161,6 @@ func spanToEnvelope(
 	if serviceInstance, exists := resourceAttributes.Get(conventions.AttributeServiceInstance); exists {
 		envelope.Tags[contracts.CloudRoleInstance] = serviceInstance.StringVal()
 	}
-
-	// Sanitize the base data, the envelope and envelope tags
-	sanitize(dataSanitizeFunc, logger)
-	sanitize(func() []string { return envelope.Sanitize() }, logger)
-	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
-
-	return envelope, nil
 }
 
 // Maps Server/Consumer Span to AppInsights RequestData
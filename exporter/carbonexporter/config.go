@@ -24,6 +24,20 @@ import (
 const (
 	DefaultEndpoint    = "localhost:2003"
 	DefaultSendTimeout = 5 * time.Second
+	DefaultTransport   = TransportTCP
+	DefaultProtocol    = ProtocolPlaintext
+)
+
+// Values for the transport used to send data to Carbon.
+const (
+	TransportTCP = "tcp"
+	TransportUDP = "udp"
+)
+
+// Values for the protocol used to send data to Carbon.
+const (
+	ProtocolPlaintext = "plaintext"
+	ProtocolPickle    = "pickle"
 )
 
 // Config defines configuration for Carbon exporter.
@@ -38,4 +52,23 @@ type Config struct {
 	// data to the Carbon/Graphite backend.
 	// The default value is defined by the DefaultSendTimeout constant.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Transport is the transport used to send data to the Carbon/Graphite
+	// backend, either "tcp" or "udp". The pickle protocol is only supported
+	// over "tcp". The default value is defined by the DefaultTransport constant.
+	Transport string `mapstructure:"transport"`
+
+	// Protocol is the Carbon protocol used to send the data, either
+	// "plaintext" or "pickle". The default value is defined by the
+	// DefaultProtocol constant.
+	Protocol string `mapstructure:"protocol"`
+
+	// NameTemplate builds the metric paths from the metric name, labels and
+	// resource attributes, eg.: "{host.name}.{service.name}.{metric_name}".
+	// The "{metric_name}" placeholder is replaced by the metric name and the
+	// other placeholders by the value of the metric label or, if the metric
+	// doesn't have the label, of the resource attribute with that key. Labels
+	// used in the template are not added as tags. When empty the path is the
+	// metric name.
+	NameTemplate string `mapstructure:"name_template"`
 }
@@ -48,6 +48,9 @@ func TestLoadConfig(t *testing.T) {
 		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "allsettings")),
 		Endpoint:         "localhost:8080",
 		Timeout:          10 * time.Second,
+		Transport:        TransportTCP,
+		Protocol:         ProtocolPickle,
+		NameTemplate:     "{host.name}.{metric_name}",
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -15,6 +15,7 @@
 package carbonexporter
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
@@ -22,18 +23,54 @@ import (
 	"time"
 
 	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/translator/internaldata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 )
 
+// udpMaxPayloadSize is the maximum size of the datagrams sent over UDP, lines
+// are not split across datagrams.
+const udpMaxPayloadSize = 1432
+
 // newCarbonExporter returns a new Carbon exporter.
 func newCarbonExporter(cfg *Config, set component.ExporterCreateSettings) (component.MetricsExporter, error) {
-	// Resolve TCP address just to ensure that it is a valid one. It is better
-	// to fail here than at when the exporter is started.
-	if _, err := net.ResolveTCPAddr("tcp", cfg.Endpoint); err != nil {
-		return nil, fmt.Errorf("%v exporter has an invalid TCP endpoint: %w", cfg.ID(), err)
+	transport := cfg.Transport
+	if transport == "" {
+		transport = DefaultTransport
+	}
+	protocol := cfg.Protocol
+	if protocol == "" {
+		protocol = DefaultProtocol
+	}
+
+	switch transport {
+	case TransportTCP:
+		// Resolve TCP address just to ensure that it is a valid one. It is better
+		// to fail here than at when the exporter is started.
+		if _, err := net.ResolveTCPAddr("tcp", cfg.Endpoint); err != nil {
+			return nil, fmt.Errorf("%v exporter has an invalid TCP endpoint: %w", cfg.ID(), err)
+		}
+	case TransportUDP:
+		if _, err := net.ResolveUDPAddr("udp", cfg.Endpoint); err != nil {
+			return nil, fmt.Errorf("%v exporter has an invalid UDP endpoint: %w", cfg.ID(), err)
+		}
+	default:
+		return nil, fmt.Errorf("%v exporter has an unsupported transport %q", cfg.ID(), transport)
+	}
+
+	switch protocol {
+	case ProtocolPlaintext:
+	case ProtocolPickle:
+		if transport != TransportTCP {
+			return nil, fmt.Errorf("%v exporter supports the %q protocol only over %q", cfg.ID(), ProtocolPickle, TransportTCP)
+		}
+	default:
+		return nil, fmt.Errorf("%v exporter has an unsupported protocol %q", cfg.ID(), protocol)
 	}
 
 	// Negative timeouts are not acceptable, since all sends will fail.
@@ -41,8 +78,22 @@ func newCarbonExporter(cfg *Config, set component.ExporterCreateSettings) (compo
 		return nil, fmt.Errorf("%v exporter requires a positive timeout", cfg.ID())
 	}
 
+	var template *nameTemplate
+	if cfg.NameTemplate != "" {
+		var err error
+		if template, err = parseNameTemplate(cfg.NameTemplate); err != nil {
+			return nil, fmt.Errorf("%v exporter has an invalid name template: %w", cfg.ID(), err)
+		}
+	}
+
+	connPool := newConnPool(transport, cfg.Endpoint, cfg.Timeout)
+	connPool.exporterName = cfg.ID().String()
 	sender := carbonSender{
-		connPool: newTCPConnPool(cfg.Endpoint, cfg.Timeout),
+		connPool:     connPool,
+		transport:    transport,
+		protocol:     protocol,
+		template:     template,
+		exporterName: cfg.ID().String(),
 	}
 
 	return exporterhelper.NewMetricsExporter(
@@ -52,29 +103,85 @@ func newCarbonExporter(cfg *Config, set component.ExporterCreateSettings) (compo
 		exporterhelper.WithShutdown(sender.Shutdown))
 }
 
-// carbonSender is the struct tying the translation function and the TCP
+// carbonSender is the struct tying the translation function and the TCP or UDP
 // connections into an implementations of exporterhelper.PushMetricsData so
 // the exporter can leverage the helper and get consistent observability.
 type carbonSender struct {
 	connPool *connPool
+
+	// transport and protocol used to send the metrics, the zero values are
+	// the "tcp" transport and "plaintext" protocol.
+	transport string
+	protocol  string
+
+	// template builds the metric paths, if not nil.
+	template *nameTemplate
+
+	// exporterName is the tag value of the self-metrics.
+	exporterName string
 }
 
-func (cs *carbonSender) pushMetricsData(_ context.Context, md pdata.Metrics) error {
+func (cs *carbonSender) pushMetricsData(ctx context.Context, md pdata.Metrics) error {
 	rms := md.ResourceMetrics()
 	mds := make([]*agentmetricspb.ExportMetricsServiceRequest, 0, rms.Len())
+	var resources []map[string]string
+	if cs.template != nil {
+		resources = make([]map[string]string, 0, rms.Len())
+	}
 	for i := 0; i < rms.Len(); i++ {
 		emsr := &agentmetricspb.ExportMetricsServiceRequest{}
 		emsr.Node, emsr.Resource, emsr.Metrics = internaldata.ResourceMetricsToOC(rms.At(i))
 		mds = append(mds, emsr)
+		if cs.template != nil {
+			resources = append(resources, resourceAttributes(rms.At(i).Resource()))
+		}
 	}
-	lines, _, _ := metricDataToPlaintext(mds)
+	metrics, _, numDropped := metricDataToCarbon(mds, resources, cs.template)
 
-	if _, err := cs.connPool.Write([]byte(lines)); err != nil {
-		// Use the sum of converted and dropped since the write failed for all.
-		return err
+	var err error
+	switch {
+	case cs.protocol == ProtocolPickle:
+		payload, numPickleDropped := formatPickle(metrics)
+		numDropped += numPickleDropped
+		if len(payload) > 0 {
+			_, err = cs.connPool.Write(payload)
+		}
+	case cs.transport == TransportUDP:
+		err = cs.writeDatagrams(metrics)
+	default:
+		_, err = cs.connPool.Write([]byte(formatPlaintext(metrics)))
 	}
 
-	return nil
+	if numDropped > 0 {
+		_ = stats.RecordWithTags(ctx,
+			[]tag.Mutator{tag.Upsert(tagExporterKey, cs.exporterName)},
+			mDroppedPoints.M(int64(numDropped)))
+	}
+
+	return err
+}
+
+// writeDatagrams writes the plaintext lines of the metrics in datagrams of up
+// to udpMaxPayloadSize bytes, lines larger than this are sent alone.
+func (cs *carbonSender) writeDatagrams(metrics []carbonMetric) error {
+	var buf bytes.Buffer
+	var errs []error
+	for _, metric := range metrics {
+		line := buildLine(metric.path, metric.value, metric.timestamp)
+		if buf.Len() > 0 && buf.Len()+len(line) > udpMaxPayloadSize {
+			if _, err := cs.connPool.Write(buf.Bytes()); err != nil {
+				errs = append(errs, err)
+			}
+			buf.Reset()
+		}
+		buf.WriteString(line)
+	}
+	if buf.Len() > 0 {
+		if _, err := cs.connPool.Write(buf.Bytes()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
 }
 
 func (cs *carbonSender) Shutdown(context.Context) error {
@@ -82,34 +189,62 @@ func (cs *carbonSender) Shutdown(context.Context) error {
 	return nil
 }
 
-// connPool is a very simple implementation of a pool of net.TCPConn instances.
+// resourceAttributes returns the string representation of the resource
+// attributes, to be used by the name template.
+func resourceAttributes(resource pdata.Resource) map[string]string {
+	attrs := make(map[string]string, resource.Attributes().Len())
+	resource.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		attrs[k] = tracetranslator.AttributeValueToString(v)
+		return true
+	})
+	return attrs
+}
+
+// connPool is a very simple implementation of a pool of net.Conn instances.
 // The implementation hides the pool and exposes a Write and Close methods.
 // It leverages the prior art from SignalFx Gateway (see
 // https://github.com/signalfx/gateway/blob/master/protocol/carbon/conn_pool.go
 // but not its implementation).
 //
-// It keeps a unbounded "stack" of TCPConn instances always "popping" the most
+// It keeps a unbounded "stack" of Conn instances always "popping" the most
 // recently returned to the pool. There is no accounting to terminating old
 // unused connections as that was the case on the prior art mentioned above.
 type connPool struct {
 	mtx      sync.Mutex
-	conns    []*net.TCPConn
+	conns    []net.Conn
+	network  string
 	endpoint string
 	timeout  time.Duration
+
+	// numFailed is the number of connections closed after a failure that were
+	// not re-established yet.
+	numFailed int
+
+	// exporterName is the tag value of the self-metrics.
+	exporterName string
 }
 
 func newTCPConnPool(
 	endpoint string,
 	timeout time.Duration,
+) *connPool {
+	return newConnPool(TransportTCP, endpoint, timeout)
+}
+
+func newConnPool(
+	network string,
+	endpoint string,
+	timeout time.Duration,
 ) *connPool {
 	return &connPool{
+		network:  network,
 		endpoint: endpoint,
 		timeout:  timeout,
 	}
 }
 
 func (cp *connPool) Write(bytes []byte) (int, error) {
-	var conn *net.TCPConn
+	var conn net.Conn
 	var err error
 
 	// The deferred function below is what puts back connections on the pool.
@@ -121,6 +256,9 @@ func (cp *connPool) Write(bytes []byte) (int, error) {
 		} else {
 			if conn != nil {
 				conn.Close()
+				cp.mtx.Lock()
+				cp.numFailed++
+				cp.mtx.Unlock()
 			}
 		}
 	}()
@@ -134,7 +272,7 @@ func (cp *connPool) Write(bytes []byte) (int, error) {
 	}
 	cp.mtx.Unlock()
 	if conn == nil {
-		if conn, err = cp.createTCPConn(); err != nil {
+		if conn, err = cp.createConn(); err != nil {
 			return 0, err
 		}
 	}
@@ -177,10 +315,23 @@ func (cp *connPool) Close() {
 	cp.conns = nil
 }
 
-func (cp *connPool) createTCPConn() (*net.TCPConn, error) {
-	c, err := net.DialTimeout("tcp", cp.endpoint, cp.timeout)
+func (cp *connPool) createConn() (net.Conn, error) {
+	c, err := net.DialTimeout(cp.network, cp.endpoint, cp.timeout)
 	if err != nil {
 		return nil, err
 	}
-	return c.(*net.TCPConn), err
+
+	cp.mtx.Lock()
+	reconnect := cp.numFailed > 0
+	if reconnect {
+		cp.numFailed--
+	}
+	cp.mtx.Unlock()
+	if reconnect {
+		_ = stats.RecordWithTags(context.Background(),
+			[]tag.Mutator{tag.Upsert(tagExporterKey, cp.exporterName)},
+			mReconnects.M(1))
+	}
+
+	return c, nil
 }
@@ -17,6 +17,7 @@ package carbonexporter
 import (
 	"bufio"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net"
@@ -66,6 +67,51 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "udp",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Endpoint:         DefaultEndpoint,
+				Transport:        TransportUDP,
+			},
+		},
+		{
+			name: "invalid_transport",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Endpoint:         DefaultEndpoint,
+				Transport:        "quic",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid_protocol",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Endpoint:         DefaultEndpoint,
+				Protocol:         "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "pickle_over_udp",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Endpoint:         DefaultEndpoint,
+				Transport:        TransportUDP,
+				Protocol:         ProtocolPickle,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid_name_template",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Endpoint:         DefaultEndpoint,
+				NameTemplate:     "{host.name}",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -279,6 +325,87 @@ func Test_connPool_Concurrency(t *testing.T) {
 	recvWG.Wait()
 }
 
+func TestConsumeMetricsData_UDP(t *testing.T) {
+	laddr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ln, err := net.ListenUDP("udp", laddr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		Endpoint:         ln.LocalAddr().String(),
+		Timeout:          time.Second,
+		Transport:        TransportUDP,
+		NameTemplate:     "{host.name}.{metric_name}",
+	}
+	exp, err := newCarbonExporter(cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := generateSmallBatch()
+	md.ResourceMetrics().At(0).Resource().Attributes().InsertString("host.name", "host00")
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	assert.NoError(t, exp.Shutdown(context.Background()))
+
+	require.NoError(t, ln.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, udpMaxPayloadSize)
+	n, err := ln.Read(buf)
+	require.NoError(t, err)
+	assert.Regexp(t, `^host00\.test_gauge;k0=v0;k1=v1 123 \d+\n$`, string(buf[:n]))
+}
+
+func TestConsumeMetricsData_Pickle(t *testing.T) {
+	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ln, err := net.ListenTCP("tcp", laddr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		Endpoint:         ln.Addr().String(),
+		Timeout:          time.Second,
+		Protocol:         ProtocolPickle,
+	}
+	exp, err := newCarbonExporter(cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.AcceptTCP()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		payload, _ := io.ReadAll(conn)
+		received <- payload
+	}()
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), generateSmallBatch()))
+	assert.NoError(t, exp.Shutdown(context.Background()))
+
+	payload := <-received
+	require.Greater(t, len(payload), 4)
+	assert.Equal(t, uint32(len(payload)-4), binary.BigEndian.Uint32(payload))
+	assert.Contains(t, string(payload), "test_gauge;k0=v0;k1=v1")
+}
+
+func generateSmallBatch() pdata.Metrics {
+	return internaldata.OCToMetrics(nil, nil, []*metricspb.Metric{
+		metricstestutil.Gauge(
+			"test_gauge",
+			[]string{"k0", "k1"},
+			metricstestutil.Timeseries(
+				time.Now(),
+				[]string{"v0", "v1"},
+				metricstestutil.Double(time.Now(), 123))),
+	})
+}
+
 func generateLargeBatch() pdata.Metrics {
 	var metrics []*metricspb.Metric
 	ts := time.Now()
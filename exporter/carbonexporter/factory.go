@@ -17,6 +17,7 @@ package carbonexporter
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -29,6 +30,8 @@ const (
 
 // NewFactory creates a factory for Carbon exporter.
 func NewFactory() component.ExporterFactory {
+	view.Register(MetricViews()...)
+
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -40,6 +43,8 @@ func createDefaultConfig() config.Exporter {
 		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
 		Endpoint:         DefaultEndpoint,
 		Timeout:          DefaultSendTimeout,
+		Transport:        DefaultTransport,
+		Protocol:         DefaultProtocol,
 	}
 }
 
@@ -52,6 +52,13 @@ const (
 	infinityCarbonValue = "inf"
 )
 
+// carbonMetric is a single Carbon metric point.
+type carbonMetric struct {
+	path      string
+	value     string
+	timestamp string
+}
+
 // metricDataToPlaintext converts internal metrics data to the Carbon plaintext
 // format as defined in https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol)
 // and https://graphite.readthedocs.io/en/latest/tags.html#carbon. See details
@@ -82,14 +89,46 @@ const (
 //  - number of time series successfully converted to carbon.
 // 	- number of time series that could not be converted to Carbon.
 func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (string, int, int) {
+	metrics, converted, dropped := metricDataToCarbon(mds, nil, nil)
+	return formatPlaintext(metrics), converted, dropped
+}
+
+// formatPlaintext concatenates the Carbon plaintext lines of the metrics.
+func formatPlaintext(metrics []carbonMetric) string {
+	var sb strings.Builder
+	for _, metric := range metrics {
+		sb.WriteString(buildLine(metric.path, metric.value, metric.timestamp))
+	}
+	return sb.String()
+}
+
+// metricDataToCarbon converts internal metrics data to Carbon metrics, see
+// metricDataToPlaintext for the details of the conversion. The paths are
+// built with the name template, if not nil, from the metric names, labels and
+// the resource attributes given at the same index as the metrics data.
+//
+// The returned values are:
+// 	- the Carbon metrics.
+//  - number of time series successfully converted to carbon.
+// 	- number of time series that could not be converted to Carbon.
+func metricDataToCarbon(
+	mds []*agentmetricspb.ExportMetricsServiceRequest,
+	resources []map[string]string,
+	template *nameTemplate,
+) ([]carbonMetric, int, int) {
 	if len(mds) == 0 {
-		return "", 0, 0
+		return nil, 0, 0
 	}
-	var sb strings.Builder
+	var metrics []carbonMetric
 	numTimeseriesDropped := 0
 	totalTimeseries := 0
 
-	for _, md := range mds {
+	for i, md := range mds {
+		var resource map[string]string
+		if i < len(resources) {
+			resource = resources[i]
+		}
+
 		for _, metric := range md.Metrics {
 			totalTimeseries++
 			descriptor := metric.MetricDescriptor
@@ -116,6 +155,13 @@ func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (s
 
 				// From this point on all code below is safe to assume that
 				// len(tagKeys) is equal to len(labelValues).
+				labelValues := ts.LabelValues
+				pathOf := func(metricName string) string {
+					if template == nil {
+						return buildPath(metricName, tagKeys, labelValues)
+					}
+					return template.buildPath(metricName, tagKeys, labelValues, resource)
+				}
 
 				for _, point := range ts.Points {
 					timestampStr := formatInt64(point.GetTimestamp().GetSeconds())
@@ -123,26 +169,24 @@ func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (s
 					switch pv := point.Value.(type) {
 
 					case *metricspb.Point_Int64Value:
-						path := buildPath(name, tagKeys, ts.LabelValues)
 						valueStr := formatInt64(pv.Int64Value)
-						sb.WriteString(buildLine(path, valueStr, timestampStr))
+						metrics = append(metrics, carbonMetric{pathOf(name), valueStr, timestampStr})
 
 					case *metricspb.Point_DoubleValue:
-						path := buildPath(name, tagKeys, ts.LabelValues)
 						valueStr := formatFloatForValue(pv.DoubleValue)
-						sb.WriteString(buildLine(path, valueStr, timestampStr))
+						metrics = append(metrics, carbonMetric{pathOf(name), valueStr, timestampStr})
 
 					case *metricspb.Point_DistributionValue:
-						err := buildDistributionIntoBuilder(
-							&sb, name, tagKeys, ts.LabelValues, timestampStr, pv.DistributionValue)
+						err := appendDistributionMetrics(
+							&metrics, name, pathOf, timestampStr, pv.DistributionValue)
 						if err != nil {
 							// TODO: log error info
 							numTimeseriesDropped++
 						}
 
 					case *metricspb.Point_SummaryValue:
-						err := buildSummaryIntoBuilder(
-							&sb, name, tagKeys, ts.LabelValues, timestampStr, pv.SummaryValue)
+						err := appendSummaryMetrics(
+							&metrics, name, pathOf, timestampStr, pv.SummaryValue)
 						if err != nil {
 							// TODO: log error info
 							numTimeseriesDropped++
@@ -153,11 +197,11 @@ func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (s
 		}
 	}
 
-	return sb.String(), totalTimeseries - numTimeseriesDropped, numTimeseriesDropped
+	return metrics, totalTimeseries - numTimeseriesDropped, numTimeseriesDropped
 }
 
-// buildDistributionIntoBuilder transforms a metric distribution into a series
-// of Carbon metrics and injects them into the string builder.
+// appendDistributionMetrics transforms a metric distribution into a series
+// of Carbon metrics and appends them to the metrics.
 //
 // Carbon doesn't have direct support to distribution metrics they will be
 // translated into a series of Carbon metrics:
@@ -170,19 +214,17 @@ func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (s
 // and will include a dimension "upper_bound" that specifies the maximum value in
 // that bucket. This metric specifies the number of events with a value that is
 // less than or equal to the upper bound.
-func buildDistributionIntoBuilder(
-	sb *strings.Builder,
+func appendDistributionMetrics(
+	metrics *[]carbonMetric,
 	metricName string,
-	tagKeys []string,
-	labelValues []*metricspb.LabelValue,
+	pathOf func(metricName string) string,
 	timestampStr string,
 	distributionValue *metricspb.DistributionValue,
 ) error {
-	buildCountAndSumIntoBuilder(
-		sb,
+	appendCountAndSumMetrics(
+		metrics,
 		metricName,
-		tagKeys,
-		labelValues,
+		pathOf,
 		distributionValue.GetCount(),
 		distributionValue.GetSum(),
 		timestampStr)
@@ -201,19 +243,19 @@ func buildDistributionIntoBuilder(
 	}
 	carbonBounds[len(carbonBounds)-1] = infinityCarbonValue
 
-	bucketPath := buildPath(metricName+distributionBucketSuffix, tagKeys, labelValues)
+	bucketPath := pathOf(metricName + distributionBucketSuffix)
 	for i, bucket := range distributionValue.Buckets {
-		sb.WriteString(buildLine(
-			bucketPath+distributionUpperBoundTagBeforeValue+carbonBounds[i],
+		*metrics = append(*metrics, carbonMetric{
+			bucketPath + distributionUpperBoundTagBeforeValue + carbonBounds[i],
 			formatInt64(bucket.Count),
-			timestampStr))
+			timestampStr})
 	}
 
 	return nil
 }
 
-// buildSummaryIntoBuilder transforms a metric summary into a series
-// of Carbon metrics and injects them into the string builder.
+// appendSummaryMetrics transforms a metric summary into a series of Carbon
+// metrics and appends them to the metrics.
 //
 // Carbon doesn't have direct support to summary metrics they will be
 // translated into a series of Carbon metrics:
@@ -224,19 +266,17 @@ func buildDistributionIntoBuilder(
 //
 // 3. Each quantile is represented by a metric named "<metricName>.quantile"
 // and will include a tag key "quantile" that specifies the quantile value.
-func buildSummaryIntoBuilder(
-	sb *strings.Builder,
+func appendSummaryMetrics(
+	metrics *[]carbonMetric,
 	metricName string,
-	tagKeys []string,
-	labelValues []*metricspb.LabelValue,
+	pathOf func(metricName string) string,
 	timestampStr string,
 	summaryValue *metricspb.SummaryValue,
 ) error {
-	buildCountAndSumIntoBuilder(
-		sb,
+	appendCountAndSumMetrics(
+		metrics,
 		metricName,
-		tagKeys,
-		labelValues,
+		pathOf,
 		summaryValue.GetCount().GetValue(),
 		summaryValue.GetSum().GetValue(),
 		timestampStr)
@@ -248,12 +288,12 @@ func buildSummaryIntoBuilder(
 			metricName)
 	}
 
-	quantilePath := buildPath(metricName+summaryQuantileSuffix, tagKeys, labelValues)
+	quantilePath := pathOf(metricName + summaryQuantileSuffix)
 	for _, quantile := range percentiles {
-		sb.WriteString(buildLine(
-			quantilePath+summaryQuantileTagBeforeValue+formatFloatForLabel(quantile.GetPercentile()),
+		*metrics = append(*metrics, carbonMetric{
+			quantilePath + summaryQuantileTagBeforeValue + formatFloatForLabel(quantile.GetPercentile()),
 			formatFloatForValue(quantile.GetValue()),
-			timestampStr))
+			timestampStr})
 	}
 
 	return nil
@@ -267,23 +307,22 @@ func buildSummaryIntoBuilder(
 //
 // 2. The total sum will be represented by a metruc with the original "<metricName>".
 //
-func buildCountAndSumIntoBuilder(
-	sb *strings.Builder,
+func appendCountAndSumMetrics(
+	metrics *[]carbonMetric,
 	metricName string,
-	tagKeys []string,
-	labelValues []*metricspb.LabelValue,
+	pathOf func(metricName string) string,
 	count int64,
 	sum float64,
 	timestampStr string,
 ) {
 	// Build count and sum metrics.
-	countPath := buildPath(metricName+countSuffix, tagKeys, labelValues)
+	countPath := pathOf(metricName + countSuffix)
 	valueStr := formatInt64(count)
-	sb.WriteString(buildLine(countPath, valueStr, timestampStr))
+	*metrics = append(*metrics, carbonMetric{countPath, valueStr, timestampStr})
 
-	sumPath := buildPath(metricName, tagKeys, labelValues)
+	sumPath := pathOf(metricName)
 	valueStr = formatFloatForValue(sum)
-	sb.WriteString(buildLine(sumPath, valueStr, timestampStr))
+	*metrics = append(*metrics, carbonMetric{sumPath, valueStr, timestampStr})
 }
 
 // buildPath is used to build the <metric_path> per description above. It
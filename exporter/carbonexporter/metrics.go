@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagExporterKey = tag.MustNewKey("exporter")
+
+	mReconnects    = stats.Int64("carbon_reconnects", "Number of times a connection to the Carbon endpoint was re-established after a failure", stats.UnitDimensionless)
+	mDroppedPoints = stats.Int64("carbon_dropped_points", "Number of metric points that could not be converted to Carbon metrics", stats.UnitDimensionless)
+)
+
+// MetricViews return the metrics views of the exporter.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mReconnects.Name(),
+			Measure:     mReconnects,
+			Description: mReconnects.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagExporterKey},
+		},
+		{
+			Name:        mDroppedPoints.Name(),
+			Measure:     mDroppedPoints,
+			Description: mDroppedPoints.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagExporterKey},
+		},
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"fmt"
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+const (
+	// metricNamePlaceholder is the placeholder of the name template that is
+	// replaced by the metric name.
+	metricNamePlaceholder = "metric_name"
+
+	// templateValueNotSetPlaceholder replaces the placeholders of the name
+	// template that don't match any label or resource attribute.
+	templateValueNotSetPlaceholder = "unknown"
+)
+
+// nameTemplate builds Carbon metric paths from the metric name, labels and
+// resource attributes, ie.: it does the reverse of the carbonreceiver path
+// parsers, which extract the metric name and labels from a metric path.
+//
+// Given the template "{host.name}.{service}.{metric_name}", the metric named
+// "cpu.seconds" with the labels {service="api", state="idle"} and the resource
+// attribute host.name="host00" has the path:
+//
+// 	host00.api.cpu.seconds;state=idle
+type nameTemplate struct {
+	// parts of the template, placeholders having their key set.
+	parts []templatePart
+}
+
+type templatePart struct {
+	literal string
+	key     string
+}
+
+// parseNameTemplate parses the name template, which must have a
+// "{metric_name}" placeholder.
+func parseNameTemplate(template string) (*nameTemplate, error) {
+	nt := &nameTemplate{}
+	hasMetricName := false
+	for rest := template; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			nt.parts = append(nt.parts, templatePart{literal: rest})
+			break
+		}
+		if start > 0 {
+			nt.parts = append(nt.parts, templatePart{literal: rest[:start]})
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in name template %q", template)
+		}
+		key := rest[start+1 : start+end]
+		if key == "" || strings.ContainsAny(key, "{") {
+			return nil, fmt.Errorf("invalid placeholder in name template %q", template)
+		}
+		if key == metricNamePlaceholder {
+			hasMetricName = true
+		} else {
+			// Label keys are sanitized before being used as tag keys.
+			key = sanitizeTagKey(key)
+		}
+		nt.parts = append(nt.parts, templatePart{key: key})
+		rest = rest[start+end+1:]
+	}
+
+	if !hasMetricName {
+		return nil, fmt.Errorf("name template %q must have a {%s} placeholder", template, metricNamePlaceholder)
+	}
+	return nt, nil
+}
+
+// buildPath builds the <metric_path> of the metric, the labels not used by the
+// template being added as tags. It assumes that len(tagKeys) is equal to
+// len(labelValues).
+func (nt *nameTemplate) buildPath(
+	name string,
+	tagKeys []string,
+	labelValues []*metricspb.LabelValue,
+	resource map[string]string,
+) string {
+	var sb strings.Builder
+	used := make(map[string]bool)
+	for _, part := range nt.parts {
+		if part.key == "" {
+			sb.WriteString(part.literal)
+			continue
+		}
+		if part.key == metricNamePlaceholder {
+			sb.WriteString(name)
+			continue
+		}
+
+		value := ""
+		for i, tagKey := range tagKeys {
+			if tagKey == part.key {
+				value = labelValues[i].Value
+				used[tagKey] = true
+				break
+			}
+		}
+		if value == "" {
+			value = resource[part.key]
+		}
+		if value == "" {
+			value = templateValueNotSetPlaceholder
+		}
+		sb.WriteString(sanitizePathNode(value))
+	}
+
+	if len(used) == 0 {
+		return buildPath(sb.String(), tagKeys, labelValues)
+	}
+
+	remainingKeys := make([]string, 0, len(tagKeys)-len(used))
+	remainingValues := make([]*metricspb.LabelValue, 0, len(tagKeys)-len(used))
+	for i, tagKey := range tagKeys {
+		if !used[tagKey] {
+			remainingKeys = append(remainingKeys, tagKey)
+			remainingValues = append(remainingValues, labelValues[i])
+		}
+	}
+	return buildPath(sb.String(), remainingKeys, remainingValues)
+}
+
+// sanitizePathNode replaces the characters that would split the value into
+// several nodes of the metric path, or end the path, by the sanitizedRune.
+func sanitizePathNode(value string) string {
+	mapRune := func(r rune) rune {
+		switch r {
+		case '.', ';', ' ', '\t', '\n':
+			return sanitizedRune
+		default:
+			return r
+		}
+	}
+
+	return strings.Map(mapRune, value)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseNameTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{
+			name:     "metric_name_only",
+			template: "{metric_name}",
+		},
+		{
+			name:     "with_placeholders",
+			template: "prefix.{host.name}.{service}.{metric_name}",
+		},
+		{
+			name:     "missing_metric_name",
+			template: "{host.name}.cpu",
+			wantErr:  `name template "{host.name}.cpu" must have a {metric_name} placeholder`,
+		},
+		{
+			name:     "unclosed_placeholder",
+			template: "{host.name.{metric_name}",
+			wantErr:  `invalid placeholder in name template "{host.name.{metric_name}"`,
+		},
+		{
+			name:     "unclosed_last_placeholder",
+			template: "{metric_name}.{host",
+			wantErr:  `unclosed placeholder in name template "{metric_name}.{host"`,
+		},
+		{
+			name:     "empty_placeholder",
+			template: "{}.{metric_name}",
+			wantErr:  `invalid placeholder in name template "{}.{metric_name}"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nt, err := parseNameTemplate(tt.template)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, nt)
+		})
+	}
+}
+
+func Test_nameTemplate_buildPath(t *testing.T) {
+	tagKeys := []string{"service", "state"}
+	labelValues := []*metricspb.LabelValue{
+		{Value: "api", HasValue: true},
+		{Value: "idle", HasValue: true},
+	}
+	resource := map[string]string{
+		"host.name": "host00.local",
+		"service":   "resource_service",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "metric_name_only",
+			template: "{metric_name}",
+			want:     "cpu.seconds;service=api;state=idle",
+		},
+		{
+			name:     "label_and_resource",
+			template: "{host.name}.{service}.{metric_name}",
+			want:     "host00_local.api.cpu.seconds;state=idle",
+		},
+		{
+			name:     "all_labels",
+			template: "{service}.{state}.{metric_name}",
+			want:     "api.idle.cpu.seconds",
+		},
+		{
+			name:     "not_set",
+			template: "{region}.{metric_name}",
+			want:     "unknown.cpu.seconds;service=api;state=idle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nt, err := parseNameTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, nt.buildPath("cpu.seconds", tagKeys, labelValues, resource))
+		})
+	}
+}
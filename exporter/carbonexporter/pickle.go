@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strconv"
+)
+
+// maxMetricsPerPickleMessage is the maximum number of metrics on each message
+// of the pickle protocol, it is the default MAX_DATAPOINTS_PER_MESSAGE of
+// carbon-relay.
+const maxMetricsPerPickleMessage = 500
+
+// Pickle protocol 2 opcodes used to encode the metrics, see
+// https://github.com/python/cpython/blob/main/Lib/pickletools.py.
+const (
+	pickleProto      = 0x80
+	pickleEmptyList  = ']'
+	pickleMark       = '('
+	pickleBinUnicode = 'X'
+	pickleBinFloat   = 'G'
+	pickleTuple2     = 0x86
+	pickleAppends    = 'e'
+	pickleStop       = '.'
+)
+
+// formatPickle encodes the metrics per the Carbon pickle protocol as defined
+// in https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+//
+// The metrics are split into messages, each one is a list of tuples pickled
+// with the protocol 2 and preceded by its length as a 4 bytes big-endian
+// unsigned integer:
+//
+// 	[(path, (timestamp, value)), ...]
+//
+// Metrics with a value or timestamp that is not a number are dropped, the
+// number of dropped metrics is returned with the encoded messages.
+func formatPickle(metrics []carbonMetric) ([]byte, int) {
+	var buf bytes.Buffer
+	dropped := 0
+	for len(metrics) > 0 {
+		n := len(metrics)
+		if n > maxMetricsPerPickleMessage {
+			n = maxMetricsPerPickleMessage
+		}
+		dropped += appendPickleMessage(&buf, metrics[:n])
+		metrics = metrics[n:]
+	}
+	return buf.Bytes(), dropped
+}
+
+func appendPickleMessage(buf *bytes.Buffer, metrics []carbonMetric) int {
+	var msg bytes.Buffer
+	msg.Write([]byte{pickleProto, 2, pickleEmptyList, pickleMark})
+
+	dropped := 0
+	for _, metric := range metrics {
+		timestamp, err := strconv.ParseFloat(metric.timestamp, 64)
+		if err != nil {
+			dropped++
+			continue
+		}
+		value, err := strconv.ParseFloat(metric.value, 64)
+		if err != nil {
+			dropped++
+			continue
+		}
+
+		msg.WriteByte(pickleBinUnicode)
+		writeUint32(&msg, binary.LittleEndian, uint32(len(metric.path)))
+		msg.WriteString(metric.path)
+		writePickleFloat(&msg, timestamp)
+		writePickleFloat(&msg, value)
+		msg.WriteByte(pickleTuple2)
+		msg.WriteByte(pickleTuple2)
+	}
+
+	msg.Write([]byte{pickleAppends, pickleStop})
+
+	if dropped == len(metrics) {
+		return dropped
+	}
+	writeUint32(buf, binary.BigEndian, uint32(msg.Len()))
+	buf.Write(msg.Bytes())
+	return dropped
+}
+
+func writePickleFloat(buf *bytes.Buffer, f float64) {
+	buf.WriteByte(pickleBinFloat)
+	writeUint32(buf, binary.BigEndian, uint32(math.Float64bits(f)>>32))
+	writeUint32(buf, binary.BigEndian, uint32(math.Float64bits(f)))
+}
+
+func writeUint32(buf *bytes.Buffer, order binary.ByteOrder, v uint32) {
+	var b [4]byte
+	order.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
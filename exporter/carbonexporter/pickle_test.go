@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_formatPickle(t *testing.T) {
+	payload, dropped := formatPickle([]carbonMetric{
+		{path: "a", value: "2", timestamp: "1"},
+		{path: "b", value: "not_a_number", timestamp: "1"},
+	})
+	assert.Equal(t, 1, dropped)
+
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x20, // Message length.
+		0x80, 0x02, ']', '(',
+		'X', 0x01, 0x00, 0x00, 0x00, 'a',
+		'G', 0x3f, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		'G', 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x86, 0x86,
+		'e', '.',
+	}
+	assert.Equal(t, expected, payload)
+}
+
+func Test_formatPickle_Messages(t *testing.T) {
+	metrics := make([]carbonMetric, maxMetricsPerPickleMessage+1)
+	for i := range metrics {
+		metrics[i] = carbonMetric{path: "metric_" + strconv.Itoa(i), value: "1", timestamp: "1"}
+	}
+
+	payload, dropped := formatPickle(metrics)
+	assert.Equal(t, 0, dropped)
+
+	var numMessages int
+	for len(payload) > 0 {
+		require.GreaterOrEqual(t, len(payload), 4)
+		size := int(binary.BigEndian.Uint32(payload))
+		require.GreaterOrEqual(t, len(payload), 4+size)
+		msg := payload[4 : 4+size]
+		assert.Equal(t, []byte{0x80, 0x02}, msg[:2])
+		assert.Equal(t, []byte{'e', '.'}, msg[len(msg)-2:])
+		payload = payload[4+size:]
+		numMessages++
+	}
+	assert.Equal(t, 2, numMessages)
+
+	payload, dropped = formatPickle(nil)
+	assert.Empty(t, payload)
+	assert.Equal(t, 0, dropped)
+}
@@ -63,6 +63,11 @@ type MetricsConfig struct {
 	// Buckets states whether to report buckets from distribution metrics
 	Buckets bool `mapstructure:"report_buckets"`
 
+	// Distributions states whether to report histogram metrics as Datadog distributions,
+	// by interpolating their values within each bucket into a sketch, so that percentiles
+	// can be computed across hosts.
+	Distributions bool `mapstructure:"report_distributions"`
+
 	// Quantiles states whether to report quantiles from summary metrics.
 	// By default, the minimum, maximum and average are reported.
 	Quantiles bool `mapstructure:"report_quantiles"`
@@ -117,6 +122,14 @@ type TracesConfig struct {
 	SpanNameRemappings map[string]string `mapstructure:"span_name_remappings"`
 }
 
+// LogsConfig defines the logs exporter specific configuration options
+type LogsConfig struct {
+	// TCPAddr.Endpoint is the host of the Datadog logs intake server to send logs to.
+	// It can also be set through the `DD_LOGS_URL` environment variable.
+	// If unset, the value is obtained from the Site.
+	confignet.TCPAddr `mapstructure:",squash"`
+}
+
 // TagsConfig defines the tag-related configuration
 // It is embedded in the configuration
 type TagsConfig struct {
@@ -177,6 +190,9 @@ type Config struct {
 	// Traces defines the Traces exporter specific configuration
 	Traces TracesConfig `mapstructure:"traces"`
 
+	// Logs defines the Logs exporter specific configuration
+	Logs LogsConfig `mapstructure:"logs"`
+
 	// SendMetadata defines whether to send host metadata
 	// This is undocumented and only used for unit testing.
 	//
@@ -242,6 +258,10 @@ func (c *Config) Sanitize() error {
 		c.Traces.TCPAddr.Endpoint = fmt.Sprintf("https://trace.agent.%s", c.API.Site)
 	}
 
+	if c.Logs.TCPAddr.Endpoint == "" {
+		c.Logs.TCPAddr.Endpoint = fmt.Sprintf("https://http-intake.logs.%s", c.API.Site)
+	}
+
 	return nil
 }
 
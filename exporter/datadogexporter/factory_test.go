@@ -66,6 +66,12 @@ func TestCreateDefaultConfig(t *testing.T) {
 			IgnoreResources: []string{},
 		},
 
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "$DD_LOGS_URL",
+			},
+		},
+
 		TagsConfig: ddconfig.TagsConfig{
 			Hostname:   "$DD_HOST",
 			Env:        "$DD_ENV",
@@ -130,6 +136,11 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.eu",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -170,6 +181,11 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -192,6 +208,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 	assert.NoError(t, os.Setenv("DD_TAGS", "envexample:tag envexample2:tag"))
 	assert.NoError(t, os.Setenv("DD_URL", "https://api.datadoghq.com"))
 	assert.NoError(t, os.Setenv("DD_APM_URL", "https://trace.agent.datadoghq.com"))
+	assert.NoError(t, os.Setenv("DD_LOGS_URL", "https://http-intake.logs.datadoghq.com"))
 
 	defer func() {
 		assert.NoError(t, os.Unsetenv("DD_API_KEY"))
@@ -203,6 +220,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 		assert.NoError(t, os.Unsetenv("DD_TAGS"))
 		assert.NoError(t, os.Unsetenv("DD_URL"))
 		assert.NoError(t, os.Unsetenv("DD_APM_URL"))
+		assert.NoError(t, os.Unsetenv("DD_LOGS_URL"))
 	}()
 
 	factories, err := componenttest.NopFactories()
@@ -253,6 +271,11 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.test",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -297,6 +320,11 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -363,6 +391,36 @@ func TestCreateAPITracesExporter(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestCreateAPILogsExporter(t *testing.T) {
+	server := testutils.DatadogServerMock()
+	defer server.Close()
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	// Use the mock server for API key validation
+	c := (cfg.Exporters[config.NewIDWithName(typeStr, "api")]).(*ddconfig.Config)
+	c.Metrics.TCPAddr.Endpoint = server.URL
+	c.SendMetadata = false
+
+	ctx := context.Background()
+	exp, err := factory.CreateLogsExporter(
+		ctx,
+		componenttest.NewNopExporterCreateSettings(),
+		cfg.Exporters[config.NewIDWithName(typeStr, "api")],
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+}
+
 func TestOnlyMetadata(t *testing.T) {
 	server := testutils.DatadogServerMock()
 	defer server.Close()
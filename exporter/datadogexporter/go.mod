@@ -3,6 +3,8 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datado
 go 1.16
 
 require (
+	github.com/DataDog/agent-payload v4.78.0+incompatible
+	github.com/DataDog/datadog-agent/pkg/quantile v0.33.1
 	github.com/DataDog/datadog-agent/pkg/trace/exportable v0.0.0-20201016145401-4646cf596b02
 	github.com/aws/aws-sdk-go v1.40.8
 	github.com/gogo/protobuf v1.3.2
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
+)
+
+const (
+	logsIntakePath string = "/v1/input"
+	logsTimeout           = 10 * time.Second
+
+	// Limits of a payload of the logs intake
+	// https://docs.datadoghq.com/api/latest/logs/#send-logs
+	maxLogsPerPayload  = 1000
+	maxLogsPayloadSize = 5 * 1024 * 1024
+)
+
+type logsExporter struct {
+	params component.ExporterCreateSettings
+	cfg    *config.Config
+	ctx    context.Context
+	client *http.Client
+	url    string
+}
+
+func newLogsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *config.Config) *logsExporter {
+	// client to perform API key validation
+	client := utils.CreateClient(cfg.API.Key, cfg.Metrics.TCPAddr.Endpoint)
+	utils.ValidateAPIKey(params.Logger, client)
+
+	return &logsExporter{
+		params: params,
+		cfg:    cfg,
+		ctx:    ctx,
+		client: utils.NewHTTPClient(logsTimeout),
+		url:    cfg.Logs.TCPAddr.Endpoint + logsIntakePath,
+	}
+}
+
+func (exp *logsExporter) pushLogsData(ctx context.Context, ld pdata.Logs) error {
+
+	// Start host metadata with resource attributes from
+	// the first payload.
+	if exp.cfg.SendMetadata {
+		once := exp.cfg.OnceMetadata()
+		once.Do(func() {
+			attrs := pdata.NewAttributeMap()
+			if ld.ResourceLogs().Len() > 0 {
+				attrs = ld.ResourceLogs().At(0).Resource().Attributes()
+			}
+			go metadata.Pusher(exp.ctx, exp.params, exp.cfg, attrs)
+		})
+	}
+
+	fallbackHost := metadata.GetHost(exp.params.Logger, exp.cfg)
+	logs := mapLogs(exp.cfg, fallbackHost, ld)
+
+	payloads, permanentErrs := splitLogsPayloads(logs)
+	for i, payload := range payloads {
+		err := exp.send(ctx, payload.body)
+		if err == nil {
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			permanentErrs = append(permanentErrs, err)
+			continue
+		}
+
+		// Retry the logs of the failed payload and of the ones not sent yet
+		var remaining []int
+		for _, unsent := range payloads[i:] {
+			remaining = append(remaining, unsent.indexes...)
+		}
+		for _, permanentErr := range permanentErrs {
+			exp.params.Logger.Error("Dropping logs", zap.Error(permanentErr))
+		}
+		return consumererror.NewLogs(err, logRecordsAt(ld, remaining))
+	}
+	return consumererror.Combine(permanentErrs)
+}
+
+// logsPayload is a JSON array of logs, with the indexes of its logs in the
+// slice they were encoded from.
+type logsPayload struct {
+	body    []byte
+	indexes []int
+}
+
+// splitLogsPayloads encodes the logs as JSON arrays within the limits of the
+// logs intake. A log which cannot be encoded, or which exceeds the payload size
+// on its own, is not sent and is reported as a permanent error.
+func splitLogsPayloads(logs []ddLog) ([]logsPayload, []error) {
+	var (
+		payloads []logsPayload
+		errs     []error
+		buf      bytes.Buffer
+		indexes  []int
+	)
+	flush := func() {
+		if len(indexes) == 0 {
+			return
+		}
+		buf.WriteByte(']')
+		payloads = append(payloads, logsPayload{
+			body:    append([]byte(nil), buf.Bytes()...),
+			indexes: indexes,
+		})
+		buf.Reset()
+		indexes = nil
+	}
+
+	for i, log := range logs {
+		encoded, err := json.Marshal(log)
+		if err != nil {
+			errs = append(errs, consumererror.Permanent(fmt.Errorf("failed to encode log: %w", err)))
+			continue
+		}
+		if len(encoded)+2 > maxLogsPayloadSize {
+			errs = append(errs, consumererror.Permanent(fmt.Errorf("log of %d bytes exceeds the maximum payload size of %d bytes", len(encoded), maxLogsPayloadSize)))
+			continue
+		}
+		if len(indexes) > 0 && (len(indexes) == maxLogsPerPayload || buf.Len()+1+len(encoded)+1 > maxLogsPayloadSize) {
+			flush()
+		}
+		if len(indexes) == 0 {
+			buf.WriteByte('[')
+		} else {
+			buf.WriteByte(',')
+		}
+		buf.Write(encoded)
+		indexes = append(indexes, i)
+	}
+	flush()
+	return payloads, errs
+}
+
+// send posts a gzip compressed JSON payload to the logs intake.
+func (exp *logsExporter) send(ctx context.Context, payload []byte) error {
+	var body bytes.Buffer
+	gzipWriter := gzip.NewWriter(&body)
+	if _, err := gzipWriter.Write(payload); err != nil {
+		return consumererror.Permanent(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exp.url, &body)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	utils.SetDDHeaders(req.Header, exp.params.BuildInfo, exp.cfg.API.Key)
+	utils.SetExtraHeaders(req.Header, utils.JSONHeaders)
+
+	resp, err := exp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		err := fmt.Errorf("request to %s responded with %s", exp.url, resp.Status)
+		// 5xx errors and throttling are retriable, all others aren't
+		if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+			return err
+		}
+		return consumererror.Permanent(err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/testutils"
+)
+
+func newTestLogsExporter(t *testing.T, logsURL string) *logsExporter {
+	server := testutils.DatadogServerMock()
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Key: "ddog_32_characters_long_api_key1",
+		},
+		Metrics: config.MetricsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+		},
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: logsURL,
+			},
+		},
+	}
+	return newLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+}
+
+func TestPushLogsData(t *testing.T) {
+	var (
+		received []ddLog
+		header   http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, logsIntakePath, r.URL.Path)
+		header = r.Header
+		reader, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(reader).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exp := newTestLogsExporter(t, server.URL)
+	err := exp.pushLogsData(context.Background(), newTestLogs())
+
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "order placed", received[0]["message"])
+	assert.Equal(t, "2", received[0]["dd.trace_id"])
+	assert.Equal(t, "ddog_32_characters_long_api_key1", header.Get("DD-Api-Key"))
+	assert.Equal(t, "gzip", header.Get("Content-Encoding"))
+}
+
+func TestPushLogsDataErrors(t *testing.T) {
+	tests := []struct {
+		status    int
+		permanent bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusForbidden, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusServiceUnavailable, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			exp := newTestLogsExporter(t, server.URL)
+			err := exp.pushLogsData(context.Background(), newTestLogs())
+
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func newTestLogsBatch(count int) pdata.Logs {
+	ld := pdata.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < count; i++ {
+		lrs.AppendEmpty().Body().SetStringVal(fmt.Sprint(i))
+	}
+	return ld
+}
+
+func TestPushLogsDataFailedPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+		requests  int
+		remaining int
+	}{
+		{"retryable", http.StatusServiceUnavailable, false, 2, maxLogsPerPayload + 1},
+		{"permanent", http.StatusRequestEntityTooLarge, true, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// the second payload fails
+				if atomic.AddInt32(&requests, 1) == 2 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			exp := newTestLogsExporter(t, server.URL)
+			err := exp.pushLogsData(context.Background(), newTestLogsBatch(2*maxLogsPerPayload+1))
+
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			assert.Equal(t, int32(tt.requests), atomic.LoadInt32(&requests))
+			var logsErr consumererror.Logs
+			if tt.remaining == 0 {
+				assert.False(t, consumererror.AsLogs(err, &logsErr))
+				return
+			}
+			require.True(t, consumererror.AsLogs(err, &logsErr))
+			remaining := logsErr.GetLogs()
+			assert.Equal(t, tt.remaining, remaining.LogRecordCount())
+			first := remaining.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+			assert.Equal(t, fmt.Sprint(maxLogsPerPayload), first.Body().StringVal())
+		})
+	}
+}
+
+func TestPushLogsDataOversizedLog(t *testing.T) {
+	var received []ddLog
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reader, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(reader).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ld := newTestLogsBatch(2)
+	lrs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	lrs.At(0).Body().SetStringVal(strings.Repeat("a", maxLogsPayloadSize))
+
+	exp := newTestLogsExporter(t, server.URL)
+	err := exp.pushLogsData(context.Background(), ld)
+
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	require.Len(t, received, 1)
+	assert.Equal(t, "1", received[0]["message"])
+}
+
+func TestSplitLogsPayloads(t *testing.T) {
+	logs := make([]ddLog, maxLogsPerPayload+1)
+	for i := range logs {
+		logs[i] = ddLog{"message": fmt.Sprint(i)}
+	}
+
+	payloads, errs := splitLogsPayloads(logs)
+
+	require.Empty(t, errs)
+	require.Len(t, payloads, 2)
+	var first, second []ddLog
+	require.NoError(t, json.Unmarshal(payloads[0].body, &first))
+	require.NoError(t, json.Unmarshal(payloads[1].body, &second))
+	assert.Len(t, first, maxLogsPerPayload)
+	assert.Len(t, payloads[0].indexes, maxLogsPerPayload)
+	assert.Equal(t, []ddLog{{"message": fmt.Sprint(maxLogsPerPayload)}}, second)
+	assert.Equal(t, []int{maxLogsPerPayload}, payloads[1].indexes)
+
+	payloads, errs = splitLogsPayloads(nil)
+	require.Empty(t, errs)
+	assert.Empty(t, payloads)
+}
+
+func TestSplitLogsPayloadsOversizedLog(t *testing.T) {
+	logs := []ddLog{
+		{"message": "0"},
+		{"message": strings.Repeat("a", maxLogsPayloadSize)},
+		{"message": "2"},
+	}
+
+	payloads, errs := splitLogsPayloads(logs)
+
+	require.Len(t, errs, 1)
+	assert.True(t, consumererror.IsPermanent(errs[0]))
+	require.Len(t, payloads, 1)
+	assert.Equal(t, []int{0, 2}, payloads[0].indexes)
+	assert.Equal(t, `[{"message":"0"},{"message":"2"}]`, string(payloads[0].body))
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/attributes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
+)
+
+const (
+	// Reserved attributes of the Datadog logs intake
+	// https://docs.datadoghq.com/logs/log_configuration/attributes_naming_convention/#reserved-attributes
+	logMessageKey   string = "message"
+	logStatusKey    string = "status"
+	logTimestampKey string = "timestamp"
+	logHostnameKey  string = "hostname"
+	logServiceKey   string = "service"
+	logSourceKey    string = "ddsource"
+	logTagsKey      string = "ddtags"
+	// Attributes used by Datadog to correlate logs and traces
+	// https://docs.datadoghq.com/tracing/connect_logs_and_traces/opentelemetry/
+	logTraceIDKey string = "dd.trace_id"
+	logSpanIDKey  string = "dd.span_id"
+
+	logSource string = "otlp_log_ingestion"
+)
+
+// ddLog is a log in the format of the Datadog logs intake: the reserved
+// attributes and the log record attributes, all at the top level.
+type ddLog map[string]interface{}
+
+// mapLogs converts OpenTelemetry logs to Datadog logs.
+func mapLogs(cfg *config.Config, fallbackHost string, ld pdata.Logs) []ddLog {
+	var logs []ddLog
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttrs := rl.Resource().Attributes()
+
+		host, ok := metadata.HostnameFromAttributes(resourceAttrs)
+		if !ok {
+			host = fallbackHost
+		}
+		service := cfg.Service
+		if serviceName, ok := resourceAttrs.Get(conventions.AttributeServiceName); ok && serviceName.StringVal() != "" {
+			service = serviceName.StringVal()
+		}
+		tags := strings.Join(logTags(cfg, resourceAttrs), ",")
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			lrs := ills.At(j).Logs()
+			for k := 0; k < lrs.Len(); k++ {
+				log := mapLogRecord(lrs.At(k))
+				log[logHostnameKey] = host
+				log[logSourceKey] = logSource
+				if service != "" {
+					log[logServiceKey] = service
+				}
+				if tags != "" {
+					log[logTagsKey] = tags
+				}
+				logs = append(logs, log)
+			}
+		}
+	}
+	return logs
+}
+
+// logRecordsAt returns the log records of ld at the given indexes of the logs
+// returned by mapLogs, with their resource and instrumentation library.
+func logRecordsAt(ld pdata.Logs, indexes []int) pdata.Logs {
+	selected := make(map[int]bool, len(indexes))
+	for _, index := range indexes {
+		selected[index] = true
+	}
+
+	result := pdata.NewLogs()
+	index := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var resultRl *pdata.ResourceLogs
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			var resultIll *pdata.InstrumentationLibraryLogs
+			lrs := ill.Logs()
+			for k := 0; k < lrs.Len(); k++ {
+				if selected[index] {
+					if resultRl == nil {
+						newRl := result.ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(newRl.Resource())
+						resultRl = &newRl
+					}
+					if resultIll == nil {
+						newIll := resultRl.InstrumentationLibraryLogs().AppendEmpty()
+						ill.InstrumentationLibrary().CopyTo(newIll.InstrumentationLibrary())
+						resultIll = &newIll
+					}
+					lrs.At(k).CopyTo(resultIll.Logs().AppendEmpty())
+				}
+				index++
+			}
+		}
+	}
+	return result
+}
+
+// logTags returns the tags of the logs of a resource: the configured tags,
+// the tags derived from the resource attributes, and the unified service tags
+// from the configuration unless the resource already sets them.
+func logTags(cfg *config.Config, resourceAttrs pdata.AttributeMap) []string {
+	var tags []string
+	for _, tag := range cfg.TagsConfig.Tags {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(cfg.TagsConfig.Tags) == 0 {
+		for _, tag := range strings.Split(cfg.EnvVarTags, " ") {
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	resourceTags := attributes.TagsFromAttributes(resourceAttrs)
+	tags = append(tags, resourceTags...)
+
+	hasTag := func(key string) bool {
+		for _, tag := range resourceTags {
+			if strings.HasPrefix(tag, key+":") {
+				return true
+			}
+		}
+		return false
+	}
+	if cfg.Env != "" && cfg.Env != "none" && !hasTag("env") {
+		tags = append(tags, "env:"+cfg.Env)
+	}
+	if cfg.Version != "" && !hasTag(versionTag) {
+		tags = append(tags, versionTag+":"+cfg.Version)
+	}
+	return tags
+}
+
+// mapLogRecord converts the log record fields and attributes to a Datadog log.
+func mapLogRecord(lr pdata.LogRecord) ddLog {
+	log := make(ddLog, lr.Attributes().Len()+7)
+	lr.Attributes().Range(func(key string, value pdata.AttributeValue) bool {
+		log[key] = attributeValueToLogValue(value)
+		return true
+	})
+
+	log[logMessageKey] = tracetranslator.AttributeValueToString(lr.Body())
+	if lr.Name() != "" {
+		log["otel.name"] = lr.Name()
+	}
+	if status := logStatus(lr); status != "" {
+		log[logStatusKey] = status
+	}
+	if lr.Timestamp() != 0 {
+		// The intake expects milliseconds since the epoch
+		log[logTimestampKey] = int64(lr.Timestamp()) / 1e6
+	}
+	if !lr.TraceID().IsEmpty() {
+		log[logTraceIDKey] = strconv.FormatUint(decodeAPMTraceID(lr.TraceID().Bytes()), 10)
+	}
+	if !lr.SpanID().IsEmpty() {
+		log[logSpanIDKey] = strconv.FormatUint(decodeAPMSpanID(lr.SpanID().Bytes()), 10)
+	}
+	return log
+}
+
+// logStatus returns the Datadog status of a log record, from its severity
+// text if set, or else its severity number.
+func logStatus(lr pdata.LogRecord) string {
+	if lr.SeverityText() != "" {
+		return lr.SeverityText()
+	}
+	switch {
+	case lr.SeverityNumber() == pdata.SeverityNumberUNDEFINED:
+		return ""
+	case lr.SeverityNumber() < pdata.SeverityNumberINFO:
+		return "debug"
+	case lr.SeverityNumber() < pdata.SeverityNumberWARN:
+		return "info"
+	case lr.SeverityNumber() < pdata.SeverityNumberERROR:
+		return "warning"
+	case lr.SeverityNumber() < pdata.SeverityNumberFATAL:
+		return "error"
+	default:
+		return "critical"
+	}
+}
+
+// attributeValueToLogValue converts an attribute value to a value which keeps
+// its type in the JSON log, so that the attribute can be used as a facet.
+func attributeValueToLogValue(value pdata.AttributeValue) interface{} {
+	switch value.Type() {
+	case pdata.AttributeValueTypeString:
+		return value.StringVal()
+	case pdata.AttributeValueTypeInt:
+		return value.IntVal()
+	case pdata.AttributeValueTypeDouble:
+		return value.DoubleVal()
+	case pdata.AttributeValueTypeBool:
+		return value.BoolVal()
+	case pdata.AttributeValueTypeMap:
+		converted := make(map[string]interface{}, value.MapVal().Len())
+		value.MapVal().Range(func(key string, value pdata.AttributeValue) bool {
+			converted[key] = attributeValueToLogValue(value)
+			return true
+		})
+		return converted
+	case pdata.AttributeValueTypeArray:
+		arr := value.ArrayVal()
+		converted := make([]interface{}, arr.Len())
+		for i := 0; i < arr.Len(); i++ {
+			converted[i] = attributeValueToLogValue(arr.At(i))
+		}
+		return converted
+	}
+	return nil
+}
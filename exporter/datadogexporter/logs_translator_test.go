@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+)
+
+func newTestLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("datadog.host.name", "custom-hostname")
+	rl.Resource().Attributes().InsertString(conventions.AttributeServiceName, "checkout")
+	rl.Resource().Attributes().InsertString(conventions.AttributeDeploymentEnvironment, "staging")
+
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetName("checkout.log")
+	lr.Body().SetStringVal("order placed")
+	lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Unix(1600000000, 123456789)))
+	lr.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	lr.Attributes().InsertString("order.id", "42")
+	lr.Attributes().InsertInt("order.items", 3)
+	return ld
+}
+
+func TestMapLogs(t *testing.T) {
+	cfg := &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:     "prod",
+			Service: "default-service",
+			Version: "1.0.0",
+			Tags:    []string{"team:payments"},
+		},
+	}
+
+	logs := mapLogs(cfg, "fallback-host", newTestLogs())
+
+	require.Len(t, logs, 1)
+	assert.Equal(t, ddLog{
+		"message":     "order placed",
+		"otel.name":   "checkout.log",
+		"status":      "warning",
+		"timestamp":   int64(1600000000123),
+		"hostname":    "custom-hostname",
+		"service":     "checkout",
+		"ddsource":    logSource,
+		"ddtags":      "team:payments,service:checkout,env:staging,version:1.0.0",
+		"dd.trace_id": "2",
+		"dd.span_id":  "3",
+		"order.id":    "42",
+		"order.items": int64(3),
+	}, logs[0])
+}
+
+func TestMapLogsDefaults(t *testing.T) {
+	cfg := &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:        "none",
+			Service:    "default-service",
+			EnvVarTags: "envexample:tag envexample2:tag",
+		},
+	}
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal("hello")
+
+	logs := mapLogs(cfg, "fallback-host", ld)
+
+	require.Len(t, logs, 1)
+	assert.Equal(t, ddLog{
+		"message":  "hello",
+		"hostname": "fallback-host",
+		"service":  "default-service",
+		"ddsource": logSource,
+		"ddtags":   "envexample:tag,envexample2:tag",
+	}, logs[0])
+}
+
+func TestLogStatus(t *testing.T) {
+	tests := []struct {
+		severityText   string
+		severityNumber pdata.SeverityNumber
+		status         string
+	}{
+		{"", pdata.SeverityNumberUNDEFINED, ""},
+		{"", pdata.SeverityNumberTRACE2, "debug"},
+		{"", pdata.SeverityNumberDEBUG, "debug"},
+		{"", pdata.SeverityNumberINFO4, "info"},
+		{"", pdata.SeverityNumberWARN, "warning"},
+		{"", pdata.SeverityNumberERROR3, "error"},
+		{"", pdata.SeverityNumberFATAL, "critical"},
+		{"notice", pdata.SeverityNumberINFO, "notice"},
+	}
+	for _, tt := range tests {
+		lr := pdata.NewLogRecord()
+		lr.SetSeverityText(tt.severityText)
+		lr.SetSeverityNumber(tt.severityNumber)
+		assert.Equal(t, tt.status, logStatus(lr), tt.severityNumber.String())
+	}
+}
+
+func TestAttributeValueToLogValue(t *testing.T) {
+	value := pdata.NewAttributeValueMap()
+	value.MapVal().InsertBool("bool", true)
+	value.MapVal().InsertDouble("double", 1.5)
+	arr := pdata.NewAttributeValueArray()
+	arr.ArrayVal().AppendEmpty().SetStringVal("a")
+	arr.ArrayVal().AppendEmpty().SetIntVal(1)
+	value.MapVal().Insert("array", arr)
+
+	assert.Equal(t, map[string]interface{}{
+		"bool":   true,
+		"double": 1.5,
+		"array":  []interface{}{"a", int64(1)},
+	}, attributeValueToLogValue(value))
+}
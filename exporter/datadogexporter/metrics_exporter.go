@@ -15,11 +15,17 @@
 package datadogexporter
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
 	"gopkg.in/zorkian/go-datadog-api.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
@@ -29,6 +35,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
 )
 
+const (
+	// sketchesEndpoint is the path of the Datadog intake receiving distribution points
+	sketchesEndpoint string = "/api/beta/sketches"
+)
+
 type metricsExporter struct {
 	params  component.ExporterCreateSettings
 	cfg     *config.Config
@@ -70,9 +81,58 @@ func (exp *metricsExporter) PushMetricsData(ctx context.Context, md pdata.Metric
 	}
 
 	fallbackHost := metadata.GetHost(exp.params.Logger, exp.cfg)
-	ms, _ := mapMetrics(exp.params.Logger, exp.cfg.Metrics, exp.prevPts, fallbackHost, md, exp.params.BuildInfo)
+	ms, sl, histCounters, _ := mapMetrics(exp.params.Logger, exp.cfg.Metrics, exp.prevPts, fallbackHost, md, exp.params.BuildInfo)
 	metrics.ProcessMetrics(ms, exp.cfg)
 
-	err := exp.client.PostMetrics(ms)
-	return err
+	// The sketches are sent first, so that the series are not sent
+	// again when the sketches are retried.
+	var sketchesErr error
+	if len(sl) > 0 {
+		sketchesErr = exp.pushSketches(ctx, sl)
+		if sketchesErr != nil && !consumererror.IsPermanent(sketchesErr) {
+			return sketchesErr
+		}
+	}
+	// The rejected sketches would be rejected again, the deltas are computed from their points
+	commitHistogramCounters(exp.prevPts, histCounters)
+
+	if err := exp.client.PostMetrics(ms); err != nil {
+		if sketchesErr != nil {
+			exp.params.Logger.Error("Dropping rejected sketches", zap.Error(sketchesErr))
+		}
+		return err
+	}
+	return sketchesErr
+}
+
+// pushSketches sends distribution points to the Datadog sketches intake
+func (exp *metricsExporter) pushSketches(ctx context.Context, sl []sketchSeries) error {
+	payload, err := proto.Marshal(newSketchPayload(sl))
+	if err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to serialize sketches payload to protobuf: %w", err))
+	}
+
+	url := exp.cfg.Metrics.TCPAddr.Endpoint + sketchesEndpoint
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	utils.SetDDHeaders(req.Header, exp.params.BuildInfo, exp.cfg.API.Key)
+	utils.SetExtraHeaders(req.Header, utils.ProtobufHeaders)
+
+	resp, err := exp.client.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("request to %s responded with %s", url, resp.Status)
+		if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+			// the payload was rejected, and would be rejected again
+			return consumererror.Permanent(err)
+		}
+		return err
+	}
+	return nil
 }
@@ -17,12 +17,19 @@ package datadogexporter
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	pb "github.com/DataDog/agent-payload/gogen"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
@@ -60,3 +67,131 @@ func TestNewExporter(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, recvMetadata.InternalHostname, "custom-hostname")
 }
+
+func TestPushSketches(t *testing.T) {
+	var (
+		payload pb.SketchPayload
+		header  http.Header
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/validate", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"valid":true}`))
+	})
+	mux.HandleFunc("/api/v1/series", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+	mux.HandleFunc(sketchesEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(body, &payload))
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Key: "ddog_32_characters_long_api_key1",
+		},
+		TagsConfig: config.TagsConfig{
+			Hostname: "test-host",
+		},
+		Metrics: config.MetricsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+			Distributions: true,
+		},
+	}
+	exp := newMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+
+	md := pdata.NewMetrics()
+	met := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	met.SetName("double.histogram")
+	met.SetDataType(pdata.MetricDataTypeHistogram)
+	met.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	newHistogramPoint(1, []float64{10}, []uint64{2, 3}, 50).CopyTo(met.Histogram().DataPoints().AppendEmpty())
+
+	require.NoError(t, exp.PushMetricsData(context.Background(), md))
+	assert.Equal(t, "application/x-protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "ddog_32_characters_long_api_key1", header.Get("DD-Api-Key"))
+	require.Len(t, payload.Sketches, 1)
+	assert.Equal(t, "double.histogram", payload.Sketches[0].Metric)
+	assert.Equal(t, "test-host", payload.Sketches[0].Host)
+	require.Len(t, payload.Sketches[0].Dogsketches, 1)
+	assert.Equal(t, int64(5), payload.Sketches[0].Dogsketches[0].Cnt)
+}
+
+func TestPushSketchesFailures(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+		wantSeries    bool
+	}{
+		{name: "server error", status: http.StatusServiceUnavailable},
+		{name: "too many requests", status: http.StatusTooManyRequests},
+		{name: "rejected", status: http.StatusBadRequest, wantPermanent: true, wantSeries: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seriesPosted bool
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/validate", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"valid":true}`))
+			})
+			mux.HandleFunc("/api/v1/series", func(w http.ResponseWriter, r *http.Request) {
+				seriesPosted = true
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"status":"ok"}`))
+			})
+			mux.HandleFunc(sketchesEndpoint, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			cfg := &config.Config{
+				API: config.APIConfig{
+					Key: "ddog_32_characters_long_api_key1",
+				},
+				TagsConfig: config.TagsConfig{
+					Hostname: "test-host",
+				},
+				Metrics: config.MetricsConfig{
+					TCPAddr: confignet.TCPAddr{
+						Endpoint: server.URL,
+					},
+					Distributions: true,
+				},
+			}
+			exp := newMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+
+			md := pdata.NewMetrics()
+			met := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+			met.SetName("double.histogram")
+			met.SetDataType(pdata.MetricDataTypeHistogram)
+			met.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+			newHistogramPoint(1, []float64{10}, []uint64{2, 3}, 50).CopyTo(met.Histogram().DataPoints().AppendEmpty())
+			// the first point is kept in memory, so that the second one gives a sketch
+			require.NoError(t, exp.PushMetricsData(context.Background(), md))
+			seriesPosted = false
+			newHistogramPoint(2, []float64{10}, []uint64{4, 6}, 100).CopyTo(met.Histogram().DataPoints().At(0))
+
+			err := exp.PushMetricsData(context.Background(), md)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+			assert.Equal(t, tt.wantSeries, seriesPosted)
+
+			// the deltas of retried sketches are computed from the same point
+			key := metricDimensionsToMapKey("double.histogram", []string{})
+			wantTs := uint64(seconds(1))
+			if tt.wantPermanent {
+				wantTs = uint64(seconds(2))
+			}
+			assert.Equal(t, wantTs, exp.prevPts.Get(key).(histogramCounter).ts)
+		})
+	}
+}
@@ -226,7 +226,11 @@ func mapSummaryMetrics(name string, slice pdata.SummaryDataPointSlice, quantiles
 }
 
 // mapMetrics maps OTLP metrics into the DataDog format
-func mapMetrics(logger *zap.Logger, cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, fallbackHost string, md pdata.Metrics, buildInfo component.BuildInfo) (series []datadog.Metric, droppedTimeSeries int) {
+//
+// The cumulative histogram points the sketches are computed from are returned as histCounters,
+// to be committed to prevPts once the sketches are sent.
+func mapMetrics(logger *zap.Logger, cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, fallbackHost string, md pdata.Metrics, buildInfo component.BuildInfo) (series []datadog.Metric, sketches []sketchSeries, histCounters map[string]histogramCounter, droppedTimeSeries int) {
+	histCounters = make(map[string]histogramCounter)
 	pushTime := uint64(time.Now().UTC().UnixNano())
 	rms := md.ResourceMetrics()
 	seenHosts := make(map[string]struct{})
@@ -265,6 +269,14 @@ func mapMetrics(logger *zap.Logger, cfg config.MetricsConfig, prevPts *ttlmap.TT
 					}
 				case pdata.MetricDataTypeHistogram:
 					datapoints = mapHistogramMetrics(md.Name(), md.Histogram().DataPoints(), cfg.Buckets, attributeTags)
+					if cfg.Distributions {
+						cumulative := md.Histogram().AggregationTemporality() == pdata.AggregationTemporalityCumulative
+						sketchPoints := mapHistogramSketches(md.Name(), prevPts, histCounters, md.Histogram().DataPoints(), cumulative, attributeTags)
+						for i := range sketchPoints {
+							sketchPoints[i].host = host
+						}
+						sketches = append(sketches, sketchPoints...)
+					}
 				case pdata.MetricDataTypeSummary:
 					datapoints = mapSummaryMetrics(md.Name(), md.Summary().DataPoints(), cfg.Quantiles, attributeTags)
 				default: // pdata.MetricDataTypeNone or any other not supported type
@@ -580,7 +580,7 @@ func TestRunningMetrics(t *testing.T) {
 		Version: "1.0",
 	}
 
-	series, _ := mapMetrics(zap.NewNop(), cfg, prevPts, "fallbackHostname", ms, buildInfo)
+	series, _, _, _ := mapMetrics(zap.NewNop(), cfg, prevPts, "fallbackHostname", ms, buildInfo)
 
 	runningHostnames := []string{}
 
@@ -732,7 +732,7 @@ func TestMapMetrics(t *testing.T) {
 
 	core, observed := observer.New(zapcore.DebugLevel)
 	testLogger := zap.New(core)
-	series, dropped := mapMetrics(testLogger, cfg, newTTLMap(), "", md, buildInfo)
+	series, _, _, dropped := mapMetrics(testLogger, cfg, newTTLMap(), "", md, buildInfo)
 
 	assert.Equal(t, dropped, 0)
 	filtered := removeRunningMetrics(series)
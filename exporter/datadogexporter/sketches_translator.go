@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"math"
+
+	pb "github.com/DataDog/agent-payload/gogen"
+	"github.com/DataDog/datadog-agent/pkg/quantile"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
+)
+
+// sketchSeries is a Datadog distribution point: a sketch of the values
+// of a metric at a given point in time
+type sketchSeries struct {
+	name      string
+	host      string
+	tags      []string
+	timestamp uint64
+	sketch    *quantile.Sketch
+}
+
+// histogramCounter keeps the counts and sum of a cumulative
+// histogram at a given point in time
+type histogramCounter struct {
+	ts      uint64
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+// getBucketBounds returns the lower and upper bounds of the
+// bucket at the given index of a histogram datapoint.
+func getBucketBounds(p pdata.HistogramDataPoint, idx int) (lowerBound float64, upperBound float64) {
+	bounds := p.ExplicitBounds()
+	lowerBound, upperBound = math.Inf(-1), math.Inf(1)
+	if idx > 0 {
+		lowerBound = bounds[idx-1]
+	}
+	if idx < len(bounds) {
+		upperBound = bounds[idx]
+	}
+	return
+}
+
+// getHistogramSketch builds a sketch from the bucket counts of a histogram
+// datapoint, interpolating the values linearly within each bucket.
+// It returns nil if the datapoint has no values or no bounds.
+func getHistogramSketch(p pdata.HistogramDataPoint, count uint64, sum float64, bucketCounts []uint64) *quantile.Sketch {
+	if count == 0 || len(p.ExplicitBounds()) == 0 || len(bucketCounts) != len(p.ExplicitBounds())+1 {
+		return nil
+	}
+
+	as := &quantile.Agent{}
+	for idx, bucketCount := range bucketCounts {
+		if bucketCount == 0 {
+			continue
+		}
+		lowerBound, upperBound := getBucketBounds(p, idx)
+		// Values can't be interpolated within an unbounded bucket: as the Datadog Agent
+		// does for its own histograms, they are put at the finite bound of the bucket.
+		// https://github.com/DataDog/datadog-agent/blob/7.31.0/pkg/aggregator/check_sampler.go#L107-L111
+		if math.IsInf(upperBound, 1) {
+			upperBound = lowerBound
+		} else if math.IsInf(lowerBound, -1) {
+			lowerBound = upperBound
+		}
+		as.InsertInterpolate(lowerBound, upperBound, uint(bucketCount))
+	}
+
+	sketch := as.Finish()
+	if sketch == nil {
+		return nil
+	}
+	// The interpolation only approximates the sum, which is known exactly.
+	sketch.Basic.Sum = sum
+	sketch.Basic.Avg = sum / float64(sketch.Basic.Cnt)
+	return sketch
+}
+
+// mapHistogramSketches maps histogram datapoints into Datadog distribution points.
+//
+// Distribution points are computed on the values reported in the interval,
+// so the deltas of cumulative histograms are computed first: as for monotonic
+// sums, the first point of a cumulative histogram is kept in memory but not
+// exported. The points to keep in memory are added to counters, and only
+// committed to prevPts once the distribution points are sent.
+func mapHistogramSketches(name string, prevPts *ttlmap.TTLMap, counters map[string]histogramCounter, slice pdata.HistogramDataPointSlice, cumulative bool, attrTags []string) []sketchSeries {
+	sketches := make([]sketchSeries, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		p := slice.At(i)
+		ts := uint64(p.Timestamp())
+		tags := getTags(p.LabelsMap())
+		tags = append(tags, attrTags...)
+
+		count, sum, bucketCounts := p.Count(), p.Sum(), p.BucketCounts()
+		if cumulative {
+			key := metricDimensionsToMapKey(name, tags)
+			cur := histogramCounter{ts, count, sum, append([]uint64(nil), bucketCounts...)}
+			c, ok := counters[key]
+			if !ok {
+				c, ok = prevPts.Get(key).(histogramCounter)
+			}
+			if ok && c.ts > ts {
+				// We were given a point older than the one in memory so we drop it
+				// We keep the existing point in memory since it is the most recent
+				continue
+			}
+			counters[key] = cur
+
+			if !ok || !isHistogramIncrease(c, cur) {
+				// This is the first point or there was a reset:
+				// we can't compute a delta
+				continue
+			}
+
+			count, sum = cur.count-c.count, cur.sum-c.sum
+			bucketCounts = make([]uint64, len(cur.buckets))
+			for idx := range cur.buckets {
+				bucketCounts[idx] = cur.buckets[idx] - c.buckets[idx]
+			}
+		}
+
+		sketch := getHistogramSketch(p, count, sum, bucketCounts)
+		if sketch == nil {
+			continue
+		}
+		sketches = append(sketches, sketchSeries{
+			name:      name,
+			tags:      tags,
+			timestamp: ts,
+			sketch:    sketch,
+		})
+	}
+	return sketches
+}
+
+// commitHistogramCounters keeps the cumulative histogram points in memory,
+// unless a more recent point was kept in the meantime.
+func commitHistogramCounters(prevPts *ttlmap.TTLMap, counters map[string]histogramCounter) {
+	for key, cur := range counters {
+		if c, ok := prevPts.Get(key).(histogramCounter); ok && c.ts > cur.ts {
+			continue
+		}
+		prevPts.Put(key, cur)
+	}
+}
+
+// isHistogramIncrease checks that no count of a cumulative histogram
+// decreased between two points, i.e. there was no reset.
+func isHistogramIncrease(prev histogramCounter, cur histogramCounter) bool {
+	if cur.count < prev.count || len(cur.buckets) != len(prev.buckets) {
+		return false
+	}
+	for idx := range cur.buckets {
+		if cur.buckets[idx] < prev.buckets[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// newSketchPayload builds the payload of the Datadog sketches intake
+func newSketchPayload(sketches []sketchSeries) *pb.SketchPayload {
+	payload := &pb.SketchPayload{
+		Sketches: make([]pb.SketchPayload_Sketch, 0, len(sketches)),
+	}
+	for _, s := range sketches {
+		k, n := s.sketch.Cols()
+		payload.Sketches = append(payload.Sketches, pb.SketchPayload_Sketch{
+			Metric: s.name,
+			Host:   s.host,
+			Tags:   s.tags,
+			Dogsketches: []pb.SketchPayload_Sketch_Dogsketch{{
+				// Transform UnixNano timestamp into Unix timestamp
+				Ts:  int64(s.timestamp / 1e9),
+				Cnt: s.sketch.Basic.Cnt,
+				Min: s.sketch.Basic.Min,
+				Max: s.sketch.Basic.Max,
+				Avg: s.sketch.Basic.Avg,
+				Sum: s.sketch.Basic.Sum,
+				K:   k,
+				N:   n,
+			}},
+		})
+	}
+	return payload
+}
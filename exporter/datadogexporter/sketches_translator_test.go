@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/quantile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+)
+
+func newHistogramPoint(ts int, bounds []float64, counts []uint64, sum float64) pdata.HistogramDataPoint {
+	p := pdata.NewHistogramDataPoint()
+	var count uint64
+	for _, c := range counts {
+		count += c
+	}
+	p.SetCount(count)
+	p.SetSum(sum)
+	p.SetExplicitBounds(bounds)
+	p.SetBucketCounts(counts)
+	p.SetTimestamp(seconds(ts))
+	return p
+}
+
+func TestGetBucketBounds(t *testing.T) {
+	p := newHistogramPoint(0, []float64{0, 10}, []uint64{1, 2, 3}, 0)
+
+	lower, upper := getBucketBounds(p, 0)
+	assert.True(t, math.IsInf(lower, -1))
+	assert.Equal(t, 0.0, upper)
+
+	lower, upper = getBucketBounds(p, 1)
+	assert.Equal(t, 0.0, lower)
+	assert.Equal(t, 10.0, upper)
+
+	lower, upper = getBucketBounds(p, 2)
+	assert.Equal(t, 10.0, lower)
+	assert.True(t, math.IsInf(upper, 1))
+}
+
+func TestGetHistogramSketch(t *testing.T) {
+	p := newHistogramPoint(0, []float64{0, 10, 20, 30}, []uint64{0, 100, 100, 0, 0}, 2000)
+
+	sketch := getHistogramSketch(p, p.Count(), p.Sum(), p.BucketCounts())
+
+	require.NotNil(t, sketch)
+	assert.Equal(t, int64(200), sketch.Basic.Cnt)
+	assert.Equal(t, 2000.0, sketch.Basic.Sum)
+	assert.Equal(t, 10.0, sketch.Basic.Avg)
+	assert.InDelta(t, 10, sketch.Quantile(quantile.Default(), 0.5), 1)
+	assert.InDelta(t, 15, sketch.Quantile(quantile.Default(), 0.75), 1)
+	assert.InDelta(t, 20, sketch.Basic.Max, 1)
+}
+
+func TestGetHistogramSketchUnboundedBuckets(t *testing.T) {
+	// All the values are in the unbounded buckets
+	p := newHistogramPoint(0, []float64{10, 20}, []uint64{3, 0, 2}, 60)
+
+	sketch := getHistogramSketch(p, p.Count(), p.Sum(), p.BucketCounts())
+
+	require.NotNil(t, sketch)
+	assert.Equal(t, int64(5), sketch.Basic.Cnt)
+	assert.InDelta(t, 10, sketch.Basic.Min, 0.2)
+	assert.InDelta(t, 20, sketch.Basic.Max, 0.4)
+}
+
+func TestGetHistogramSketchNoValues(t *testing.T) {
+	empty := newHistogramPoint(0, []float64{10}, []uint64{0, 0}, 0)
+	assert.Nil(t, getHistogramSketch(empty, empty.Count(), empty.Sum(), empty.BucketCounts()))
+
+	noBounds := newHistogramPoint(0, nil, []uint64{5}, 5)
+	assert.Nil(t, getHistogramSketch(noBounds, noBounds.Count(), noBounds.Sum(), noBounds.BucketCounts()))
+
+	mismatch := newHistogramPoint(0, []float64{10, 20}, []uint64{5}, 5)
+	assert.Nil(t, getHistogramSketch(mismatch, mismatch.Count(), mismatch.Sum(), mismatch.BucketCounts()))
+}
+
+func TestMapDeltaHistogramSketches(t *testing.T) {
+	slice := pdata.NewHistogramDataPointSlice()
+	newHistogramPoint(1, []float64{10}, []uint64{2, 3}, 50).CopyTo(slice.AppendEmpty())
+	newHistogramPoint(2, []float64{10}, []uint64{1, 1}, 20).CopyTo(slice.AppendEmpty())
+
+	sketches := mapHistogramSketches("hist.test", newTTLMap(), map[string]histogramCounter{}, slice, false, []string{"attribute_tag:attribute_value"})
+
+	require.Len(t, sketches, 2)
+	assert.Equal(t, "hist.test", sketches[0].name)
+	assert.Equal(t, []string{"attribute_tag:attribute_value"}, sketches[0].tags)
+	assert.Equal(t, uint64(seconds(1)), sketches[0].timestamp)
+	assert.Equal(t, int64(5), sketches[0].sketch.Basic.Cnt)
+	assert.Equal(t, 50.0, sketches[0].sketch.Basic.Sum)
+	assert.Equal(t, int64(2), sketches[1].sketch.Basic.Cnt)
+}
+
+func TestMapCumulativeHistogramSketches(t *testing.T) {
+	slice := pdata.NewHistogramDataPointSlice()
+	newHistogramPoint(1, []float64{10}, []uint64{2, 3}, 50).CopyTo(slice.AppendEmpty())
+	newHistogramPoint(3, []float64{10}, []uint64{4, 3}, 60).CopyTo(slice.AppendEmpty())
+	// Older than the point in memory: dropped
+	newHistogramPoint(2, []float64{10}, []uint64{3, 3}, 55).CopyTo(slice.AppendEmpty())
+	// Reset: kept in memory but not exported
+	newHistogramPoint(4, []float64{10}, []uint64{1, 0}, 5).CopyTo(slice.AppendEmpty())
+	newHistogramPoint(5, []float64{10}, []uint64{1, 2}, 45).CopyTo(slice.AppendEmpty())
+
+	prevPts := newTTLMap()
+	counters := map[string]histogramCounter{}
+	sketches := mapHistogramSketches("hist.test", prevPts, counters, slice, true, []string{})
+
+	require.Len(t, sketches, 2)
+	assert.Equal(t, uint64(seconds(3)), sketches[0].timestamp)
+	assert.Equal(t, int64(2), sketches[0].sketch.Basic.Cnt)
+	assert.Equal(t, 10.0, sketches[0].sketch.Basic.Sum)
+	assert.Equal(t, uint64(seconds(5)), sketches[1].timestamp)
+	assert.Equal(t, int64(2), sketches[1].sketch.Basic.Cnt)
+	assert.Equal(t, 40.0, sketches[1].sketch.Basic.Sum)
+
+	// The points are kept in memory once committed
+	key := metricDimensionsToMapKey("hist.test", []string{})
+	assert.Nil(t, prevPts.Get(key))
+	commitHistogramCounters(prevPts, counters)
+	assert.Equal(t, uint64(seconds(5)), prevPts.Get(key).(histogramCounter).ts)
+}
+
+func TestMapMetricsDistributions(t *testing.T) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("datadog.host.name", "custom-hostname")
+	met := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	met.SetName("double.histogram")
+	met.SetDataType(pdata.MetricDataTypeHistogram)
+	met.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	newHistogramPoint(0, []float64{10}, []uint64{2, 3}, 50).CopyTo(met.Histogram().DataPoints().AppendEmpty())
+
+	_, sketches, _, _ := mapMetrics(zap.NewNop(), config.MetricsConfig{}, newTTLMap(), "", md, component.BuildInfo{})
+	assert.Empty(t, sketches)
+
+	series, sketches, _, _ := mapMetrics(zap.NewNop(), config.MetricsConfig{Distributions: true}, newTTLMap(), "", md, component.BuildInfo{})
+	require.Len(t, sketches, 1)
+	assert.Equal(t, "custom-hostname", sketches[0].host)
+	assert.Equal(t, int64(5), sketches[0].sketch.Basic.Cnt)
+	// count and sum are still reported
+	assert.Len(t, removeRunningMetrics(series), 2)
+}
+
+func TestNewSketchPayload(t *testing.T) {
+	p := newHistogramPoint(10, []float64{10}, []uint64{2, 3}, 50)
+	sketch := getHistogramSketch(p, p.Count(), p.Sum(), p.BucketCounts())
+	require.NotNil(t, sketch)
+
+	payload := newSketchPayload([]sketchSeries{{
+		name:      "hist.test",
+		host:      "host",
+		tags:      []string{"key:value"},
+		timestamp: uint64(seconds(10)),
+		sketch:    sketch,
+	}})
+
+	require.Len(t, payload.Sketches, 1)
+	s := payload.Sketches[0]
+	assert.Equal(t, "hist.test", s.Metric)
+	assert.Equal(t, "host", s.Host)
+	assert.Equal(t, []string{"key:value"}, s.Tags)
+	require.Len(t, s.Dogsketches, 1)
+	d := s.Dogsketches[0]
+	assert.Equal(t, int64(10), d.Ts)
+	assert.Equal(t, int64(5), d.Cnt)
+	assert.Equal(t, 50.0, d.Sum)
+	assert.Equal(t, 10.0, d.Avg)
+	k, n := sketch.Cols()
+	assert.Equal(t, k, d.K)
+	assert.Equal(t, n, d.N)
+	var total uint32
+	for _, c := range d.N {
+		total += c
+	}
+	assert.Equal(t, uint32(5), total)
+}
@@ -44,13 +44,52 @@ type Config struct {
 	// NumWorkers configures the number of workers publishing bulk requests.
 	NumWorkers int `mapstructure:"num_workers"`
 
-	// Index configures the index, index alias, or data stream name events should be indexed in.
+	// Index configures the index, index alias, or data stream name logs should be indexed in.
+	// If set, it takes precedence over LogsIndex.
+	//
+	// Deprecated: use LogsIndex instead.
+	Index string `mapstructure:"index"`
+
+	// LogsIndex configures the index, index alias, or data stream name logs should be indexed in.
+	// The name can hold `{attribute}` placeholders, e.g. `logs-{service.name}`, that are replaced
+	// with the value of the attribute in the record attributes, or else in the resource attributes.
 	//
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/indices.html
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/data-streams.html
-	//
-	// This setting is required.
-	Index string `mapstructure:"index"`
+	LogsIndex string `mapstructure:"logs_index"`
+
+	// LogsIndexFallback configures the index logs are indexed in when the placeholders of
+	// LogsIndex can't be resolved. Such logs are dropped if the fallback is empty.
+	LogsIndexFallback string `mapstructure:"logs_index_fallback"`
+
+	// TracesIndex configures the index, index alias, or data stream name spans should be indexed in.
+	// The name can hold `{attribute}` placeholders, resolved like the ones of LogsIndex.
+	TracesIndex string `mapstructure:"traces_index"`
+
+	// TracesIndexFallback configures the index spans are indexed in when the placeholders of
+	// TracesIndex can't be resolved.
+	TracesIndexFallback string `mapstructure:"traces_index_fallback"`
+
+	// MetricsIndex configures the index, index alias, or data stream name metric data points
+	// should be indexed in. The name can hold `{attribute}` placeholders, resolved from the
+	// data point labels, or else from the resource attributes.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
+	// MetricsIndexFallback configures the index metric data points are indexed in when the
+	// placeholders of MetricsIndex can't be resolved.
+	MetricsIndexFallback string `mapstructure:"metrics_index_fallback"`
+
+	// DataStream configures the exporter to index the records in data streams named after
+	// the Elasticsearch data stream naming scheme. The index settings are ignored when enabled.
+	DataStream DataStreamSettings `mapstructure:"data_stream"`
+
+	// IndexDateSuffix configures the date suffix appended to the index names, derived from the
+	// timestamp of each record.
+	IndexDateSuffix IndexDateSuffixSettings `mapstructure:"index_date_suffix"`
+
+	// SpanEvents configures how span events are indexed: "nested" within the span document,
+	// or "flattened" into one document per event.
+	SpanEvents string `mapstructure:"span_events"`
 
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
@@ -144,6 +183,37 @@ type RetrySettings struct {
 	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
+// IndexDateSuffixSettings defines the date based suffix of the index names. The date is
+// derived from the timestamp of each record, in UTC. Records without timestamp use the current date.
+type IndexDateSuffixSettings struct {
+	// Enabled appends the date suffix to the index names.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Separator is placed between the index name and the date.
+	Separator string `mapstructure:"separator"`
+
+	// Format is the Go time layout used to format the date, e.g. 2006.01.02 for daily indices.
+	Format string `mapstructure:"format"`
+}
+
+// DataStreamSettings defines the data streams the records are indexed in, named
+// `<type>-<dataset>-<namespace>` where the type is either logs, traces or metrics.
+// The documents hold the matching data_stream.* fields.
+//
+// https://www.elastic.co/guide/en/fleet/current/data-streams.html#data-streams-naming-scheme
+type DataStreamSettings struct {
+	// Enabled indexes the records in data streams.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dataset configures the dataset of the data streams. It can hold `{attribute}` placeholders,
+	// resolved like the ones of the index names. The "generic" dataset is used when they can't be resolved.
+	Dataset string `mapstructure:"dataset"`
+
+	// Namespace configures the namespace of the data streams. It can hold `{attribute}` placeholders,
+	// resolved like the ones of the index names. The "default" namespace is used when they can't be resolved.
+	Namespace string `mapstructure:"namespace"`
+}
+
 type MappingsSettings struct {
 	// Mode configures the field mappings.
 	Mode string `mapstructure:"mode"`
@@ -168,10 +238,20 @@ const (
 	MappingECS
 )
 
+// Enum values for SpanEvents.
+const (
+	SpanEventsNested    = "nested"
+	SpanEventsFlattened = "flattened"
+)
+
 var (
-	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
-	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
-	errConfigNoIndex       = errors.New("index must be specified")
+	errConfigNoEndpoint        = errors.New("endpoints or cloudid must be specified")
+	errConfigEmptyEndpoint     = errors.New("endpoints must not include empty entries")
+	errConfigNoIndex           = errors.New("index must be specified")
+	errConfigNoIndexDateFormat = errors.New("index_date_suffix::format must be specified when the date suffix is enabled")
+	errConfigInvalidSpanEvents = errors.New("span_events must be either nested or flattened")
+	errConfigNoDataStreamName  = errors.New("data_stream::dataset and data_stream::namespace must be specified when data streams are enabled")
+	errConfigDataStreamSuffix  = errors.New("index_date_suffix can't be enabled along with data streams")
 )
 
 func (m MappingMode) String() string {
@@ -217,8 +297,35 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
-	if cfg.Index == "" {
-		return errConfigNoIndex
+	if cfg.DataStream.Enabled {
+		if cfg.DataStream.Dataset == "" || cfg.DataStream.Namespace == "" {
+			return errConfigNoDataStreamName
+		}
+		if cfg.IndexDateSuffix.Enabled {
+			return errConfigDataStreamSuffix
+		}
+		for _, name := range []string{cfg.DataStream.Dataset, cfg.DataStream.Namespace} {
+			if _, err := parseIndexTemplate(name); err != nil {
+				return err
+			}
+		}
+	} else {
+		if cfg.logsIndex() == "" || cfg.TracesIndex == "" || cfg.MetricsIndex == "" {
+			return errConfigNoIndex
+		}
+		for _, index := range []string{cfg.logsIndex(), cfg.TracesIndex, cfg.MetricsIndex} {
+			if _, err := parseIndexTemplate(index); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cfg.IndexDateSuffix.Enabled && cfg.IndexDateSuffix.Format == "" {
+		return errConfigNoIndexDateFormat
+	}
+
+	if cfg.SpanEvents != SpanEventsNested && cfg.SpanEvents != SpanEventsFlattened {
+		return errConfigInvalidSpanEvents
 	}
 
 	if _, ok := mappingModes[cfg.Mapping.Mode]; !ok {
@@ -227,3 +334,11 @@ func (cfg *Config) Validate() error {
 
 	return nil
 }
+
+// logsIndex returns the index logs should be indexed in, honoring the deprecated Index setting.
+func (cfg *Config) logsIndex() string {
+	if cfg.Index != "" {
+		return cfg.Index
+	}
+	return cfg.LogsIndex
+}
@@ -15,6 +15,7 @@
 package elasticsearchexporter
 
 import (
+	"errors"
 	"path"
 	"testing"
 	"time"
@@ -45,11 +46,27 @@ func TestLoadConfig(t *testing.T) {
 
 	r1 := cfg.Exporters[config.NewIDWithName(typeStr, "customname")].(*Config)
 	assert.Equal(t, r1, &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "customname")),
-		Endpoints:        []string{"https://elastic.example.com:9200"},
-		CloudID:          "TRNMxjXlNJEt",
-		Index:            "myindex",
-		Pipeline:         "mypipeline",
+		ExporterSettings:     config.NewExporterSettings(config.NewIDWithName(typeStr, "customname")),
+		Endpoints:            []string{"https://elastic.example.com:9200"},
+		CloudID:              "TRNMxjXlNJEt",
+		Index:                "myindex",
+		LogsIndex:            "logs-generic-default",
+		LogsIndexFallback:    "logs-generic-default",
+		TracesIndex:          "traces-{service.name}",
+		TracesIndexFallback:  "traces-unknown",
+		MetricsIndex:         "mymetricsindex",
+		MetricsIndexFallback: "metrics-generic-default",
+		DataStream: DataStreamSettings{
+			Dataset:   "generic",
+			Namespace: "default",
+		},
+		IndexDateSuffix: IndexDateSuffixSettings{
+			Enabled:   true,
+			Separator: "-",
+			Format:    "2006.01",
+		},
+		SpanEvents: SpanEventsFlattened,
+		Pipeline:   "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -81,6 +98,81 @@ func TestLoadConfig(t *testing.T) {
 	})
 }
 
+func TestConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		config *Config
+		err    error
+	}{
+		"default": {
+			config: withDefaultConfig(),
+		},
+		"deprecated index overrides logs_index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Index = "myindex"
+				cfg.LogsIndex = ""
+			}),
+		},
+		"no logs index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.LogsIndex = ""
+			}),
+			err: errConfigNoIndex,
+		},
+		"no traces index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.TracesIndex = ""
+			}),
+			err: errConfigNoIndex,
+		},
+		"no date format": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.IndexDateSuffix.Enabled = true
+				cfg.IndexDateSuffix.Format = ""
+			}),
+			err: errConfigNoIndexDateFormat,
+		},
+		"invalid index template": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.LogsIndex = "logs-{service.name"
+			}),
+			err: errors.New(`unclosed placeholder in index name "logs-{service.name"`),
+		},
+		"data streams": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.LogsIndex = ""
+			}),
+		},
+		"data streams without namespace": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Namespace = ""
+			}),
+			err: errConfigNoDataStreamName,
+		},
+		"data streams with date suffix": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.IndexDateSuffix.Enabled = true
+			}),
+			err: errConfigDataStreamSuffix,
+		},
+		"invalid span events mode": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.SpanEvents = "separate"
+			}),
+			err: errConfigInvalidSpanEvents,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.config.Endpoints = []string{"https://elastic.example.com:9200"}
+			assert.Equal(t, test.err, test.config.Validate())
+		})
+	}
+}
+
 func withDefaultConfig(fns ...func(*Config)) *Config {
 	cfg := createDefaultConfig().(*Config)
 	for _, fn := range fns {
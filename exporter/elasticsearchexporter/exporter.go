@@ -42,8 +42,12 @@ type esBulkIndexerResponseItem = esutil7.BulkIndexerResponseItem
 type elasticsearchExporter struct {
 	logger *zap.Logger
 
-	index       string
-	maxAttempts int
+	logsRouter    *indexRouter
+	tracesRouter  *indexRouter
+	metricsRouter *indexRouter
+	maxAttempts   int
+
+	flattenSpanEvents bool
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
@@ -69,22 +73,44 @@ func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error
 		return nil, err
 	}
 
+	logsRouter, err := newIndexRouter(cfg, cfg.logsIndex(), cfg.LogsIndexFallback, dataStreamTypeLogs)
+	if err != nil {
+		return nil, err
+	}
+	tracesRouter, err := newIndexRouter(cfg, cfg.TracesIndex, cfg.TracesIndexFallback, dataStreamTypeTraces)
+	if err != nil {
+		return nil, err
+	}
+	metricsRouter, err := newIndexRouter(cfg, cfg.MetricsIndex, cfg.MetricsIndexFallback, dataStreamTypeMetrics)
+	if err != nil {
+		return nil, err
+	}
+
 	maxAttempts := 1
 	if cfg.Retry.Enabled {
 		maxAttempts = cfg.Retry.MaxRequests
 	}
 
 	// TODO: Apply encoding and field mapping settings.
-	model := &encodeModel{dedup: true, dedot: false}
+	flattenSpanEvents := cfg.SpanEvents == SpanEventsFlattened
+	model := &encodeModel{
+		dedup:             true,
+		dedot:             false,
+		flattenSpanEvents: flattenSpanEvents,
+	}
 
 	return &elasticsearchExporter{
 		logger:      logger,
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.Index,
-		maxAttempts: maxAttempts,
-		model:       model,
+		logsRouter:    logsRouter,
+		tracesRouter:  tracesRouter,
+		metricsRouter: metricsRouter,
+		maxAttempts:   maxAttempts,
+		model:         model,
+
+		flattenSpanEvents: flattenSpanEvents,
 	}, nil
 }
 
@@ -101,7 +127,7 @@ func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld pdata.Logs)
 		resource := rl.Resource()
 		ills := rl.InstrumentationLibraryLogs()
 		for j := 0; j < ills.Len(); j++ {
-			logs := ills.At(i).Logs()
+			logs := ills.At(j).Logs()
 			for k := 0; k < logs.Len(); k++ {
 				if err := e.pushLogRecord(ctx, resource, logs.At(k)); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
@@ -118,17 +144,126 @@ func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld pdata.Logs)
 }
 
 func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pdata.Resource, record pdata.LogRecord) error {
-	document, err := e.model.encodeLog(resource, record)
+	index, ds, err := e.logsRouter.route(record.Timestamp(), attributeMapLookup(record.Attributes()), attributeMapLookup(resource.Attributes()))
+	if err != nil {
+		return err
+	}
+	document, err := e.model.encodeLog(resource, record, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return e.pushEvent(ctx, document)
+	return e.pushEvent(ctx, index, document)
+}
+
+func (e *elasticsearchExporter) pushTracesData(ctx context.Context, td pdata.Traces) error {
+	var errs []error
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource()
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if err := e.pushTraceRecord(ctx, resource, spans.At(k)); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchExporter) pushTraceRecord(ctx context.Context, resource pdata.Resource, span pdata.Span) error {
+	lookups := []attributeLookup{attributeMapLookup(span.Attributes()), attributeMapLookup(resource.Attributes())}
+	index, ds, err := e.tracesRouter.route(span.StartTimestamp(), lookups...)
+	if err != nil {
+		return err
+	}
+	document, err := e.model.encodeSpan(resource, span, ds)
+	if err != nil {
+		return fmt.Errorf("Failed to encode trace record: %w", err)
+	}
+	if err = e.pushEvent(ctx, index, document); err != nil {
+		return err
+	}
+
+	if !e.flattenSpanEvents {
+		return nil
+	}
+
+	// flattened span events are indexed as separate documents, next to their span
+
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		index, ds, err := e.tracesRouter.route(event.Timestamp(), lookups...)
+		if err != nil {
+			return err
+		}
+		document, err := e.model.encodeSpanEvent(resource, span, event, ds)
+		if err != nil {
+			return fmt.Errorf("Failed to encode span event: %w", err)
+		}
+		if err = e.pushEvent(ctx, index, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *elasticsearchExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) error {
+	var errs []error
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource()
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if err := e.pushMetricRecord(ctx, resource, metrics.At(k)); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchExporter) pushMetricRecord(ctx context.Context, resource pdata.Resource, metric pdata.Metric) error {
+	for _, dp := range metricDataPoints(metric) {
+		index, ds, err := e.metricsRouter.route(dp.Timestamp(), stringMapLookup(dp.LabelsMap()), attributeMapLookup(resource.Attributes()))
+		if err != nil {
+			return err
+		}
+		document, err := e.model.encodeMetricDataPoint(resource, metric, dp, ds)
+		if err != nil {
+			return fmt.Errorf("Failed to encode metric: %w", err)
+		}
+		if err := e.pushEvent(ctx, index, document); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (e *elasticsearchExporter) pushEvent(ctx context.Context, document []byte) error {
+func (e *elasticsearchExporter) pushEvent(ctx context.Context, index string, document []byte) error {
 	attempts := 1
 	body := bytes.NewReader(document)
-	item := esBulkIndexerItem{Action: createAction, Index: e.index, Body: body}
+	item := esBulkIndexerItem{Action: createAction, Index: index, Body: body}
 
 	// Setup error handler. The handler handles the per item response status based on the
 	// selective ACKing in the bulk response.
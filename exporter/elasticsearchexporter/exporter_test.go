@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 )
@@ -308,6 +309,130 @@ func TestExporter_PushEvent(t *testing.T) {
 	})
 }
 
+func TestExporter_PushTracesAndMetrics(t *testing.T) {
+	indexOf := func(t *testing.T, item itemRequest) string {
+		var action struct {
+			Create struct {
+				Index string `json:"_index"`
+			} `json:"create"`
+		}
+		require.NoError(t, json.Unmarshal(item.Action, &action))
+		return action.Create.Index
+	}
+
+	t.Run("traces with flattened span events", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.SpanEvents = SpanEventsFlattened
+		})
+
+		resource, span := testSpan()
+		td := pdata.NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		resource.CopyTo(rs.Resource())
+		span.CopyTo(rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty())
+		require.NoError(t, exporter.pushTracesData(context.TODO(), td))
+
+		rec.WaitItems(2)
+		for _, item := range rec.Items() {
+			assert.Equal(t, "traces-generic-default", indexOf(t, item))
+		}
+	})
+
+	t.Run("metrics with date suffix", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = "metrics"
+			cfg.IndexDateSuffix.Enabled = true
+		})
+
+		md := pdata.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("queue.size")
+		m.SetDataType(pdata.MetricDataTypeIntGauge)
+		m.IntGauge().DataPoints().AppendEmpty().SetTimestamp(pdata.TimestampFromTime(testStart))
+		m.IntGauge().DataPoints().AppendEmpty().SetTimestamp(pdata.TimestampFromTime(testStart.AddDate(0, 0, 1)))
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), md))
+
+		rec.WaitItems(2)
+		var indices []string
+		for _, item := range rec.Items() {
+			indices = append(indices, indexOf(t, item))
+		}
+		assert.ElementsMatch(t, []string{"metrics-2021.07.20", "metrics-2021.07.21"}, indices)
+	})
+
+	t.Run("metrics with dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = "metrics-{service.name}-{queue}"
+			cfg.MetricsIndexFallback = "metrics-unknown"
+		})
+
+		md := pdata.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", "Checkout")
+		m := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("queue.size")
+		m.SetDataType(pdata.MetricDataTypeIntGauge)
+		m.IntGauge().DataPoints().AppendEmpty().LabelsMap().Insert("queue", "orders")
+		m.IntGauge().DataPoints().AppendEmpty()
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), md))
+
+		rec.WaitItems(2)
+		var indices []string
+		for _, item := range rec.Items() {
+			indices = append(indices, indexOf(t, item))
+		}
+		assert.ElementsMatch(t, []string{"metrics-checkout-orders", "metrics-unknown"}, indices)
+	})
+
+	t.Run("traces in data streams", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.DataStream.Enabled = true
+			cfg.DataStream.Dataset = "{service.name}"
+		})
+
+		resource, span := testSpan()
+		td := pdata.NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		resource.CopyTo(rs.Resource())
+		span.CopyTo(rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty())
+		require.NoError(t, exporter.pushTracesData(context.TODO(), td))
+
+		rec.WaitItems(1)
+		item := rec.Items()[0]
+		assert.Equal(t, "traces-checkout-default", indexOf(t, item))
+
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(item.Document, &document))
+		assert.Equal(t, "traces", document["data_stream.type"])
+		assert.Equal(t, "checkout", document["data_stream.dataset"])
+		assert.Equal(t, "default", document["data_stream.namespace"])
+	})
+}
+
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchExporter {
 	exporter, err := newExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
 	require.NoError(t, err)
@@ -330,6 +455,6 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 }
 
 func mustSend(t *testing.T, exporter *elasticsearchExporter, contents string) {
-	err := exporter.pushEvent(context.TODO(), []byte(contents))
+	err := exporter.pushEvent(context.TODO(), "logs-generic-default", []byte(contents))
 	require.NoError(t, err)
 }
@@ -35,6 +35,8 @@ func NewFactory() component.ExporterFactory {
 		typeStr,
 		createDefaultConfig,
 		exporterhelper.WithLogs(createLogsExporter),
+		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
 	)
 }
 
@@ -44,7 +46,21 @@ func createDefaultConfig() config.Exporter {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index: "logs-generic-default",
+		LogsIndex:            "logs-generic-default",
+		LogsIndexFallback:    "logs-generic-default",
+		TracesIndex:          "traces-generic-default",
+		TracesIndexFallback:  "traces-generic-default",
+		MetricsIndex:         "metrics-generic-default",
+		MetricsIndexFallback: "metrics-generic-default",
+		DataStream: DataStreamSettings{
+			Dataset:   defaultDataStreamDataset,
+			Namespace: defaultDataStreamNamespace,
+		},
+		IndexDateSuffix: IndexDateSuffixSettings{
+			Separator: "-",
+			Format:    "2006.01.02",
+		},
+		SpanEvents: SpanEventsNested,
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -79,3 +95,45 @@ func createLogsExporter(
 		exporterhelper.WithShutdown(exporter.Shutdown),
 	)
 }
+
+// createTracesExporter creates a new exporter for traces.
+//
+// Spans are directly indexed into Elasticsearch.
+func createTracesExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	exporter, err := newExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch traces exporter: %w", err)
+	}
+
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		exporter.pushTracesData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
+
+// createMetricsExporter creates a new exporter for metrics.
+//
+// Each metric data point is indexed as a document into Elasticsearch.
+func createMetricsExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	exporter, err := newExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
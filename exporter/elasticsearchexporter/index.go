@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Data stream types, one per signal.
+const (
+	dataStreamTypeLogs    = "logs"
+	dataStreamTypeTraces  = "traces"
+	dataStreamTypeMetrics = "metrics"
+)
+
+const (
+	defaultDataStreamDataset   = "generic"
+	defaultDataStreamNamespace = "default"
+)
+
+var errUnresolvedIndex = errors.New("index name could not be resolved from the record attributes and no fallback is configured")
+
+// indexTemplate is an index name holding `{attribute}` placeholders, that are resolved
+// from the attributes of each record.
+type indexTemplate struct {
+	parts []templatePart
+}
+
+// templatePart is either a literal part of the index name or the name of an attribute.
+type templatePart struct {
+	literal   string
+	attribute string
+}
+
+// parseIndexTemplate parses an index name holding `{attribute}` placeholders.
+func parseIndexTemplate(tmpl string) (*indexTemplate, error) {
+	var parts []templatePart
+	for rest := tmpl; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			parts = append(parts, templatePart{literal: rest})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, templatePart{literal: rest[:start]})
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in index name %q", tmpl)
+		}
+		attribute := rest[start+1 : start+end]
+		if attribute == "" || strings.ContainsRune(attribute, '{') {
+			return nil, fmt.Errorf("invalid placeholder in index name %q", tmpl)
+		}
+		parts = append(parts, templatePart{attribute: attribute})
+		rest = rest[start+end+1:]
+	}
+	return &indexTemplate{parts: parts}, nil
+}
+
+// resolve replaces the placeholders with the value of the first lookup holding the attribute.
+// It returns false if any of the attributes could not be found.
+func (t *indexTemplate) resolve(lookups []attributeLookup) (string, bool) {
+	var sb strings.Builder
+	for _, part := range t.parts {
+		if part.attribute == "" {
+			sb.WriteString(part.literal)
+			continue
+		}
+
+		value, found := lookupAttribute(part.attribute, lookups)
+		if !found {
+			return "", false
+		}
+		sb.WriteString(sanitizeIndexPart(value))
+	}
+	return sb.String(), true
+}
+
+// attributeLookup returns the string value of the attribute with the given key.
+type attributeLookup func(key string) (string, bool)
+
+func attributeMapLookup(am pdata.AttributeMap) attributeLookup {
+	return func(key string) (string, bool) {
+		v, found := am.Get(key)
+		if !found {
+			return "", false
+		}
+		switch v.Type() {
+		case pdata.AttributeValueTypeString:
+			return v.StringVal(), v.StringVal() != ""
+		case pdata.AttributeValueTypeInt:
+			return fmt.Sprint(v.IntVal()), true
+		case pdata.AttributeValueTypeBool:
+			return fmt.Sprint(v.BoolVal()), true
+		default:
+			return "", false
+		}
+	}
+}
+
+func stringMapLookup(sm pdata.StringMap) attributeLookup {
+	return func(key string) (string, bool) {
+		v, found := sm.Get(key)
+		return v, found && v != ""
+	}
+}
+
+func lookupAttribute(key string, lookups []attributeLookup) (string, bool) {
+	for _, lookup := range lookups {
+		if v, found := lookup(key); found {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// sanitizeIndexPart makes an attribute value usable in an index name, which must be lowercase
+// and can't hold some characters.
+func sanitizeIndexPart(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', '/', '*', '?', '"', '<', '>', '|', ' ', ',', '#', ':':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(value))
+}
+
+// dataStream identifies the data stream a document is indexed in.
+// The zero value means the document is not indexed in a data stream.
+type dataStream struct {
+	typ       string
+	dataset   string
+	namespace string
+}
+
+func (ds dataStream) name() string {
+	return ds.typ + "-" + ds.dataset + "-" + ds.namespace
+}
+
+// indexRouter resolves the index, or data stream, the records of a signal are indexed in.
+type indexRouter struct {
+	index    *indexTemplate
+	fallback string
+
+	// dataStreamType is set when the records are indexed in data streams,
+	// which are named after the dataset and namespace instead of the index.
+	dataStreamType string
+	dataset        *indexTemplate
+	namespace      *indexTemplate
+
+	dateSuffix IndexDateSuffixSettings
+}
+
+func newIndexRouter(cfg *Config, index string, fallback string, dataStreamType string) (*indexRouter, error) {
+	router := &indexRouter{fallback: fallback, dateSuffix: cfg.IndexDateSuffix}
+
+	if cfg.DataStream.Enabled {
+		router.dataStreamType = dataStreamType
+
+		var err error
+		if router.dataset, err = parseIndexTemplate(cfg.DataStream.Dataset); err != nil {
+			return nil, err
+		}
+		if router.namespace, err = parseIndexTemplate(cfg.DataStream.Namespace); err != nil {
+			return nil, err
+		}
+		return router, nil
+	}
+
+	var err error
+	router.index, err = parseIndexTemplate(index)
+	return router, err
+}
+
+// route returns the index a record with the given timestamp and attributes is indexed in,
+// along with its data stream if data streams are enabled. The attributes are looked up in order,
+// usually the record attributes first, then the resource attributes.
+func (r *indexRouter) route(ts pdata.Timestamp, lookups ...attributeLookup) (string, dataStream, error) {
+	if r.dataStreamType != "" {
+		ds := dataStream{
+			typ:       r.dataStreamType,
+			dataset:   defaultDataStreamDataset,
+			namespace: defaultDataStreamNamespace,
+		}
+		// the dash separates the parts of the data stream names, so it can't be used within them
+		if dataset, ok := r.dataset.resolve(lookups); ok && dataset != "" {
+			ds.dataset = strings.ReplaceAll(dataset, "-", "_")
+		}
+		if namespace, ok := r.namespace.resolve(lookups); ok && namespace != "" {
+			ds.namespace = strings.ReplaceAll(namespace, "-", "_")
+		}
+		return ds.name(), ds, nil
+	}
+
+	index, ok := r.index.resolve(lookups)
+	if !ok {
+		if r.fallback == "" {
+			return "", dataStream{}, consumererror.Permanent(errUnresolvedIndex)
+		}
+		index = r.fallback
+	}
+
+	if !r.dateSuffix.Enabled {
+		return index, dataStream{}, nil
+	}
+
+	t := time.Now()
+	if ts != 0 {
+		t = ts.AsTime()
+	}
+	return index + r.dateSuffix.Separator + t.UTC().Format(r.dateSuffix.Format), dataStream{}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestParseIndexTemplate(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		parts    []templatePart
+		err      string
+	}{
+		"static": {
+			template: "logs-generic-default",
+			parts:    []templatePart{{literal: "logs-generic-default"}},
+		},
+		"placeholders": {
+			template: "logs-{service.name}-{k8s.namespace.name}",
+			parts: []templatePart{
+				{literal: "logs-"},
+				{attribute: "service.name"},
+				{literal: "-"},
+				{attribute: "k8s.namespace.name"},
+			},
+		},
+		"placeholder only": {
+			template: "{index}",
+			parts:    []templatePart{{attribute: "index"}},
+		},
+		"unclosed placeholder": {
+			template: "logs-{service.name",
+			err:      `unclosed placeholder in index name "logs-{service.name"`,
+		},
+		"empty placeholder": {
+			template: "logs-{}",
+			err:      `invalid placeholder in index name "logs-{}"`,
+		},
+		"nested placeholder": {
+			template: "logs-{a{b}}",
+			err:      `invalid placeholder in index name "logs-{a{b}}"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := parseIndexTemplate(test.template)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.parts, tmpl.parts)
+		})
+	}
+}
+
+func TestIndexRouter(t *testing.T) {
+	record := pdata.NewAttributeMap()
+	record.InsertString("service.name", "Frontend")
+	record.InsertInt("shard", 3)
+	resource := pdata.NewAttributeMap()
+	resource.InsertString("service.name", "checkout")
+	resource.InsertString("k8s.namespace.name", "prod-eu")
+	lookups := []attributeLookup{attributeMapLookup(record), attributeMapLookup(resource)}
+
+	ts := pdata.TimestampFromTime(testStart)
+
+	tests := map[string]struct {
+		config   func(*Config)
+		index    string
+		fallback string
+		want     string
+		ds       dataStream
+		err      error
+	}{
+		"static": {
+			index: "logs-generic-default",
+			want:  "logs-generic-default",
+		},
+		"record attributes take precedence": {
+			index: "logs-{service.name}-{k8s.namespace.name}-{shard}",
+			want:  "logs-frontend-prod-eu-3",
+		},
+		"fallback": {
+			index:    "logs-{host.name}",
+			fallback: "logs-unknown",
+			want:     "logs-unknown",
+		},
+		"no fallback": {
+			index: "logs-{host.name}",
+			err:   errUnresolvedIndex,
+		},
+		"date suffix": {
+			config: func(cfg *Config) {
+				cfg.IndexDateSuffix.Enabled = true
+			},
+			index: "logs-{k8s.namespace.name}",
+			want:  "logs-prod-eu-2021.07.20",
+		},
+		"data stream": {
+			config: func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Dataset = "{service.name}"
+				cfg.DataStream.Namespace = "{k8s.namespace.name}"
+			},
+			want: "logs-frontend-prod_eu",
+			ds:   dataStream{typ: "logs", dataset: "frontend", namespace: "prod_eu"},
+		},
+		"data stream defaults": {
+			config: func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Dataset = "{host.name}"
+			},
+			want: "logs-generic-default",
+			ds:   dataStream{typ: "logs", dataset: "generic", namespace: "default"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := withDefaultConfig()
+			if test.config != nil {
+				test.config(cfg)
+			}
+			router, err := newIndexRouter(cfg, test.index, test.fallback, dataStreamTypeLogs)
+			require.NoError(t, err)
+
+			index, ds, err := router.route(ts, lookups...)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				assert.True(t, consumererror.IsPermanent(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, index)
+			assert.Equal(t, test.ds, ds)
+		})
+	}
+}
+
+func TestSanitizeIndexPart(t *testing.T) {
+	assert.Equal(t, "my_service_v1", sanitizeIndexPart("My Service/v1"))
+}
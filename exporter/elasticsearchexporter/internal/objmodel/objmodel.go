@@ -264,7 +264,7 @@ func (doc *Document) iterJSONFlat(w *json.Visitor) error {
 		}
 
 		w.OnKey(fld.key)
-		if err := fld.value.iterJSON(w, true); err != nil {
+		if err := fld.value.iterJSON(w, false); err != nil {
 			return err
 		}
 	}
@@ -299,6 +299,11 @@ func ArrValue(values ...Value) Value {
 	return Value{kind: KindArr, arr: values}
 }
 
+// ObjectValue creates a new value holding a nested document.
+func ObjectValue(doc Document) Value {
+	return Value{kind: KindObject, doc: doc}
+}
+
 // TimestampValue create a new value from a time.Time.
 func TimestampValue(ts time.Time) Value {
 	return Value{kind: KindTimestamp, ts: ts}
@@ -318,6 +318,15 @@ func TestValue_Serialize(t *testing.T) {
 			value: Value{kind: KindObject, doc: Document{}},
 			want:  "null",
 		},
+		"array of objects": {
+			value: func() Value {
+				doc := Document{}
+				doc.AddString("a", "b")
+				doc.AddInt("c", 1)
+				return ArrValue(ObjectValue(doc), ObjectValue(doc))
+			}(),
+			want: `[{"a":"b","c":1},{"a":"b","c":1}]`,
+		},
 	}
 
 	for name, test := range tests {
@@ -329,3 +338,17 @@ func TestValue_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestDocument_SerializeNestedObjects(t *testing.T) {
+	var event Document
+	event.AddString("Name", "exception")
+	event.AddInt("Count", 2)
+
+	var doc Document
+	doc.AddString("Name", "span")
+	doc.Add("Events", ArrValue(ObjectValue(event)))
+
+	var buf strings.Builder
+	require.NoError(t, doc.Serialize(&buf, false))
+	assert.Equal(t, `{"Name":"span","Events":[{"Name":"exception","Count":2}]}`, buf.String())
+}
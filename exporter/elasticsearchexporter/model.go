@@ -23,7 +23,49 @@ import (
 )
 
 type mappingModel interface {
-	encodeLog(pdata.Resource, pdata.LogRecord) ([]byte, error)
+	encodeLog(pdata.Resource, pdata.LogRecord, dataStream) ([]byte, error)
+	encodeSpan(pdata.Resource, pdata.Span, dataStream) ([]byte, error)
+	encodeSpanEvent(pdata.Resource, pdata.Span, pdata.SpanEvent, dataStream) ([]byte, error)
+	encodeMetricDataPoint(pdata.Resource, pdata.Metric, dataPoint, dataStream) ([]byte, error)
+}
+
+// dataPoint is implemented by all the metric data point types.
+type dataPoint interface {
+	StartTimestamp() pdata.Timestamp
+	Timestamp() pdata.Timestamp
+	LabelsMap() pdata.StringMap
+}
+
+// metricDataPoints returns the data points of the metric, each of them being encoded as a document.
+func metricDataPoints(metric pdata.Metric) []dataPoint {
+	var dps []dataPoint
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		for i, s := 0, metric.IntGauge().DataPoints(); i < s.Len(); i++ {
+			dps = append(dps, s.At(i))
+		}
+	case pdata.MetricDataTypeIntSum:
+		for i, s := 0, metric.IntSum().DataPoints(); i < s.Len(); i++ {
+			dps = append(dps, s.At(i))
+		}
+	case pdata.MetricDataTypeGauge:
+		for i, s := 0, metric.Gauge().DataPoints(); i < s.Len(); i++ {
+			dps = append(dps, s.At(i))
+		}
+	case pdata.MetricDataTypeSum:
+		for i, s := 0, metric.Sum().DataPoints(); i < s.Len(); i++ {
+			dps = append(dps, s.At(i))
+		}
+	case pdata.MetricDataTypeHistogram:
+		for i, s := 0, metric.Histogram().DataPoints(); i < s.Len(); i++ {
+			dps = append(dps, s.At(i))
+		}
+	case pdata.MetricDataTypeSummary:
+		for i, s := 0, metric.Summary().DataPoints(); i < s.Len(); i++ {
+			dps = append(dps, s.At(i))
+		}
+	}
+	return dps
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -35,9 +77,13 @@ type mappingModel interface {
 type encodeModel struct {
 	dedup bool
 	dedot bool
+
+	// flattenSpanEvents excludes the span events from the span documents,
+	// as they are encoded as separate documents instead.
+	flattenSpanEvents bool
 }
 
-func (m *encodeModel) encodeLog(resource pdata.Resource, record pdata.LogRecord) ([]byte, error) {
+func (m *encodeModel) encodeLog(resource pdata.Resource, record pdata.LogRecord, ds dataStream) ([]byte, error) {
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", record.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddID("TraceId", record.TraceID())
@@ -49,7 +95,144 @@ func (m *encodeModel) encodeLog(resource pdata.Resource, record pdata.LogRecord)
 	document.AddAttribute("Body", record.Body())
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
+	addDataStream(&document, ds)
+
+	return m.serialize(document)
+}
+
+func (m *encodeModel) encodeSpan(resource pdata.Resource, span pdata.Span, ds dataStream) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream traces template is used.
+	document.AddTimestamp("EndTimestamp", span.EndTimestamp())
+	document.AddInt("Duration", int64(span.EndTimestamp()-span.StartTimestamp()))
+	document.AddID("TraceId", span.TraceID())
+	document.AddID("SpanId", span.SpanID())
+	document.AddID("ParentSpanId", span.ParentSpanID())
+	document.AddString("TraceState", string(span.TraceState()))
+	document.AddString("Name", span.Name())
+	document.AddString("Kind", span.Kind().String())
+	document.AddString("TraceStatus", span.Status().Code().String())
+	document.AddString("TraceStatusDescription", span.Status().Message())
+	document.AddAttributes("Attributes", span.Attributes())
+	document.AddAttributes("Resource", resource.Attributes())
+
+	if links := span.Links(); links.Len() > 0 {
+		values := make([]objmodel.Value, links.Len())
+		for i := 0; i < links.Len(); i++ {
+			link := links.At(i)
+			var linkDoc objmodel.Document
+			linkDoc.AddID("TraceId", link.TraceID())
+			linkDoc.AddID("SpanId", link.SpanID())
+			linkDoc.AddString("TraceState", string(link.TraceState()))
+			linkDoc.AddAttributes("Attributes", link.Attributes())
+			values[i] = objmodel.ObjectValue(linkDoc)
+		}
+		document.Add("Links", objmodel.ArrValue(values...))
+	}
+
+	if events := span.Events(); events.Len() > 0 && !m.flattenSpanEvents {
+		values := make([]objmodel.Value, events.Len())
+		for i := 0; i < events.Len(); i++ {
+			event := events.At(i)
+			var eventDoc objmodel.Document
+			eventDoc.AddTimestamp("@timestamp", event.Timestamp())
+			eventDoc.AddString("Name", event.Name())
+			eventDoc.AddAttributes("Attributes", event.Attributes())
+			values[i] = objmodel.ObjectValue(eventDoc)
+		}
+		document.Add("Events", objmodel.ArrValue(values...))
+	}
+	addDataStream(&document, ds)
+
+	return m.serialize(document)
+}
+
+// encodeSpanEvent encodes a span event as a standalone document, referencing the span it belongs to.
+func (m *encodeModel) encodeSpanEvent(resource pdata.Resource, span pdata.Span, event pdata.SpanEvent, ds dataStream) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", event.Timestamp())
+	document.AddID("TraceId", span.TraceID())
+	document.AddID("SpanId", span.SpanID())
+	document.AddString("SpanName", span.Name())
+	document.AddString("Name", event.Name())
+	document.AddAttributes("Attributes", event.Attributes())
+	document.AddAttributes("Resource", resource.Attributes())
+	addDataStream(&document, ds)
+
+	return m.serialize(document)
+}
+
+// encodeMetricDataPoint encodes a data point of the metric as a document.
+func (m *encodeModel) encodeMetricDataPoint(resource pdata.Resource, metric pdata.Metric, dp dataPoint, ds dataStream) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", dp.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream metrics template is used.
+	if start := dp.StartTimestamp(); start != 0 {
+		document.AddTimestamp("StartTimestamp", start)
+	}
+	document.AddString("Name", metric.Name())
+	document.AddString("Unit", metric.Unit())
+	document.AddString("Type", metric.DataType().String())
+	dp.LabelsMap().Range(func(k string, v string) bool {
+		document.AddString("Attributes."+k, v)
+		return true
+	})
+	document.AddAttributes("Resource", resource.Attributes())
+
+	switch dp := dp.(type) {
+	case pdata.IntDataPoint:
+		document.AddInt("Value", dp.Value())
+	case pdata.NumberDataPoint:
+		if dp.Type() == pdata.MetricValueTypeInt {
+			document.AddInt("Value", dp.IntVal())
+		} else {
+			document.Add("Value", objmodel.DoubleValue(dp.DoubleVal()))
+		}
+	case pdata.HistogramDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+
+		counts := make([]objmodel.Value, len(dp.BucketCounts()))
+		for i, count := range dp.BucketCounts() {
+			counts[i] = objmodel.IntValue(int64(count))
+		}
+		document.Add("BucketCounts", objmodel.ArrValue(counts...))
+
+		bounds := make([]objmodel.Value, len(dp.ExplicitBounds()))
+		for i, bound := range dp.ExplicitBounds() {
+			bounds[i] = objmodel.DoubleValue(bound)
+		}
+		document.Add("ExplicitBounds", objmodel.ArrValue(bounds...))
+	case pdata.SummaryDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+
+		qvs := dp.QuantileValues()
+		quantiles := make([]objmodel.Value, qvs.Len())
+		for i := 0; i < qvs.Len(); i++ {
+			var quantile objmodel.Document
+			quantile.Add("Quantile", objmodel.DoubleValue(qvs.At(i).Quantile()))
+			quantile.Add("Value", objmodel.DoubleValue(qvs.At(i).Value()))
+			quantiles[i] = objmodel.ObjectValue(quantile)
+		}
+		document.Add("Quantiles", objmodel.ArrValue(quantiles...))
+	}
+	addDataStream(&document, ds)
+
+	return m.serialize(document)
+}
+
+// addDataStream adds the data stream fields Elasticsearch requires in the documents
+// indexed in data streams.
+func addDataStream(document *objmodel.Document, ds dataStream) {
+	if ds.typ == "" {
+		return
+	}
+	document.AddString("data_stream.type", ds.typ)
+	document.AddString("data_stream.dataset", ds.dataset)
+	document.AddString("data_stream.namespace", ds.namespace)
+}
 
+func (m *encodeModel) serialize(document objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
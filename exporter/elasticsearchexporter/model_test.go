@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var (
+	testStart = time.Date(2021, 7, 20, 10, 0, 0, 0, time.UTC)
+	testEnd   = testStart.Add(time.Second)
+)
+
+func TestEncodeSpan(t *testing.T) {
+	resource, span := testSpan()
+
+	model := &encodeModel{dedup: true}
+	body, err := model.encodeSpan(resource, span, dataStream{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"@timestamp": "2021-07-20T10:00:00.000000000Z",
+		"Attributes.http.method": "GET",
+		"Duration": 1000000000,
+		"EndTimestamp": "2021-07-20T10:00:01.000000000Z",
+		"Events": [{"@timestamp": "2021-07-20T10:00:01.000000000Z", "Attributes.exception.type": "Error", "Name": "exception"}],
+		"Kind": "SPAN_KIND_SERVER",
+		"Links": [{"SpanId": "0203040506070809", "TraceId": "02030405060708090a0b0c0d0e0f1011"}],
+		"Name": "GET /",
+		"ParentSpanId": "0102030405060708",
+		"Resource.service.name": "checkout",
+		"SpanId": "0101010101010101",
+		"TraceId": "0102030405060708090a0b0c0d0e0f10",
+		"TraceStatus": "STATUS_CODE_ERROR",
+		"TraceStatusDescription": "boom"
+	}`, string(body))
+}
+
+func TestEncodeSpanFlattenedEvents(t *testing.T) {
+	resource, span := testSpan()
+
+	model := &encodeModel{dedup: true, flattenSpanEvents: true}
+	body, err := model.encodeSpan(resource, span, dataStream{})
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "Events")
+
+	body, err = model.encodeSpanEvent(resource, span, span.Events().At(0), dataStream{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2021-07-20T10:00:01.000000000Z",
+		"Attributes.exception.type": "Error",
+		"Name": "exception",
+		"Resource.service.name": "checkout",
+		"SpanId": "0101010101010101",
+		"SpanName": "GET /",
+		"TraceId": "0102030405060708090a0b0c0d0e0f10"
+	}`, string(body))
+}
+
+func TestEncodeMetric(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+
+	tests := map[string]struct {
+		metric func() pdata.Metric
+		want   []string
+	}{
+		"int gauge": {
+			metric: func() pdata.Metric {
+				m := pdata.NewMetric()
+				m.SetName("queue.size")
+				m.SetDataType(pdata.MetricDataTypeIntGauge)
+				dp := m.IntGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pdata.TimestampFromTime(testEnd))
+				dp.SetValue(42)
+				dp.LabelsMap().Insert("queue", "orders")
+				return m
+			},
+			want: []string{`{
+				"@timestamp": "2021-07-20T10:00:01.000000000Z",
+				"Attributes.queue": "orders",
+				"Name": "queue.size",
+				"Resource.service.name": "checkout",
+				"Type": "IntGauge",
+				"Value": 42
+			}`},
+		},
+		"double sum": {
+			metric: func() pdata.Metric {
+				m := pdata.NewMetric()
+				m.SetName("requests")
+				m.SetUnit("1")
+				m.SetDataType(pdata.MetricDataTypeSum)
+				for _, v := range []float64{1.5, 2.5} {
+					dp := m.Sum().DataPoints().AppendEmpty()
+					dp.SetStartTimestamp(pdata.TimestampFromTime(testStart))
+					dp.SetTimestamp(pdata.TimestampFromTime(testEnd))
+					dp.SetDoubleVal(v)
+				}
+				return m
+			},
+			want: []string{`{
+				"@timestamp": "2021-07-20T10:00:01.000000000Z",
+				"StartTimestamp": "2021-07-20T10:00:00.000000000Z",
+				"Name": "requests",
+				"Unit": "1",
+				"Resource.service.name": "checkout",
+				"Type": "Sum",
+				"Value": 1.5
+			}`, `{
+				"@timestamp": "2021-07-20T10:00:01.000000000Z",
+				"StartTimestamp": "2021-07-20T10:00:00.000000000Z",
+				"Name": "requests",
+				"Unit": "1",
+				"Resource.service.name": "checkout",
+				"Type": "Sum",
+				"Value": 2.5
+			}`},
+		},
+		"histogram": {
+			metric: func() pdata.Metric {
+				m := pdata.NewMetric()
+				m.SetName("latency")
+				m.SetDataType(pdata.MetricDataTypeHistogram)
+				dp := m.Histogram().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pdata.TimestampFromTime(testEnd))
+				dp.SetCount(3)
+				dp.SetSum(7.5)
+				dp.SetBucketCounts([]uint64{1, 2, 0})
+				dp.SetExplicitBounds([]float64{1, 5})
+				return m
+			},
+			want: []string{`{
+				"@timestamp": "2021-07-20T10:00:01.000000000Z",
+				"BucketCounts": [1, 2, 0],
+				"Count": 3,
+				"ExplicitBounds": [1, 5],
+				"Name": "latency",
+				"Resource.service.name": "checkout",
+				"Sum": 7.5,
+				"Type": "Histogram"
+			}`},
+		},
+		"summary": {
+			metric: func() pdata.Metric {
+				m := pdata.NewMetric()
+				m.SetName("latency")
+				m.SetDataType(pdata.MetricDataTypeSummary)
+				dp := m.Summary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pdata.TimestampFromTime(testEnd))
+				dp.SetCount(3)
+				dp.SetSum(7.5)
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(0.99)
+				qv.SetValue(4)
+				return m
+			},
+			want: []string{`{
+				"@timestamp": "2021-07-20T10:00:01.000000000Z",
+				"Count": 3,
+				"Name": "latency",
+				"Quantiles": [{"Quantile": 0.99, "Value": 4}],
+				"Resource.service.name": "checkout",
+				"Sum": 7.5,
+				"Type": "Summary"
+			}`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			model := &encodeModel{dedup: true}
+			metric := test.metric()
+			dps := metricDataPoints(metric)
+			require.Len(t, dps, len(test.want))
+			for i, want := range test.want {
+				body, err := model.encodeMetricDataPoint(resource, metric, dps[i], dataStream{})
+				require.NoError(t, err)
+				assert.JSONEq(t, want, string(body))
+			}
+		})
+	}
+}
+
+func TestEncodeLogDataStream(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+	record := pdata.NewLogRecord()
+	record.SetTimestamp(pdata.TimestampFromTime(testStart))
+	record.Body().SetStringVal("hello")
+
+	model := &encodeModel{dedup: true}
+	body, err := model.encodeLog(resource, record, dataStream{typ: "logs", dataset: "checkout", namespace: "default"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2021-07-20T10:00:00.000000000Z",
+		"Body": "hello",
+		"Resource.service.name": "checkout",
+		"SeverityNumber": 0,
+		"TraceFlags": 0,
+		"data_stream.dataset": "checkout",
+		"data_stream.namespace": "default",
+		"data_stream.type": "logs"
+	}`, string(body))
+}
+
+func testSpan() (pdata.Resource, pdata.Span) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+
+	span := pdata.NewSpan()
+	span.SetName("GET /")
+	span.SetKind(pdata.SpanKindServer)
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1}))
+	span.SetParentSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetStartTimestamp(pdata.TimestampFromTime(testStart))
+	span.SetEndTimestamp(pdata.TimestampFromTime(testEnd))
+	span.Status().SetCode(pdata.StatusCodeError)
+	span.Status().SetMessage("boom")
+	span.Attributes().InsertString("http.method", "GET")
+
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(pdata.TimestampFromTime(testEnd))
+	event.Attributes().InsertString("exception.type", "Error")
+
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pdata.NewTraceID([16]byte{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}))
+	link.SetSpanID(pdata.NewSpanID([8]byte{2, 3, 4, 5, 6, 7, 8, 9}))
+
+	return resource, span
+}
@@ -35,6 +35,7 @@ func NewFactory() component.ExporterFactory {
 		typeStr,
 		createDefaultConfig,
 		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithLogs(createLogsExporter),
 	)
 }
 
@@ -92,3 +93,35 @@ func createTracesExporter(
 		exporterhelper.WithShutdown(exporter.shutdown),
 	)
 }
+
+func createLogsExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	config config.Exporter,
+) (component.LogsExporter, error) {
+	if config == nil {
+		return nil, errors.New("missing config")
+	}
+	cfg := config.(*Config)
+
+	if err := cfg.sanitize(); err != nil {
+		return nil, err
+	}
+
+	// We only require the log ingest token when the log exporter is enabled
+	if cfg.Logs.IngestToken == "" {
+		return nil, errors.New("an ingest token for logs is required when enabling the Humio log exporter")
+	}
+
+	exporter := newLogsExporter(cfg, set.Logger)
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		set,
+		exporter.pushLogData,
+		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithRetry(cfg.RetrySettings),
+		exporterhelper.WithStart(exporter.start),
+		exporterhelper.WithShutdown(exporter.shutdown),
+	)
+}
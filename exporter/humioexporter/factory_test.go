@@ -150,13 +150,110 @@ func TestCreateMetricsExporter(t *testing.T) {
 }
 
 func TestCreateLogsExporter(t *testing.T) {
+	// Arrange
 	factory := newHumioFactory(t)
-	lExp, err := factory.CreateLogsExporter(
-		context.Background(),
-		componenttest.NewNopExporterCreateSettings(),
-		factory.CreateDefaultConfig(),
-	)
+	testCases := []struct {
+		desc              string
+		cfg               config.Exporter
+		wantErrorOnCreate bool
+		wantErrorOnStart  bool
+	}{
+		{
+			desc: "Valid log configuration",
+			cfg: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Tag:              TagNone,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:8080",
+				},
+				Logs: LogsConfig{
+					IngestToken: "00000000-0000-0000-0000-0000000000000",
+					LogParser:   "custom-parser",
+				},
+			},
+			wantErrorOnCreate: false,
+		},
+		{
+			desc: "Unsanitizable log configuration",
+			cfg: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Tag:              TagNone,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "\n",
+				},
+			},
+			wantErrorOnCreate: true,
+		},
+		{
+			desc: "Missing ingest token",
+			cfg: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Tag:              TagNone,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:8080",
+				},
+				Traces: TracesConfig{
+					IngestToken: "00000000-0000-0000-0000-0000000000000",
+				},
+			},
+			wantErrorOnCreate: true,
+		},
+		{
+			desc:              "Default configuration",
+			cfg:               factory.CreateDefaultConfig(),
+			wantErrorOnCreate: true,
+		},
+		{
+			desc: "Invalid client configuration",
+			cfg: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				Tag:              TagNone,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:8080",
+					TLSSetting: configtls.TLSClientSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "",
+							KeyFile:  "key.key",
+						},
+					},
+				},
+				Logs: LogsConfig{
+					IngestToken: "00000000-0000-0000-0000-0000000000000",
+				},
+			},
+			wantErrorOnCreate: false,
+			wantErrorOnStart:  true,
+		},
+		{
+			desc:              "Missing configuration",
+			cfg:               nil,
+			wantErrorOnCreate: true,
+		},
+	}
 
-	require.Error(t, err)
-	assert.Nil(t, lExp)
+	// Act
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			exp, err := factory.CreateLogsExporter(
+				context.Background(),
+				componenttest.NewNopExporterCreateSettings(),
+				tC.cfg,
+			)
+
+			if (err != nil) != tC.wantErrorOnCreate {
+				t.Errorf("CreateLogsExporter() error = %v, wantErr %v", err, tC.wantErrorOnCreate)
+			}
+
+			if (err == nil) && (exp == nil) {
+				t.Error("No log exporter created despite no errors")
+			}
+
+			if exp != nil {
+				err = exp.Start(context.Background(), componenttest.NewNopHost())
+				if (err != nil) != tC.wantErrorOnStart {
+					t.Errorf("CreateLogsExporter() error = %v, wantErr %v", err, tC.wantErrorOnStart)
+				}
+			}
+		})
+	}
 }
@@ -96,6 +96,7 @@ func (e *HumioStructuredEvent) MarshalJSON() ([]byte, error) {
 type exporterClient interface {
 	sendUnstructuredEvents(context.Context, []*HumioUnstructuredEvents) error
 	sendStructuredEvents(context.Context, []*HumioStructuredEvents) error
+	sendStructuredLogEvents(context.Context, []*HumioStructuredEvents) error
 }
 
 // A concrete HTTP client for sending unstructured and structured events to Humio
@@ -133,6 +134,11 @@ func (h *humioClient) sendStructuredEvents(ctx context.Context, evts []*HumioStr
 	return h.sendEvents(ctx, evts, h.cfg.structuredEndpoint.String(), h.cfg.Traces.IngestToken)
 }
 
+// Send a payload of structured log events to the structured API, authorized with the ingest token for logs
+func (h *humioClient) sendStructuredLogEvents(ctx context.Context, evts []*HumioStructuredEvents) error {
+	return h.sendEvents(ctx, evts, h.cfg.structuredEndpoint.String(), h.cfg.Logs.IngestToken)
+}
+
 // Send a payload of generic events to the specified Humio API. This method should
 // never be called directly
 func (h *humioClient) sendEvents(ctx context.Context, evts interface{}, url string, token string) error {
@@ -225,6 +225,24 @@ func TestSendStructuredEventsUnix(t *testing.T) {
 	assert.Equal(t, expected, result.Body)
 }
 
+func TestSendStructuredLogEvents(t *testing.T) {
+	// Arrange
+	expected := `[{"tags":{"tag1":"tagval1","tag2":"tagval2"},"events":[{"timestamp":"2021-03-28T12:30:15+02:00","attributes":{"attr1":"attrval1","attr2":"attrval2"}}]},{"events":[{"timestamp":"2021-03-28T12:30:15+02:00"},{"timestamp":"2021-03-28T12:30:15+02:00"}]}]`
+	evts := makeStructuredEvents(false)
+
+	// Act
+	result := executeRequest(func(s *httptest.Server) error {
+		humio := makeClient(t, s.URL, false)
+		return humio.sendStructuredLogEvents(context.Background(), evts)
+	})
+
+	// Assert
+	require.NoError(t, result.Error)
+	assert.Contains(t, result.Header.Get("authorization"), "Bearer logs-token")
+	assert.Equal(t, "/api/v1/ingest/humio-structured", result.Path)
+	assert.Equal(t, expected, result.Body)
+}
+
 func TestSendEventsUncompressedHeaders(t *testing.T) {
 	// Arrange
 	evts := makeStructuredEvents(true)
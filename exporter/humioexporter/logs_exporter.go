@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+// HumioLog represents a log record as it is stored inside Humio
+type HumioLog struct {
+	TraceID     string                 `json:"trace_id,omitempty"`
+	SpanID      string                 `json:"span_id,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Severity    string                 `json:"severity,omitempty"`
+	ServiceName string                 `json:"service,omitempty"`
+	Body        interface{}            `json:"body,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type humioLogsExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	client exporterClient
+	wg     sync.WaitGroup
+
+	// Needed to enable current unit tests with the latest changes from core collector.
+	getClient clientGetter
+}
+
+func newLogsExporter(cfg *Config, logger *zap.Logger) *humioLogsExporter {
+	return newLogsExporterWithClientGetter(cfg, logger, newHumioClient)
+}
+
+func newLogsExporterWithClientGetter(cfg *Config, logger *zap.Logger, cg clientGetter) *humioLogsExporter {
+	return &humioLogsExporter{
+		cfg:       cfg,
+		logger:    logger,
+		getClient: cg,
+	}
+}
+
+func (e *humioLogsExporter) pushLogData(ctx context.Context, ld pdata.Logs) error {
+	e.wg.Add(1)
+	defer e.wg.Done()
+
+	evts := e.logsToHumioEvents(ld)
+
+	// When a custom parser is configured, the log messages are sent unstructured to let
+	// Humio parse them. Otherwise, the log records are sent as structured events
+	if e.cfg.Logs.LogParser != "" {
+		return e.client.sendUnstructuredEvents(ctx, e.toUnstructuredEvents(evts))
+	}
+
+	return e.client.sendStructuredLogEvents(ctx, evts)
+}
+
+func (e *humioLogsExporter) logsToHumioEvents(ld pdata.Logs) []*HumioStructuredEvents {
+	organizer := newTagOrganizer(e.cfg.Tag, tagFromLog)
+
+	resLogs := ld.ResourceLogs()
+	for i := 0; i < resLogs.Len(); i++ {
+		resLog := resLogs.At(i)
+		r := resLog.Resource()
+
+		instLogs := resLog.InstrumentationLibraryLogs()
+		for j := 0; j < instLogs.Len(); j++ {
+			instLog := instLogs.At(j)
+			lib := instLog.InstrumentationLibrary()
+
+			otelLogs := instLog.Logs()
+			for k := 0; k < otelLogs.Len(); k++ {
+				organizer.consume(e.logToHumioEvent(otelLogs.At(k), lib, r))
+			}
+		}
+	}
+
+	return organizer.asEvents()
+}
+
+func (e *humioLogsExporter) logToHumioEvent(lr pdata.LogRecord, inst pdata.InstrumentationLibrary, res pdata.Resource) *HumioStructuredEvent {
+	attr := toHumioAttributes(lr.Attributes(), res.Attributes())
+	if instName := inst.Name(); instName != "" {
+		attr[conventions.InstrumentationLibraryName] = instName
+	}
+	if instVer := inst.Version(); instVer != "" {
+		attr[conventions.InstrumentationLibraryVersion] = instVer
+	}
+
+	serviceName := ""
+	if sName, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+		// No need to store the service name in two places
+		delete(attr, conventions.AttributeServiceName)
+		serviceName = sName.StringVal()
+	}
+
+	severity := lr.SeverityText()
+	if severity == "" && lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+		severity = strings.TrimPrefix(lr.SeverityNumber().String(), "SEVERITY_NUMBER_")
+	}
+
+	// Humio requires a timestamp for every event, so fall back to the current
+	// time for log records without one
+	timestamp := time.Now()
+	if lr.Timestamp() != 0 {
+		timestamp = lr.Timestamp().AsTime()
+	}
+
+	humioLog := &HumioLog{
+		Name:        lr.Name(),
+		Severity:    severity,
+		ServiceName: serviceName,
+		Body:        toHumioAttributeValue(lr.Body()),
+		Attributes:  attr,
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		humioLog.TraceID = traceID.HexString()
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		humioLog.SpanID = spanID.HexString()
+	}
+
+	return &HumioStructuredEvent{
+		Timestamp:  timestamp,
+		Attributes: humioLog,
+	}
+}
+
+// Converts the structured events into unstructured events, keeping the tags of
+// each group and using the log body as the message to be parsed by Humio
+func (e *humioLogsExporter) toUnstructuredEvents(evts []*HumioStructuredEvents) []*HumioUnstructuredEvents {
+	results := make([]*HumioUnstructuredEvents, 0, len(evts))
+	for _, group := range evts {
+		messages := make([]string, 0, len(group.Events))
+		for _, evt := range group.Events {
+			messages = append(messages, logMessage(evt.Attributes.(*HumioLog).Body))
+		}
+
+		results = append(results, &HumioUnstructuredEvents{
+			Tags:     group.Tags,
+			Type:     e.cfg.Logs.LogParser,
+			Messages: messages,
+		})
+	}
+	return results
+}
+
+func logMessage(body interface{}) string {
+	switch b := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return b
+	}
+
+	// Structured bodies are serialized as JSON, which most parsers are able to handle
+	msg, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	return string(msg)
+}
+
+func tagFromLog(evt *HumioStructuredEvent, strategy Tagger) string {
+	switch strategy {
+	case TagTraceID:
+		return evt.Attributes.(*HumioLog).TraceID
+
+	case TagServiceName:
+		return evt.Attributes.(*HumioLog).ServiceName
+
+	default: // TagNone
+		return ""
+	}
+}
+
+func (e *humioLogsExporter) start(_ context.Context, host component.Host) error {
+	client, err := e.getClient(e.cfg, e.logger, host)
+	if err != nil {
+		return err
+	}
+
+	e.client = client
+
+	return nil
+}
+
+func (e *humioLogsExporter) shutdown(context.Context) error {
+	e.wg.Wait()
+	return nil
+}
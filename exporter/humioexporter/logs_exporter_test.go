@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+// Implement a mock of the client interface recording the events sent
+type recordingClientMock struct {
+	unstructured [][]*HumioUnstructuredEvents
+	structured   [][]*HumioStructuredEvents
+}
+
+func (m *recordingClientMock) sendUnstructuredEvents(ctx context.Context, evts []*HumioUnstructuredEvents) error {
+	m.unstructured = append(m.unstructured, evts)
+	return nil
+}
+
+func (m *recordingClientMock) sendStructuredEvents(ctx context.Context, evts []*HumioStructuredEvents) error {
+	return errors.New("logs must not be sent with the traces ingest token")
+}
+
+func (m *recordingClientMock) sendStructuredLogEvents(ctx context.Context, evts []*HumioStructuredEvents) error {
+	m.structured = append(m.structured, evts)
+	return nil
+}
+
+func newTestLogsExporter(t *testing.T, cfg *Config, client exporterClient) *humioLogsExporter {
+	cg := func(cfg *Config, logger *zap.Logger, host component.Host) (exporterClient, error) {
+		return client, nil
+	}
+	exp := newLogsExporterWithClientGetter(cfg, zap.NewNop(), cg)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func makeLogs() pdata.Logs {
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(conventions.AttributeServiceName, "service-A")
+	lrs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	lrs.AppendEmpty().Body().SetStringVal("first message")
+	lrs.AppendEmpty().Body().SetStringVal("second message")
+	return logs
+}
+
+func TestPushLogData(t *testing.T) {
+	// Arrange
+	testCases := []struct {
+		desc     string
+		client   exporterClient
+		wantErr  bool
+		wantPerm bool
+	}{
+		{
+			desc: "Valid request",
+			client: &clientMock{
+				response: func() error {
+					return nil
+				},
+			},
+			wantErr:  false,
+			wantPerm: false,
+		},
+		{
+			desc: "Forwards transient errors",
+			client: &clientMock{
+				response: func() error {
+					return errors.New("Error")
+				},
+			},
+			wantErr:  true,
+			wantPerm: false,
+		},
+		{
+			desc: "Forwards permanent errors",
+			client: &clientMock{
+				response: func() error {
+					return consumererror.Permanent(errors.New("Error"))
+				},
+			},
+			wantErr:  true,
+			wantPerm: true,
+		},
+	}
+
+	// Act
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			exp := newTestLogsExporter(t, &Config{}, tC.client)
+
+			err := exp.pushLogData(context.Background(), makeLogs())
+
+			// Assert
+			if (err != nil) != tC.wantErr {
+				t.Errorf("pushLogData() error = %v, wantErr %v", err, tC.wantErr)
+			}
+
+			if consumererror.IsPermanent(err) != tC.wantPerm {
+				t.Errorf("pushLogData() permanent = %v, wantPerm %v",
+					consumererror.IsPermanent(err), tC.wantPerm)
+			}
+		})
+	}
+}
+
+func TestPushLogData_Structured(t *testing.T) {
+	// Arrange
+	client := &recordingClientMock{}
+	exp := newTestLogsExporter(t, &Config{Tag: TagNone}, client)
+
+	// Act
+	err := exp.pushLogData(context.Background(), makeLogs())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, client.unstructured)
+	require.Len(t, client.structured, 1)
+	require.Len(t, client.structured[0], 1)
+	evts := client.structured[0][0].Events
+	require.Len(t, evts, 2)
+	assert.Equal(t, "first message", evts[0].Attributes.(*HumioLog).Body)
+	assert.Equal(t, "second message", evts[1].Attributes.(*HumioLog).Body)
+}
+
+func TestPushLogData_UnstructuredWithParser(t *testing.T) {
+	// Arrange
+	client := &recordingClientMock{}
+	exp := newTestLogsExporter(t, &Config{
+		Tag: TagServiceName,
+		Logs: LogsConfig{
+			LogParser: "custom-parser",
+		},
+	}, client)
+
+	// Act
+	err := exp.pushLogData(context.Background(), makeLogs())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, client.structured)
+	require.Len(t, client.unstructured, 1)
+	assert.Equal(t, []*HumioUnstructuredEvents{
+		{
+			Tags:     map[string]string{string(TagServiceName): "service-A"},
+			Type:     "custom-parser",
+			Messages: []string{"first message", "second message"},
+		},
+	}, client.unstructured[0])
+}
+
+func TestLogsToHumioEvents_OrganizedByTags(t *testing.T) {
+	// Arrange
+	logs := pdata.NewLogs()
+
+	// Three log records for the same trace across two different resources, as
+	// well as a log record from a separate trace and one without a trace
+	res1 := logs.ResourceLogs().AppendEmpty()
+	res1.Resource().Attributes().InsertString(conventions.AttributeServiceName, "service-A")
+	ill1 := res1.InstrumentationLibraryLogs().AppendEmpty()
+	ill1.Logs().AppendEmpty().SetTraceID(pdata.NewTraceID(createTraceID("10000000000000000000000000000000")))
+	ill1.Logs().AppendEmpty().SetTraceID(pdata.NewTraceID(createTraceID("10000000000000000000000000000000")))
+
+	res2 := logs.ResourceLogs().AppendEmpty()
+	ill2 := res2.InstrumentationLibraryLogs().AppendEmpty()
+	ill2.Logs().AppendEmpty().SetTraceID(pdata.NewTraceID(createTraceID("10000000000000000000000000000000")))
+	ill2.Logs().AppendEmpty().SetTraceID(pdata.NewTraceID(createTraceID("20000000000000000000000000000000")))
+	ill2.Logs().AppendEmpty()
+
+	exp := newTestLogsExporter(t, &Config{Tag: TagTraceID}, &clientMock{})
+
+	// Act
+	actual := exp.logsToHumioEvents(logs)
+
+	// Assert
+	assert.Len(t, actual, 3)
+	for _, group := range actual {
+		switch group.Tags[string(TagTraceID)] {
+		case "10000000000000000000000000000000":
+			assert.Len(t, group.Events, 3)
+		case "20000000000000000000000000000000":
+			assert.Len(t, group.Events, 1)
+		default:
+			// Log records without a trace id are not tagged
+			assert.Nil(t, group.Tags)
+			assert.Len(t, group.Events, 1)
+		}
+	}
+}
+
+func TestLogToHumioEvent(t *testing.T) {
+	// Arrange
+	lr := pdata.NewLogRecord()
+	lr.SetTimestamp(pdata.TimestampFromTime(
+		time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+	))
+	lr.SetTraceID(pdata.NewTraceID(createTraceID("10")))
+	lr.SetSpanID(pdata.NewSpanID(createSpanID("20")))
+	lr.SetName("log")
+	lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	lr.Body().SetStringVal("something happened")
+	lr.Attributes().InsertString("key", "val")
+
+	inst := pdata.NewInstrumentationLibrary()
+	inst.SetName("otel-test")
+	inst.SetVersion("1.0.0")
+
+	res := pdata.NewResource()
+	res.Attributes().InsertString("service.name", "myapp")
+	res.Attributes().InsertString("host.name", "myhost")
+
+	expected := &HumioStructuredEvent{
+		Timestamp: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		Attributes: &HumioLog{
+			TraceID:     "10000000000000000000000000000000",
+			SpanID:      "2000000000000000",
+			Name:        "log",
+			Severity:    "WARN",
+			ServiceName: "myapp",
+			Body:        "something happened",
+			Attributes: map[string]interface{}{
+				"key":                  "val",
+				"host.name":            "myhost",
+				"otel.library.name":    "otel-test",
+				"otel.library.version": "1.0.0",
+			},
+		},
+	}
+
+	exp := newTestLogsExporter(t, &Config{}, &clientMock{})
+
+	// Act
+	actual := exp.logToHumioEvent(lr, inst, res)
+
+	// Assert
+	assert.Equal(t, expected, actual)
+}
+
+func TestLogToHumioEvent_MissingTimestamp(t *testing.T) {
+	// Arrange
+	exp := newTestLogsExporter(t, &Config{}, &clientMock{})
+	before := time.Now()
+
+	// Act
+	actual := exp.logToHumioEvent(pdata.NewLogRecord(), pdata.NewInstrumentationLibrary(), pdata.NewResource())
+
+	// Assert
+	assert.False(t, actual.Timestamp.Before(before))
+	humioLog := actual.Attributes.(*HumioLog)
+	assert.Empty(t, humioLog.TraceID)
+	assert.Empty(t, humioLog.SpanID)
+	assert.Empty(t, humioLog.Severity)
+	assert.Nil(t, humioLog.Body)
+}
+
+func TestLogMessage(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		body     interface{}
+		expected string
+	}{
+		{
+			desc:     "Missing body",
+			body:     nil,
+			expected: "",
+		},
+		{
+			desc:     "String body",
+			body:     "msg",
+			expected: "msg",
+		},
+		{
+			desc:     "Structured body",
+			body:     map[string]interface{}{"key": "val", "num": int64(1)},
+			expected: `{"key":"val","num":1}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, logMessage(tC.body))
+		})
+	}
+}
@@ -58,6 +58,10 @@ func (m *clientMock) sendStructuredEvents(ctx context.Context, evts []*HumioStru
 	return m.response()
 }
 
+func (m *clientMock) sendStructuredLogEvents(ctx context.Context, evts []*HumioStructuredEvents) error {
+	return m.response()
+}
+
 func TestPushTraceData(t *testing.T) {
 	// Arrange
 	testCases := []struct {
@@ -17,6 +17,15 @@ package loadbalancingexporter
 import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// traceIDRouting routes the data based on the trace ID
+	traceIDRouting = "traceID"
+	// serviceRouting routes the data based on the service name of the resource
+	serviceRouting = "service"
 )
 
 // Config defines configuration for the exporter.
@@ -24,6 +33,10 @@ type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
+
+	// RoutingKey determines which value is used to select the backend: "traceID", "service",
+	// or the name of a resource attribute. Metrics can't be routed by trace ID.
+	RoutingKey string `mapstructure:"routing_key"`
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -35,6 +48,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -47,3 +61,13 @@ type DNSResolver struct {
 	Hostname string `mapstructure:"hostname"`
 	Port     string `mapstructure:"port"`
 }
+
+// K8sSvcResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sSvcResolver struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// Service is the name of the service to watch, optionally followed by its namespace: "name.namespace".
+	// The "default" namespace is used when none is specified.
+	Service string `mapstructure:"service"`
+	Port    string `mapstructure:"port"`
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
 )
 
@@ -33,4 +34,10 @@ func TestLoadConfig(t *testing.T) {
 	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
+
+	assert.Equal(t, traceIDRouting, cfg.Exporters[config.NewID(typeStr)].(*Config).RoutingKey)
+	assert.Equal(t, serviceRouting, cfg.Exporters[config.NewIDWithName(typeStr, "4")].(*Config).RoutingKey)
+	assert.Equal(t,
+		&K8sSvcResolver{Service: "lb-svc.observability", Port: "55690"},
+		cfg.Exporters[config.NewIDWithName(typeStr, "5")].(*Config).Resolver.K8sSvc)
 }
@@ -38,6 +38,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		exporterhelper.WithTraces(createTracesExporter),
 		exporterhelper.WithLogs(createLogExporter),
+		exporterhelper.WithMetrics(createMetricExporter),
 	)
 }
 
@@ -50,6 +51,7 @@ func createDefaultConfig() config.Exporter {
 		Protocol: Protocol{
 			OTLP: *otlpDefaultCfg,
 		},
+		RoutingKey: traceIDRouting,
 	}
 }
 
@@ -60,3 +62,7 @@ func createTracesExporter(_ context.Context, params component.ExporterCreateSett
 func createLogExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
 	return newLogsExporter(params, cfg)
 }
+
+func createMetricExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	return newMetricsExporter(params, cfg)
+}
@@ -60,3 +60,23 @@ func TestLogExporterGetsCreatedWithValidConfiguration(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, exp)
 }
+
+func TestMetricExporterGetsCreatedWithValidConfiguration(t *testing.T) {
+	// prepare
+	factory := NewFactory()
+	creationParams := componenttest.NewNopExporterCreateSettings()
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+		RoutingKey: serviceRouting,
+	}
+
+	// test
+	exp, err := factory.CreateMetricsExporter(context.Background(), creationParams, cfg)
+
+	// verify
+	assert.Nil(t, err)
+	assert.NotNil(t, exp)
+}
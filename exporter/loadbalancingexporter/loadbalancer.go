@@ -18,13 +18,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -42,7 +46,7 @@ type componentFactory func(ctx context.Context, endpoint string) (component.Expo
 
 type loadBalancer interface {
 	component.Component
-	Endpoint(traceID pdata.TraceID) string
+	Endpoint(identifier []byte) string
 	Exporter(endpoint string) (component.Exporter, error)
 }
 
@@ -64,7 +68,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporter, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -86,6 +90,24 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		}
 	}
 
+	if oCfg.Resolver.K8sSvc != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s"))
+
+		apiCfg := oCfg.Resolver.K8sSvc.APIConfig
+		if apiCfg.AuthType == "" {
+			apiCfg.AuthType = k8sconfig.AuthTypeServiceAccount
+		}
+		clt, err := k8sconfig.MakeClient(apiCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = newK8sResolver(clt, k8sLogger, oCfg.Resolver.K8sSvc.Service, oCfg.Resolver.K8sSvc.Port)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -98,6 +120,20 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 	}, nil
 }
 
+func countResolvers(cfg ResolverSettings) int {
+	count := 0
+	if cfg.Static != nil {
+		count++
+	}
+	if cfg.DNS != nil {
+		count++
+	}
+	if cfg.K8sSvc != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
@@ -143,8 +179,9 @@ func (lb *loadBalancerImp) addMissingExporters(ctx context.Context, endpoints []
 }
 
 func endpointWithPort(endpoint string) string {
-	if !strings.Contains(endpoint, ":") {
-		endpoint = fmt.Sprintf("%s:%s", endpoint, defaultPort)
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		// no port in the endpoint, IPv6 addresses may be enclosed in brackets
+		endpoint = net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(endpoint, "["), "]"), defaultPort)
 	}
 	return endpoint
 }
@@ -168,16 +205,16 @@ func endpointFound(endpoint string, endpoints []string) bool {
 	return false
 }
 
-func (lb *loadBalancerImp) Shutdown(context.Context) error {
+func (lb *loadBalancerImp) Shutdown(ctx context.Context) error {
 	lb.stopped = true
-	return nil
+	return lb.res.shutdown(ctx)
 }
 
-func (lb *loadBalancerImp) Endpoint(traceID pdata.TraceID) string {
+func (lb *loadBalancerImp) Endpoint(identifier []byte) string {
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
 
-	return lb.ring.endpointFor(traceID)
+	return lb.ring.endpointFor(identifier)
 }
 
 func (lb *loadBalancerImp) Exporter(endpoint string) (component.Exporter, error) {
@@ -194,3 +231,28 @@ func (lb *loadBalancerImp) Exporter(endpoint string) (component.Exporter, error)
 
 	return exp, nil
 }
+
+// consumeByResource groups the n resources of a batch by the endpoint responsible for their routing key,
+// and calls consume once per endpoint with the indexes of the resources routed to it
+func consumeByResource(
+	lb loadBalancer,
+	routingKey string,
+	n int,
+	resourceAt func(i int) pdata.Resource,
+	consume func(endpoint string, indexes []int) error,
+) error {
+	indexesByEndpoint := map[string][]int{}
+	for i := 0; i < n; i++ {
+		endpoint := lb.Endpoint(resourceIdentifier(routingKey, resourceAt(i)))
+		indexesByEndpoint[endpoint] = append(indexesByEndpoint[endpoint], i)
+	}
+
+	var errors []error
+	for endpoint, indexes := range indexesByEndpoint {
+		if err := consume(endpoint, indexes); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	return consumererror.Combine(errors)
+}
@@ -28,6 +28,8 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -76,6 +78,25 @@ func TestNewLoadBalancerInvalidDNSResolver(t *testing.T) {
 	require.Equal(t, errNoHostname, err)
 }
 
+func TestNewLoadBalancerInvalidK8sResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				APIConfig: k8sconfig.APIConfig{AuthType: "invalid"},
+				Service:   "lb",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	require.Nil(t, p)
+	require.EqualError(t, err, "invalid authType for kubernetes: invalid")
+}
+
 func TestLoadBalancerStart(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -132,6 +153,26 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestMultipleResolversWithK8s(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			DNS: &DNSResolver{
+				Hostname: "service-1",
+			},
+			K8sSvc: &K8sSvcResolver{
+				Service: "lb",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -308,6 +349,18 @@ func TestEndpointWithPort(t *testing.T) {
 			"endpoint-1:55690",
 			"endpoint-1:55690",
 		},
+		{
+			"fd00::1",
+			"[fd00::1]:4317",
+		},
+		{
+			"[fd00::1]",
+			"[fd00::1]:4317",
+		},
+		{
+			"[fd00::1]:55690",
+			"[fd00::1]:55690",
+		},
 	} {
 		assert.Equal(t, tt.expected, endpointWithPort(tt.input))
 	}
@@ -343,7 +396,8 @@ func TestFailedExporterInRing(t *testing.T) {
 
 	// test
 	// this trace ID will reach the endpoint-2 -- see the consistent hashing tests for more info
-	_, err = p.Exporter(p.Endpoint(pdata.NewTraceID([16]byte{128, 128, 0, 0})))
+	traceID := pdata.NewTraceID([16]byte{128, 128, 0, 0}).Bytes()
+	_, err = p.Exporter(p.Endpoint(traceID[:]))
 
 	// verify
 	assert.Error(t, err)
@@ -40,6 +40,7 @@ type logExporterImp struct {
 	logger *zap.Logger
 
 	loadBalancer loadBalancer
+	routingKey   string
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -65,6 +66,7 @@ func newLogsExporter(params component.ExporterCreateSettings, cfg config.Exporte
 	return &logExporterImp{
 		logger:       params.Logger,
 		loadBalancer: loadBalancer,
+		routingKey:   routingKeyFromConfig(cfg.(*Config)),
 	}, nil
 }
 
@@ -76,13 +78,17 @@ func (e *logExporterImp) Start(ctx context.Context, host component.Host) error {
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *logExporterImp) Shutdown(context.Context) error {
+func (e *logExporterImp) Shutdown(ctx context.Context) error {
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if e.routingKey != traceIDRouting {
+		return e.consumeByResource(ctx, ld)
+	}
+
 	var errors []error
 	batches := batchpersignal.SplitLogs(ld)
 	for _, batch := range batches {
@@ -104,7 +110,25 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld pdata.Logs) error {
 		balancingKey = random()
 	}
 
-	endpoint := e.loadBalancer.Endpoint(balancingKey)
+	b := balancingKey.Bytes()
+	return e.consumeWithEndpoint(ctx, e.loadBalancer.Endpoint(b[:]), ld)
+}
+
+// consumeByResource sends the resource logs to the endpoints responsible for their routing key
+func (e *logExporterImp) consumeByResource(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	return consumeByResource(e.loadBalancer, e.routingKey, rls.Len(),
+		func(i int) pdata.Resource { return rls.At(i).Resource() },
+		func(endpoint string, indexes []int) error {
+			batch := pdata.NewLogs()
+			for _, i := range indexes {
+				rls.At(i).CopyTo(batch.ResourceLogs().AppendEmpty())
+			}
+			return e.consumeWithEndpoint(ctx, endpoint, batch)
+		})
+}
+
+func (e *logExporterImp) consumeWithEndpoint(ctx context.Context, endpoint string, ld pdata.Logs) error {
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
@@ -258,6 +258,55 @@ func TestLogBatchWithTwoTraces(t *testing.T) {
 	assert.Len(t, sink.AllLogs(), 2)
 }
 
+func TestLogsRoutedByResourceAttribute(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockLogsExporter(), nil
+	}
+	cfg := simpleConfig()
+	cfg.RoutingKey = "tenant"
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2"}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newLogsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load the exporters here, so that we don't use the actual OTLP exporter
+	sinks := map[string]*consumertest.LogsSink{
+		"endpoint-1": new(consumertest.LogsSink),
+		"endpoint-2": new(consumertest.LogsSink),
+	}
+	for endpoint, sink := range sinks {
+		lb.exporters[endpoint] = newMockLogsExporter(sink.ConsumeLogs)
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	ld := simpleLogWithoutID()
+	ld.ResourceLogs().At(0).Resource().Attributes().InsertString("tenant", "acme")
+
+	// test
+	for i := 0; i < 10; i++ {
+		err = p.ConsumeLogs(context.Background(), ld)
+		require.NoError(t, err)
+	}
+
+	// verify: all the logs from the same tenant reach the same backend
+	expected := lb.Endpoint([]byte("acme"))
+	for endpoint, sink := range sinks {
+		if endpoint == expected {
+			assert.Len(t, sink.AllLogs(), 10)
+		} else {
+			assert.Len(t, sink.AllLogs(), 0)
+		}
+	}
+}
+
 func TestNoLogsInBatch(t *testing.T) {
 	for _, tt := range []struct {
 		desc  string
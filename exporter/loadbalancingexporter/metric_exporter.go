@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+var (
+	errTraceIDRoutingForMetrics = errors.New("metrics can't be routed by trace ID, use \"service\" or a resource attribute as the routing_key")
+)
+
+type metricExporterImp struct {
+	logger *zap.Logger
+
+	loadBalancer loadBalancer
+	routingKey   string
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	routingKey := routingKeyFromConfig(cfg.(*Config))
+	if routingKey == traceIDRouting {
+		return nil, errTraceIDRoutingForMetrics
+	}
+
+	exporterFactory := otlpexporter.NewFactory()
+
+	tmplParams := component.ExporterCreateSettings{
+		Logger:    params.Logger,
+		BuildInfo: params.BuildInfo,
+	}
+
+	loadBalancer, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, tmplParams, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricExporterImp{
+		logger:       params.Logger,
+		loadBalancer: loadBalancer,
+		routingKey:   routingKey,
+	}, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(ctx context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return e.loadBalancer.Shutdown(ctx)
+}
+
+// ConsumeMetrics sends the resource metrics to the endpoints responsible for their routing key
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	rms := md.ResourceMetrics()
+	return consumeByResource(e.loadBalancer, e.routingKey, rms.Len(),
+		func(i int) pdata.Resource { return rms.At(i).Resource() },
+		func(endpoint string, indexes []int) error {
+			batch := pdata.NewMetrics()
+			for _, i := range indexes {
+				rms.At(i).CopyTo(batch.ResourceMetrics().AppendEmpty())
+			}
+			return e.consumeWithEndpoint(ctx, endpoint, batch)
+		})
+}
+
+func (e *metricExporterImp) consumeWithEndpoint(ctx context.Context, endpoint string, md pdata.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+
+	if err == nil {
+		sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
+		stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			serviceRoutingConfig(),
+			nil,
+		},
+		{
+			"default routing key",
+			simpleConfig(),
+			errTraceIDRoutingForMetrics,
+		},
+		{
+			"empty",
+			&Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				RoutingKey:       serviceRouting,
+			},
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockMetricsExporter(), nil
+	}
+	cfg := serviceRoutingConfig()
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2"}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load the exporters here, so that we don't use the actual OTLP exporter
+	sinks := map[string]*consumertest.MetricsSink{
+		"endpoint-1": new(consumertest.MetricsSink),
+		"endpoint-2": new(consumertest.MetricsSink),
+	}
+	for endpoint, sink := range sinks {
+		lb.exporters[endpoint] = newMockMetricsExporter(sink.ConsumeMetrics)
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	services := []string{"service-a", "service-b", "service-c", "service-a"}
+	md := pdata.NewMetrics()
+	for _, service := range services {
+		simpleMetricsWithService(service).ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+	}
+
+	// test
+	err = p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	require.NoError(t, err)
+
+	received := map[string][]string{}
+	for endpoint, sink := range sinks {
+		for _, batch := range sink.AllMetrics() {
+			rms := batch.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				service, _ := rms.At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+				received[endpoint] = append(received[endpoint], service.StringVal())
+			}
+		}
+		// a single batch is sent per endpoint
+		assert.LessOrEqual(t, len(sink.AllMetrics()), 1)
+	}
+
+	total := 0
+	for endpoint, svcs := range received {
+		for _, service := range svcs {
+			assert.Equal(t, lb.Endpoint([]byte(service)), endpoint)
+		}
+		total += len(svcs)
+	}
+	assert.Equal(t, len(services), total)
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), serviceRoutingConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), serviceRoutingConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockExporter()
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetricsWithService("service-a"))
+
+	// verify
+	assert.Error(t, res)
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func serviceRoutingConfig() *Config {
+	cfg := simpleConfig()
+	cfg.RoutingKey = serviceRouting
+	return cfg
+}
+
+func simpleMetricsWithService(service string) pdata.Metrics {
+	metrics := pdata.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+	rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	return metrics
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	ConsumeMetricsFn func(ctx context.Context, md pdata.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pdata.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        componenthelper.New(),
+		ConsumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func newNopMockMetricsExporter() component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component: componenthelper.New(),
+		ConsumeMetricsFn: func(ctx context.Context, md pdata.Metrics) error {
+			return nil
+		},
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if e.ConsumeMetricsFn == nil {
+		return nil
+	}
+	return e.ConsumeMetricsFn(ctx, md)
+}
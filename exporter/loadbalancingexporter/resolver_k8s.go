@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace       = "default"
+	defaultK8sInitialSyncTime = 10 * time.Second
+)
+
+var (
+	errNoSvc              = errors.New("no service specified to resolve the backends")
+	errInitialSyncTimeout = errors.New("timed out waiting for the initial list of endpoints of the service")
+)
+
+// k8sResolver watches the Endpoints object of a Kubernetes service, updating the list of backends
+// as soon as the pods backing the service change.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName         string
+	svcNs           string
+	port            string
+	informer        cache.SharedInformer
+	initialSyncTime time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+// newK8sResolver creates a resolver for the given service, in the form "name" or "name.namespace"
+func newK8sResolver(clt kubernetes.Interface, logger *zap.Logger, service string, port string) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	nAddr := strings.SplitN(service, ".", 2)
+	name, namespace := nAddr[0], defaultK8sNamespace
+	if len(nAddr) > 1 && nAddr[1] != "" {
+		namespace = nAddr[1]
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	epsListWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return clt.CoreV1().Endpoints(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return clt.CoreV1().Endpoints(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	r := &k8sResolver{
+		logger:          logger,
+		svcName:         name,
+		svcNs:           namespace,
+		port:            port,
+		informer:        cache.NewSharedInformer(epsListWatcher, &v1.Endpoints{}, 0),
+		initialSyncTime: defaultK8sInitialSyncTime,
+		stopCh:          make(chan struct{}),
+	}
+
+	// every change to the Endpoints object triggers a new resolution
+	r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { r.resolveOnEvent() },
+		UpdateFunc: func(interface{}, interface{}) { r.resolveOnEvent() },
+		DeleteFunc: func(interface{}) { r.resolveOnEvent() },
+	})
+
+	return r, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	go r.informer.Run(r.stopCh)
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.initialSyncTime)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), r.informer.HasSynced) {
+		return errInitialSyncTimeout
+	}
+
+	_, err := r.resolve(ctx)
+	return err
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) resolveOnEvent() {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	// the context to use for all metrics in this function
+	mCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("resolver"), "k8s"))
+
+	obj, exists, err := r.informer.GetStore().GetByKey(r.svcNs + "/" + r.svcName)
+	if err != nil {
+		failedCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(failedCtx, mNumResolutions.M(1))
+		return nil, err
+	}
+
+	// from this point, we don't fail anymore
+	successCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "true"))
+	stats.Record(successCtx, mNumResolutions.M(1))
+
+	var backends []string
+	if eps, ok := obj.(*v1.Endpoints); exists && ok {
+		backends = r.backendsFor(eps)
+	}
+
+	// resolutions are triggered both by the informer events and by the start, the lock
+	// makes sure that the callbacks are invoked in the same order as the list changes
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+	if equalStringSlice(r.endpoints, backends) {
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	stats.Record(mCtx, mNumBackends.M(int64(len(backends))))
+
+	if len(backends) == 0 {
+		r.logger.Warn("no ready endpoints found for the service", zap.String("service", r.svcName), zap.String("namespace", r.svcNs))
+	}
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+// backendsFor returns the sorted list of ready addresses of the given Endpoints object
+func (r *k8sResolver) backendsFor(eps *v1.Endpoints) []string {
+	var backends []string
+	seen := map[string]bool{}
+	for _, subset := range eps.Subsets {
+		for _, addr := range subset.Addresses {
+			backend := addr.IP
+
+			// if a port is specified in the configuration, add it
+			if r.port != "" {
+				backend = net.JoinHostPort(backend, r.port)
+			}
+
+			if !seen[backend] {
+				seen[backend] = true
+				backends = append(backends, backend)
+			}
+		}
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+	return backends
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewK8sResolver(t *testing.T) {
+	for _, tt := range []struct {
+		service   string
+		name      string
+		namespace string
+		err       error
+	}{
+		{"lb", "lb", "default", nil},
+		{"lb.observability", "lb", "observability", nil},
+		{"lb.", "lb", "default", nil},
+		{"", "", "", errNoSvc},
+	} {
+		t.Run(tt.service, func(t *testing.T) {
+			// test
+			res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), tt.service, "")
+
+			// verify
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.name, res.svcName)
+				assert.Equal(t, tt.namespace, res.svcNs)
+			}
+		})
+	}
+}
+
+func TestInitialK8sResolution(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newEndpoints("lb", "observability", []string{"10.0.0.2", "10.0.0.1", "fd00::1"}, []string{"10.0.0.3"}))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.observability", "55690")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+
+	// test
+	err = res.start(context.Background())
+	require.NoError(t, err)
+	defer res.shutdown(context.Background())
+
+	// verify: only the ready addresses are used, in a stable order
+	expected := []string{"10.0.0.1:55690", "10.0.0.2:55690", "[fd00::1]:55690"}
+	mu.Lock()
+	assert.Equal(t, expected, resolved)
+	mu.Unlock()
+}
+
+func TestK8sResolverIPv6WithoutPort(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newEndpoints("lb", "default", []string{"fd00::2", "10.0.0.1", "fd00::1"}, nil))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", "")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+
+	// test
+	err = res.start(context.Background())
+	require.NoError(t, err)
+	defer res.shutdown(context.Background())
+
+	// verify: the default port is added to the IPv6 addresses as well
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1", "fd00::2"}, resolved)
+	var endpoints []string
+	for _, endpoint := range resolved {
+		endpoints = append(endpoints, endpointWithPort(endpoint))
+	}
+	assert.Equal(t, []string{"10.0.0.1:4317", "[fd00::1]:4317", "[fd00::2]:4317"}, endpoints)
+}
+
+func TestK8sResolverOnChanges(t *testing.T) {
+	// prepare
+	eps := newEndpoints("lb", "default", []string{"10.0.0.1", "10.0.0.2"}, nil)
+	clt := fake.NewSimpleClientset(eps)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", "")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	latest := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+	require.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, latest())
+
+	// test: a pod is replaced by a new one
+	updated := newEndpoints("lb", "default", []string{"10.0.0.2", "10.0.0.3"}, nil)
+	_, err = clt.CoreV1().Endpoints("default").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.2", "10.0.0.3"}, latest())
+	}, time.Second, 10*time.Millisecond)
+
+	// test: the service is removed
+	err = clt.CoreV1().Endpoints("default").Delete(context.Background(), "lb", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(latest()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolverIgnoresOtherServices(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(
+		newEndpoints("lb", "default", []string{"10.0.0.1"}, nil),
+		newEndpoints("other", "default", []string{"10.0.0.9"}, nil),
+	)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", "")
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	res.updateLock.Lock()
+	assert.Equal(t, []string{"10.0.0.1"}, res.endpoints)
+	res.updateLock.Unlock()
+}
+
+func TestK8sResolverInitialSyncTimeout(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// test
+	err = res.start(ctx)
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, errInitialSyncTimeout, err)
+}
+
+func TestK8sResolverShutdownClearsCallbacks(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", "")
+	require.NoError(t, err)
+	res.onChange(func(s []string) {})
+
+	// test
+	err = res.shutdown(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, res.onChangeCallbacks, 0)
+}
+
+func newEndpoints(name, namespace string, ready []string, notReady []string) *v1.Endpoints {
+	subset := v1.EndpointSubset{}
+	for _, ip := range ready {
+		subset.Addresses = append(subset.Addresses, v1.EndpointAddress{IP: ip})
+	}
+	for _, ip := range notReady {
+		subset.NotReadyAddresses = append(subset.NotReadyAddresses, v1.EndpointAddress{IP: ip})
+	}
+
+	return &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []v1.EndpointSubset{subset},
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// routingKeyFromConfig returns the routing key from the configuration, defaulting to the trace ID
+func routingKeyFromConfig(cfg *Config) string {
+	if cfg.RoutingKey == "" {
+		return traceIDRouting
+	}
+	return cfg.RoutingKey
+}
+
+// resourceIdentifier returns the value of the routing key for the given resource, to be fed to the hash ring.
+// Resources without the routing key get a random identifier, so that their data is spread among the backends.
+func resourceIdentifier(routingKey string, resource pdata.Resource) []byte {
+	attrKey := routingKey
+	if routingKey == serviceRouting {
+		attrKey = conventions.AttributeServiceName
+	}
+
+	if attr, found := resource.Attributes().Get(attrKey); found {
+		if value := tracetranslator.AttributeValueToString(attr); value != "" {
+			return []byte(value)
+		}
+	}
+
+	id := random().Bytes()
+	return id[:]
+}
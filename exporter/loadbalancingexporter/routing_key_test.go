@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestRoutingKeyFromConfig(t *testing.T) {
+	assert.Equal(t, traceIDRouting, routingKeyFromConfig(&Config{}))
+	assert.Equal(t, serviceRouting, routingKeyFromConfig(&Config{RoutingKey: serviceRouting}))
+	assert.Equal(t, "k8s.pod.name", routingKeyFromConfig(&Config{RoutingKey: "k8s.pod.name"}))
+}
+
+func TestResourceIdentifier(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(conventions.AttributeServiceName, "service-a")
+	resource.Attributes().InsertInt("shard", 42)
+
+	assert.Equal(t, []byte("service-a"), resourceIdentifier(serviceRouting, resource))
+	assert.Equal(t, []byte("42"), resourceIdentifier("shard", resource))
+
+	// resources without the attribute get a random identifier
+	id := resourceIdentifier("k8s.pod.name", resource)
+	assert.Len(t, id, 16)
+	assert.Len(t, resourceIdentifier(serviceRouting, pdata.NewResource()), 16)
+}
@@ -44,6 +44,7 @@ type traceExporterImp struct {
 	logger *zap.Logger
 
 	loadBalancer loadBalancer
+	routingKey   string
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -69,6 +70,7 @@ func newTracesExporter(params component.ExporterCreateSettings, cfg config.Expor
 	return &traceExporterImp{
 		logger:       params.Logger,
 		loadBalancer: loadBalancer,
+		routingKey:   routingKeyFromConfig(cfg.(*Config)),
 	}, nil
 }
 
@@ -87,13 +89,17 @@ func (e *traceExporterImp) Start(ctx context.Context, host component.Host) error
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *traceExporterImp) Shutdown(context.Context) error {
+func (e *traceExporterImp) Shutdown(ctx context.Context) error {
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if e.routingKey != traceIDRouting {
+		return e.consumeByResource(ctx, td)
+	}
+
 	var errors []error
 	batches := batchpersignal.SplitTraces(td)
 	for _, batch := range batches {
@@ -111,7 +117,25 @@ func (e *traceExporterImp) consumeTrace(ctx context.Context, td pdata.Traces) er
 		return errNoTracesInBatch
 	}
 
-	endpoint := e.loadBalancer.Endpoint(traceID)
+	b := traceID.Bytes()
+	return e.consumeWithEndpoint(ctx, e.loadBalancer.Endpoint(b[:]), td)
+}
+
+// consumeByResource sends the resource spans to the endpoints responsible for their routing key
+func (e *traceExporterImp) consumeByResource(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	return consumeByResource(e.loadBalancer, e.routingKey, rss.Len(),
+		func(i int) pdata.Resource { return rss.At(i).Resource() },
+		func(endpoint string, indexes []int) error {
+			batch := pdata.NewTraces()
+			for _, i := range indexes {
+				rss.At(i).CopyTo(batch.ResourceSpans().AppendEmpty())
+			}
+			return e.consumeWithEndpoint(ctx, endpoint, batch)
+		})
+}
+
+func (e *traceExporterImp) consumeWithEndpoint(ctx context.Context, endpoint string, td pdata.Traces) error {
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
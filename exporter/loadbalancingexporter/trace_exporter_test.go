@@ -36,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 )
 
@@ -284,6 +285,45 @@ func TestBatchWithTwoTraces(t *testing.T) {
 	assert.Len(t, sink.AllTraces(), 2)
 }
 
+func TestBatchWithTwoServices(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockTracesExporter(), nil
+	}
+	cfg := serviceRoutingConfig()
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	sink := new(consumertest.TracesSink)
+	lb.exporters["endpoint-1"] = newMockTracesExporter(sink.ConsumeTraces)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// two traces from two services, all resources being routed to the single endpoint
+	batch := pdata.NewTraces()
+	for i, service := range []string{"service-a", "service-b"} {
+		rs := batch.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(pdata.NewTraceID([16]byte{byte(i + 1)}))
+	}
+
+	// test
+	err = p.ConsumeTraces(context.Background(), batch)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 2, sink.AllTraces()[0].ResourceSpans().Len())
+}
+
 func TestNoTracesInBatch(t *testing.T) {
 	for _, tt := range []struct {
 		desc  string
@@ -34,8 +34,17 @@ type Config struct {
 	// TenantID defines the tenant ID to associate log streams with.
 	TenantID string `mapstructure:"tenant_id"`
 
+	// TenantAttribute is the resource attribute holding the tenant ID to associate the log streams with.
+	// The logs of the resources without this attribute are associated with TenantID.
+	TenantAttribute string `mapstructure:"tenant_attribute"`
+
 	// Labels defines how labels should be applied to log streams sent to Loki.
 	Labels LabelsConfig `mapstructure:"labels"`
+
+	// Format defines how the log records are rendered into the log lines: "body" renders the body only,
+	// "json" and "logfmt" render the body along with the attributes that are not labels,
+	// the trace and span IDs and the severity.
+	Format string `mapstructure:"format"`
 }
 
 func (c *Config) validate() error {
@@ -43,6 +52,12 @@ func (c *Config) validate() error {
 		return fmt.Errorf("\"endpoint\" must be a valid URL")
 	}
 
+	switch c.Format {
+	case "", formatBody, formatJSON, formatLogfmt:
+	default:
+		return fmt.Errorf("\"format\" must be one of %q, %q or %q", formatBody, formatJSON, formatLogfmt)
+	}
+
 	return c.Labels.validate()
 }
 
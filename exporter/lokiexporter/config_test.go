@@ -75,7 +75,9 @@ func TestLoadConfig(t *testing.T) {
 			NumConsumers: 2,
 			QueueSize:    10,
 		},
-		TenantID: "example",
+		TenantID:        "example",
+		TenantAttribute: "tenant",
+		Format:          formatLogfmt,
 		Labels: LabelsConfig{
 			Attributes: map[string]string{
 				conventions.AttributeContainerName: "container_name",
@@ -107,6 +109,7 @@ func TestConfig_validate(t *testing.T) {
 		CredentialFile string
 		Audience       string
 		Labels         LabelsConfig
+		Format         string
 	}
 	tests := []struct {
 		name         string
@@ -168,6 +171,25 @@ func TestConfig_validate(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "with json format",
+			fields: fields{
+				Endpoint: validEndpoint,
+				Labels:   validAttribLabelsConfig,
+				Format:   formatJSON,
+			},
+			shouldError: false,
+		},
+		{
+			name: "with invalid format",
+			fields: fields{
+				Endpoint: validEndpoint,
+				Labels:   validAttribLabelsConfig,
+				Format:   "xml",
+			},
+			errorMessage: "\"format\" must be one of \"body\", \"json\" or \"logfmt\"",
+			shouldError:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +199,9 @@ func TestConfig_validate(t *testing.T) {
 			cfg.ExporterSettings = config.NewExporterSettings(config.NewID(typeStr))
 			cfg.Endpoint = tt.fields.Endpoint
 			cfg.Labels = tt.fields.Labels
+			if tt.fields.Format != "" {
+				cfg.Format = tt.fields.Format
+			}
 
 			err := cfg.validate()
 			if (err != nil) != tt.shouldError {
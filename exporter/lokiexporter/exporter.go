@@ -53,11 +53,44 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld pdata.Logs) error {
 	l.wg.Add(1)
 	defer l.wg.Done()
 
-	pushReq, _ := l.logDataToLoki(ld)
-	if len(pushReq.Streams) == 0 {
+	var errs, permanentErrs []error
+	failed := pdata.NewLogs()
+	pushed := 0
+	for tenant, logs := range l.splitByTenant(ld) {
+		pushReq, _ := l.logDataToLoki(logs)
+		if len(pushReq.Streams) == 0 {
+			continue
+		}
+		pushed++
+
+		if err := l.push(ctx, tenant, pushReq); err != nil {
+			if consumererror.IsPermanent(err) {
+				permanentErrs = append(permanentErrs, err)
+				continue
+			}
+			errs = append(errs, err)
+			rls := logs.ResourceLogs()
+			for i := 0; i < rls.Len(); i++ {
+				rls.At(i).CopyTo(failed.ResourceLogs().AppendEmpty())
+			}
+		}
+	}
+
+	if pushed == 0 {
 		return consumererror.Permanent(fmt.Errorf("failed to transform logs into Loki log streams"))
 	}
+	if len(errs) == 0 {
+		return consumererror.Combine(permanentErrs)
+	}
+	// a permanent error would prevent the logs of the other tenants from being retried
+	for _, err := range permanentErrs {
+		l.logger.Error("Dropping the logs of a tenant", zap.Error(err))
+	}
+	return consumererror.NewLogs(consumererror.Combine(errs), failed)
+}
 
+// push sends the push request to Loki, associating its log streams with the tenant.
+func (l *lokiExporter) push(ctx context.Context, tenant string, pushReq *logproto.PushRequest) error {
 	buf, err := encode(pushReq)
 	if err != nil {
 		return consumererror.Permanent(err)
@@ -73,26 +106,51 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld pdata.Logs) error {
 	}
 	req.Header.Set("Content-Type", "application/x-protobuf")
 
-	if len(l.config.TenantID) > 0 {
-		req.Header.Set("X-Scope-OrgID", l.config.TenantID)
+	if len(tenant) > 0 {
+		req.Header.Set("X-Scope-OrgID", tenant)
 	}
 
 	resp, err := l.client.Do(req)
 	if err != nil {
-		return consumererror.NewLogs(err, ld)
+		return err
 	}
 
 	_, _ = io.Copy(ioutil.Discard, resp.Body)
 	_ = resp.Body.Close()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		err = fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
-		return consumererror.NewLogs(err, ld)
+		return fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
 	return nil
 }
 
+// splitByTenant groups the resource logs by the tenant they are associated with, which is the value of
+// the TenantAttribute resource attribute or TenantID if the resource does not have this attribute.
+func (l *lokiExporter) splitByTenant(ld pdata.Logs) map[string]pdata.Logs {
+	if l.config.TenantAttribute == "" {
+		return map[string]pdata.Logs{l.config.TenantID: ld}
+	}
+
+	tenants := make(map[string]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		tenant := l.config.TenantID
+		if av, ok := rl.Resource().Attributes().Get(l.config.TenantAttribute); ok && av.StringVal() != "" {
+			tenant = av.StringVal()
+		}
+
+		logs, ok := tenants[tenant]
+		if !ok {
+			logs = pdata.NewLogs()
+			tenants[tenant] = logs
+		}
+		rl.CopyTo(logs.ResourceLogs().AppendEmpty())
+	}
+	return tenants
+}
+
 func encode(pb proto.Message) ([]byte, error) {
 	buf, err := proto.Marshal(pb)
 	if err != nil {
@@ -135,7 +193,12 @@ func (l *lokiExporter) logDataToLoki(ld pdata.Logs) (pr *logproto.PushRequest, n
 					continue
 				}
 				labels := mergedLabels.String()
-				entry := convertLogToLokiEntry(log)
+				entry, err := l.convertLogToLokiEntry(log, resource)
+				if err != nil {
+					l.logger.Debug("Failed to convert log record to Loki entry", zap.Error(err))
+					numDroppedLogs++
+					continue
+				}
 
 				if stream, ok := streams[labels]; ok {
 					stream.Entries = append(stream.Entries, *entry)
@@ -195,9 +258,23 @@ func (l *lokiExporter) convertAttributesToLabels(attributes pdata.AttributeMap,
 	return ls
 }
 
-func convertLogToLokiEntry(lr pdata.LogRecord) *logproto.Entry {
+func (l *lokiExporter) convertLogToLokiEntry(lr pdata.LogRecord, resource pdata.Resource) (*logproto.Entry, error) {
+	var line string
+	var err error
+	switch l.config.Format {
+	case formatJSON:
+		line, err = formatJSONLine(lr, resource, &l.config.Labels)
+	case formatLogfmt:
+		line, err = formatLogfmtLine(lr, resource, &l.config.Labels)
+	default:
+		line = formatBodyLine(lr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return &logproto.Entry{
 		Timestamp: time.Unix(0, int64(lr.Timestamp())),
-		Line:      lr.Body().StringVal(),
-	}
+		Line:      line,
+	}, nil
 }
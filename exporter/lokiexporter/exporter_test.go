@@ -473,18 +473,179 @@ func TestExporter_convertAttributesToLabels(t *testing.T) {
 
 func TestExporter_convertLogToLokiEntry(t *testing.T) {
 	ts := pdata.Timestamp(int64(1) * time.Millisecond.Nanoseconds())
-	lr := pdata.NewLogRecord()
-	lr.Body().SetStringVal("log message")
-	lr.SetTimestamp(ts)
 
-	entry := convertLogToLokiEntry(lr)
+	genLogRecord := func() pdata.LogRecord {
+		lr := pdata.NewLogRecord()
+		lr.Body().SetStringVal("log message")
+		lr.SetTimestamp(ts)
+		lr.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4}))
+		lr.SetSpanID(pdata.NewSpanID([8]byte{5, 6, 7, 8}))
+		lr.SetSeverityText("info")
+		lr.Attributes().InsertString("severity", "info")
+		lr.Attributes().InsertInt("http.status_code", 200)
+		return lr
+	}
+
+	genResource := func() pdata.Resource {
+		resource := pdata.NewResource()
+		resource.Attributes().InsertString("resource.name", "myresource")
+		resource.Attributes().InsertString("host.name", "myhost")
+		return resource
+	}
+
+	labels := LabelsConfig{
+		Attributes: map[string]string{
+			"severity": "severity",
+		},
+		ResourceAttributes: map[string]string{
+			"resource.name": "resource_name",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		genBody  func(body pdata.AttributeValue)
+		expected string
+	}{
+		{
+			name:     "body",
+			format:   formatBody,
+			expected: "log message",
+		},
+		{
+			name:   "body with map body",
+			format: formatBody,
+			genBody: func(body pdata.AttributeValue) {
+				pdata.NewAttributeValueMap().CopyTo(body)
+				body.MapVal().InsertString("msg", "log message")
+			},
+			expected: `{"msg":"log message"}`,
+		},
+		{
+			name:     "json",
+			format:   formatJSON,
+			expected: `{"body":"log message","traceid":"01020304000000000000000000000000","spanid":"0506070800000000","severity":"info","attributes":{"http.status_code":200},"resources":{"host.name":"myhost"}}`,
+		},
+		{
+			name:     "logfmt",
+			format:   formatLogfmt,
+			expected: `msg="log message" traceID=01020304000000000000000000000000 spanID=0506070800000000 severity=info attribute_http.status_code=200 resource_host.name=myhost`,
+		},
+		{
+			name:   "logfmt with map body",
+			format: formatLogfmt,
+			genBody: func(body pdata.AttributeValue) {
+				pdata.NewAttributeValueMap().CopyTo(body)
+				body.MapVal().InsertString("msg", "log message")
+				body.MapVal().InsertBool("error", true)
+			},
+			expected: `error=true msg="log message" traceID=01020304000000000000000000000000 spanID=0506070800000000 severity=info attribute_http.status_code=200 resource_host.name=myhost`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := newExporter(&Config{Format: tt.format, Labels: labels}, zap.NewNop())
+			lr := genLogRecord()
+			if tt.genBody != nil {
+				tt.genBody(lr.Body())
+			}
+
+			entry, err := exp.convertLogToLokiEntry(lr, genResource())
+			require.NoError(t, err)
+
+			expEntry := &logproto.Entry{
+				Timestamp: time.Unix(0, int64(lr.Timestamp())),
+				Line:      tt.expected,
+			}
+			require.Equal(t, expEntry, entry)
+		})
+	}
+}
+
+func TestExporter_pushLogDataWithTenantAttribute(t *testing.T) {
+	tenants := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenants[r.Header.Get("X-Scope-OrgID")]++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: server.URL,
+		},
+		TenantID:        "default",
+		TenantAttribute: "tenant",
+		Labels: LabelsConfig{
+			Attributes: map[string]string{
+				"severity": "severity",
+			},
+		},
+	}
+
+	ld := pdata.NewLogs()
+	for _, tenant := range []string{"tenant1", "tenant2", "tenant1", ""} {
+		logs := createLogData(1, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+			"severity": pdata.NewAttributeValueString("debug"),
+		}))
+		if tenant != "" {
+			logs.ResourceLogs().At(0).Resource().Attributes().InsertString("tenant", tenant)
+		}
+		logs.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	}
 
-	expEntry := &logproto.Entry{
-		Timestamp: time.Unix(0, int64(lr.Timestamp())),
-		Line:      "log message",
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.pushLogData(context.Background(), ld))
+
+	assert.Equal(t, map[string]int{"tenant1": 1, "tenant2": 1, "default": 1}, tenants)
+}
+
+func TestExporter_pushLogDataRetriesFailedTenants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Scope-OrgID") == "tenant2" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: server.URL,
+		},
+		TenantAttribute: "tenant",
+		Labels: LabelsConfig{
+			Attributes: map[string]string{
+				"severity": "severity",
+			},
+		},
 	}
-	require.NotNil(t, entry)
-	require.Equal(t, expEntry, entry)
+
+	ld := pdata.NewLogs()
+	for _, tenant := range []string{"tenant1", "tenant2"} {
+		logs := createLogData(1, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+			"severity": pdata.NewAttributeValueString("debug"),
+		}))
+		logs.ResourceLogs().At(0).Resource().Attributes().InsertString("tenant", tenant)
+		logs.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	}
+
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	err := exp.pushLogData(context.Background(), ld)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	var logsErr consumererror.Logs
+	require.True(t, consumererror.AsLogs(err, &logsErr))
+	failed := logsErr.GetLogs()
+	require.Equal(t, 1, failed.ResourceLogs().Len())
+	tenant, _ := failed.ResourceLogs().At(0).Resource().Attributes().Get("tenant")
+	assert.Equal(t, "tenant2", tenant.StringVal())
+	assert.Equal(t, 2, ld.ResourceLogs().Len())
 }
 
 type badProtoForCoverage struct {
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/go-logfmt/logfmt"
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// Line formats of the log entries.
+const (
+	formatBody   = "body"
+	formatJSON   = "json"
+	formatLogfmt = "logfmt"
+)
+
+// lokiEntry holds the fields of a log record rendered in the json format.
+type lokiEntry struct {
+	Name       string                 `json:"name,omitempty"`
+	Body       interface{}            `json:"body,omitempty"`
+	TraceID    string                 `json:"traceid,omitempty"`
+	SpanID     string                 `json:"spanid,omitempty"`
+	Severity   string                 `json:"severity,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Resources  map[string]interface{} `json:"resources,omitempty"`
+}
+
+// formatBodyLine renders the body of the log record, non-string bodies being rendered as JSON.
+func formatBodyLine(lr pdata.LogRecord) string {
+	if lr.Body().Type() == pdata.AttributeValueTypeString {
+		return lr.Body().StringVal()
+	}
+	return tracetranslator.AttributeValueToString(lr.Body())
+}
+
+// formatJSONLine renders the log record as a JSON object, without the attributes that are labels.
+func formatJSONLine(lr pdata.LogRecord, resource pdata.Resource, labels *LabelsConfig) (string, error) {
+	entry := lokiEntry{
+		Name:       lr.Name(),
+		Body:       attributeValue(lr.Body()),
+		Severity:   lr.SeverityText(),
+		Attributes: attributesWithoutLabels(lr.Attributes(), labels.Attributes),
+		Resources:  attributesWithoutLabels(resource.Attributes(), labels.ResourceAttributes),
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		entry.TraceID = traceID.HexString()
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		entry.SpanID = spanID.HexString()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
+
+// formatLogfmtLine renders the log record as logfmt key/value pairs, without the attributes that
+// are labels. The fields of map bodies are rendered as pairs, other bodies as the msg value.
+func formatLogfmtLine(lr pdata.LogRecord, resource pdata.Resource, labels *LabelsConfig) (string, error) {
+	var keyvals []interface{}
+
+	if body := lr.Body(); body.Type() == pdata.AttributeValueTypeMap {
+		keyvals = appendKeyvals(keyvals, "", attributeValues(body.MapVal()))
+	} else if body.Type() != pdata.AttributeValueTypeNull {
+		keyvals = append(keyvals, "msg", formatBodyLine(lr))
+	}
+	if name := lr.Name(); name != "" {
+		keyvals = append(keyvals, "name", name)
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		keyvals = append(keyvals, "traceID", traceID.HexString())
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		keyvals = append(keyvals, "spanID", spanID.HexString())
+	}
+	if severity := lr.SeverityText(); severity != "" {
+		keyvals = append(keyvals, "severity", severity)
+	}
+	keyvals = appendKeyvals(keyvals, "attribute_", attributesWithoutLabels(lr.Attributes(), labels.Attributes))
+	keyvals = appendKeyvals(keyvals, "resource_", attributesWithoutLabels(resource.Attributes(), labels.ResourceAttributes))
+
+	line, err := logfmt.MarshalKeyvals(keyvals...)
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
+
+// appendKeyvals appends the values sorted by key, the keys being prefixed. Nested values are
+// rendered as JSON, as logfmt values are flat.
+func appendKeyvals(keyvals []interface{}, prefix string, values map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := values[k]
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			if b, err := json.Marshal(v); err == nil {
+				v = string(b)
+			} else {
+				v = fmt.Sprint(v)
+			}
+		}
+		keyvals = append(keyvals, prefix+k, v)
+	}
+	return keyvals
+}
+
+// attributesWithoutLabels returns the attributes that are not added as labels to the log streams.
+func attributesWithoutLabels(attributes pdata.AttributeMap, labels map[string]string) map[string]interface{} {
+	values := attributeValues(attributes)
+	for attr := range labels {
+		delete(values, attr)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func attributeValues(attributes pdata.AttributeMap) map[string]interface{} {
+	values := make(map[string]interface{}, attributes.Len())
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		values[k] = attributeValue(v)
+		return true
+	})
+	return values
+}
+
+func attributeValue(value pdata.AttributeValue) interface{} {
+	switch value.Type() {
+	case pdata.AttributeValueTypeString:
+		return value.StringVal()
+	case pdata.AttributeValueTypeInt:
+		return value.IntVal()
+	case pdata.AttributeValueTypeDouble:
+		return value.DoubleVal()
+	case pdata.AttributeValueTypeBool:
+		return value.BoolVal()
+	case pdata.AttributeValueTypeMap:
+		return attributeValues(value.MapVal())
+	case pdata.AttributeValueTypeArray:
+		arr := value.ArrayVal()
+		values := make([]interface{}, arr.Len())
+		for i := 0; i < arr.Len(); i++ {
+			values[i] = attributeValue(arr.At(i))
+		}
+		return values
+	default:
+		return nil
+	}
+}
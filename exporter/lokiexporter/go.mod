@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiex
 go 1.16
 
 require (
+	github.com/go-logfmt/logfmt v0.5.0
 	github.com/gogo/protobuf v1.3.2
 	github.com/golang/snappy v0.0.4
 	github.com/mattn/go-colorable v0.1.7 // indirect
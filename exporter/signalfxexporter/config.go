@@ -32,6 +32,7 @@ import (
 
 const (
 	translationRulesConfigKey = "translation_rules"
+	logEventsConfigKey        = "log_events"
 )
 
 var _ config.CustomUnmarshable = (*Config)(nil)
@@ -105,6 +106,10 @@ type Config struct {
 	// NonAlphanumericDimensionChars is a list of allowable characters, in addition to alphanumeric ones,
 	// to be used in a dimension key.
 	NonAlphanumericDimensionChars string `mapstructure:"nonalphanumeric_dimension_chars"`
+
+	// LogEvents defines how log records that are not SignalFx events, e.g. Kubernetes
+	// events, are translated to SignalFx events. Such log records are dropped by default.
+	LogEvents translation.LogEventsConfig `mapstructure:"log_events"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -131,13 +136,19 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		return nil, fmt.Errorf("invalid \"%s\": %v", translationRulesConfigKey, err)
 	}
 
+	logEventsTranslator, err := translation.NewLogEventsTranslator(cfg.LogEvents)
+	if err != nil {
+		return nil, fmt.Errorf("invalid \"%s\": %v", logEventsConfigKey, err)
+	}
+
 	return &exporterOptions{
-		ingestURL:        ingestURL,
-		apiURL:           apiURL,
-		httpTimeout:      cfg.Timeout,
-		token:            cfg.AccessToken,
-		logDimUpdate:     cfg.LogDimensionUpdates,
-		metricTranslator: metricTranslator,
+		ingestURL:           ingestURL,
+		apiURL:              apiURL,
+		httpTimeout:         cfg.Timeout,
+		token:               cfg.AccessToken,
+		logDimUpdate:        cfg.LogDimensionUpdates,
+		metricTranslator:    metricTranslator,
+		logEventsTranslator: logEventsTranslator,
 	}, nil
 }
 
@@ -177,6 +177,19 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		NonAlphanumericDimensionChars: "_-.",
+		LogEvents: translation.LogEventsConfig{
+			Enabled:            true,
+			DefaultCategory:    "user_defined",
+			EventTypeAttribute: "k8s.event.reason",
+			Rules: []translation.LogEventRule{
+				{
+					Attribute: "k8s.event.type",
+					Values:    []string{"Warning"},
+					Category:  "alert",
+				},
+			},
+			DimensionAttributes: []string{"k8s.cluster.name", "k8s.namespace.name"},
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -200,6 +213,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		Headers          map[string]string
 		TranslationRules []translation.Rule
 		SyncHostMetadata bool
+		LogEvents        translation.LogEventsConfig
 	}
 	tests := []struct {
 		name    string
@@ -305,6 +319,19 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test invalid log events",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				LogEvents: translation.LogEventsConfig{
+					Enabled:         true,
+					DefaultCategory: "unknown",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -321,6 +348,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				TranslationRules:    tt.fields.TranslationRules,
 				SyncHostMetadata:    tt.fields.SyncHostMetadata,
 				DeltaTranslationTTL: 3600,
+				LogEvents:           tt.fields.LogEvents,
 			}
 
 			got, err := cfg.getOptionsFromConfig()
@@ -36,6 +36,7 @@ type sfxEventClient struct {
 	sfxClientBase
 	logger                 *zap.Logger
 	accessTokenPassthrough bool
+	logEventsTranslator    *translation.LogEventsTranslator
 }
 
 func (s *sfxEventClient) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
@@ -54,7 +55,7 @@ func (s *sfxEventClient) pushLogsData(ctx context.Context, ld pdata.Logs) (int,
 		ills := rl.InstrumentationLibraryLogs()
 		for j := 0; j < ills.Len(); j++ {
 			ill := ills.At(j)
-			events, dropped := translation.LogSliceToSignalFxV2(s.logger, ill.Logs(), rl.Resource().Attributes())
+			events, dropped := translation.LogSliceToSignalFxV2(s.logger, ill.Logs(), rl.Resource().Attributes(), s.logEventsTranslator)
 			sfxEvents = append(sfxEvents, events...)
 			numDroppedLogRecords += dropped
 		}
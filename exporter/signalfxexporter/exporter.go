@@ -64,12 +64,13 @@ type signalfxExporter struct {
 }
 
 type exporterOptions struct {
-	ingestURL        *url.URL
-	apiURL           *url.URL
-	httpTimeout      time.Duration
-	token            string
-	logDimUpdate     bool
-	metricTranslator *translation.MetricTranslator
+	ingestURL           *url.URL
+	apiURL              *url.URL
+	httpTimeout         time.Duration
+	token               string
+	logDimUpdate        bool
+	metricTranslator    *translation.MetricTranslator
+	logEventsTranslator *translation.LogEventsTranslator
 }
 
 // newSignalFxExporter returns a new SignalFx exporter.
@@ -172,6 +173,7 @@ func newEventExporter(config *Config, logger *zap.Logger) (*signalfxExporter, er
 		},
 		logger:                 logger,
 		accessTokenPassthrough: config.AccessTokenPassthrough,
+		logEventsTranslator:    options.logEventsTranslator,
 	}
 
 	return &signalfxExporter{
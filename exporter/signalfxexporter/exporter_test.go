@@ -512,6 +512,7 @@ func TestConsumeEventData(t *testing.T) {
 	tests := []struct {
 		name                 string
 		resourceLogs         pdata.Logs
+		logEvents            translation.LogEventsConfig
 		reqTestFunc          func(t *testing.T, r *http.Request)
 		httpResponseCode     int
 		numDroppedLogRecords int
@@ -534,6 +535,24 @@ func TestConsumeEventData(t *testing.T) {
 			numDroppedLogRecords: 1,
 			httpResponseCode:     http.StatusAccepted,
 		},
+		{
+			name: "no_event_attribute_with_log_events",
+			resourceLogs: func() pdata.Logs {
+				out := makeSampleResourceLogs()
+				out.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes().Delete("com.splunk.signalfx.event_category")
+				return out
+			}(),
+			logEvents: translation.LogEventsConfig{Enabled: true},
+			reqTestFunc: func(t *testing.T, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				var msg sfxpb.EventUploadMessage
+				require.NoError(t, msg.Unmarshal(body))
+				require.Len(t, msg.Events, 1)
+				assert.Equal(t, sfxpb.EventCategory_USER_DEFINED, msg.Events[0].GetCategory())
+			},
+			httpResponseCode: http.StatusAccepted,
+		},
 		{
 			name: "nonconvertible_log_attrs",
 			resourceLogs: func() pdata.Logs {
@@ -582,6 +601,9 @@ func TestConsumeEventData(t *testing.T) {
 			serverURL, err := url.Parse(server.URL)
 			assert.NoError(t, err)
 
+			logEventsTranslator, err := translation.NewLogEventsTranslator(tt.logEvents)
+			require.NoError(t, err)
+
 			eventClient := &sfxEventClient{
 				sfxClientBase: sfxClientBase{
 					ingestURL: serverURL,
@@ -591,7 +613,8 @@ func TestConsumeEventData(t *testing.T) {
 					},
 					zippers: newGzipPool(),
 				},
-				logger: zap.NewNop(),
+				logger:              zap.NewNop(),
+				logEventsTranslator: logEventsTranslator,
 			}
 
 			numDroppedLogRecords, err := eventClient.pushLogsData(context.Background(), tt.resourceLogs)
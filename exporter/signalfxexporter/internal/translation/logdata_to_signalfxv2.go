@@ -24,10 +24,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
+// LogSliceToSignalFxV2 converts the log records to SignalFx events. Log records
+// without the SignalFx event category attribute are translated by the given
+// LogEventsTranslator, or dropped if it is nil.
 func LogSliceToSignalFxV2(
 	logger *zap.Logger,
 	logs pdata.LogSlice,
 	resourceAttrs pdata.AttributeMap,
+	logEventsTranslator *LogEventsTranslator,
 ) ([]*sfxpb.Event, int) {
 	events := make([]*sfxpb.Event, 0, logs.Len())
 	numDroppedLogRecords := 0
@@ -35,6 +39,9 @@ func LogSliceToSignalFxV2(
 	for i := 0; i < logs.Len(); i++ {
 		lr := logs.At(i)
 		event, ok := convertLogRecord(lr, resourceAttrs, logger)
+		if !ok && logEventsTranslator != nil {
+			event, ok = logEventsTranslator.translate(lr, resourceAttrs, logger), true
+		}
 		if !ok {
 			numDroppedLogRecords++
 			continue
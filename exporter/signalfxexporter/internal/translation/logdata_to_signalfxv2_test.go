@@ -121,7 +121,7 @@ func TestLogDataToSignalFxEvents(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			resource := tt.logData.ResourceLogs().At(0).Resource()
 			logSlice := tt.logData.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
-			events, dropped := LogSliceToSignalFxV2(zap.NewNop(), logSlice, resource.Attributes())
+			events, dropped := LogSliceToSignalFxV2(zap.NewNop(), logSlice, resource.Attributes(), nil)
 			for i := 0; i < logSlice.Len(); i++ {
 				logSlice.At(i).Attributes().Sort()
 			}
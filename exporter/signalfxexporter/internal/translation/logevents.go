@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"fmt"
+	"strings"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLogEventType = "log"
+
+	// Event properties set from generic log records.
+	logEventMessageProperty  = "message"
+	logEventSeverityProperty = "severity"
+)
+
+// LogEventsConfig defines how log records that are not SignalFx events, e.g.
+// Kubernetes events, are translated to SignalFx events.
+type LogEventsConfig struct {
+	// Enabled translates log records without the SignalFx event category
+	// attribute to SignalFx events instead of dropping them.
+	Enabled bool `mapstructure:"enabled"`
+
+	// DefaultCategory is the category of the translated events when no rule
+	// matches, USER_DEFINED if not set.
+	DefaultCategory string `mapstructure:"default_category"`
+
+	// EventTypeAttribute is the log record attribute holding the event type.
+	// If not set or missing from a log record, the log record name is used.
+	EventTypeAttribute string `mapstructure:"event_type_attribute"`
+
+	// DefaultEventType is the event type used when it can't be determined
+	// from the log record, "log" if not set.
+	DefaultEventType string `mapstructure:"default_event_type"`
+
+	// Rules override the category and the event type of the log records
+	// matching them. The first matching rule is applied.
+	Rules []LogEventRule `mapstructure:"rules"`
+
+	// DimensionAttributes are the resource attributes added as event
+	// dimensions. All resource attributes with string values are added if empty.
+	DimensionAttributes []string `mapstructure:"dimension_attributes"`
+}
+
+// LogEventRule matches log records with a given log record or resource
+// attribute value.
+type LogEventRule struct {
+	// Attribute is the log record or resource attribute to match, log record
+	// attributes take priority.
+	Attribute string `mapstructure:"attribute"`
+
+	// Values are the attribute values matching the rule, any value matches if empty.
+	Values []string `mapstructure:"values"`
+
+	// Category is the category of the matching events.
+	Category string `mapstructure:"category"`
+
+	// EventType is the event type of the matching events.
+	EventType string `mapstructure:"event_type"`
+}
+
+type logEventRule struct {
+	attribute string
+	values    map[string]bool
+	category  *sfxpb.EventCategory
+	eventType string
+}
+
+// LogEventsTranslator translates generic log records to SignalFx events.
+type LogEventsTranslator struct {
+	defaultCategory     sfxpb.EventCategory
+	eventTypeAttribute  string
+	defaultEventType    string
+	rules               []logEventRule
+	dimensionAttributes []string
+}
+
+// NewLogEventsTranslator creates a LogEventsTranslator from the given
+// config, it returns nil if the translation is not enabled.
+func NewLogEventsTranslator(cfg LogEventsConfig) (*LogEventsTranslator, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	t := &LogEventsTranslator{
+		defaultCategory:     sfxpb.EventCategory_USER_DEFINED,
+		eventTypeAttribute:  cfg.EventTypeAttribute,
+		defaultEventType:    cfg.DefaultEventType,
+		dimensionAttributes: cfg.DimensionAttributes,
+	}
+	if t.defaultEventType == "" {
+		t.defaultEventType = defaultLogEventType
+	}
+
+	if cfg.DefaultCategory != "" {
+		category, err := parseEventCategory(cfg.DefaultCategory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid \"default_category\": %v", err)
+		}
+		t.defaultCategory = category
+	}
+
+	for i, r := range cfg.Rules {
+		if r.Attribute == "" {
+			return nil, fmt.Errorf("field \"attribute\" is required for log event rule %d", i)
+		}
+		if r.Category == "" && r.EventType == "" {
+			return nil, fmt.Errorf("field \"category\" or \"event_type\" is required for log event rule %d", i)
+		}
+
+		rule := logEventRule{
+			attribute: r.Attribute,
+			eventType: r.EventType,
+		}
+		if len(r.Values) > 0 {
+			rule.values = make(map[string]bool, len(r.Values))
+			for _, v := range r.Values {
+				rule.values[v] = true
+			}
+		}
+		if r.Category != "" {
+			category, err := parseEventCategory(r.Category)
+			if err != nil {
+				return nil, fmt.Errorf("invalid \"category\" for log event rule %d: %v", i, err)
+			}
+			rule.category = &category
+		}
+		t.rules = append(t.rules, rule)
+	}
+
+	return t, nil
+}
+
+func parseEventCategory(name string) (sfxpb.EventCategory, error) {
+	category, ok := sfxpb.EventCategory_value[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown event category %q", name)
+	}
+	return sfxpb.EventCategory(category), nil
+}
+
+// translate converts a log record to a SignalFx event. The log record
+// attributes, body and severity become event properties and the resource
+// attributes become event dimensions.
+func (t *LogEventsTranslator) translate(lr pdata.LogRecord, resourceAttrs pdata.AttributeMap, logger *zap.Logger) *sfxpb.Event {
+	attrs := lr.Attributes()
+	category := t.defaultCategory
+	event := sfxpb.Event{
+		EventType: t.eventType(lr),
+		Category:  &category,
+		// Convert nanoseconds to nearest milliseconds, which is the unit of
+		// SignalFx event timestamps.
+		Timestamp: int64(lr.Timestamp()) / 1e6,
+	}
+
+	if rule, ok := t.matchRule(attrs, resourceAttrs); ok {
+		if rule.category != nil {
+			category = *rule.category
+		}
+		if rule.eventType != "" {
+			event.EventType = rule.eventType
+		}
+	}
+
+	addDimension := func(k string, v pdata.AttributeValue) {
+		if v.Type() != pdata.AttributeValueTypeString {
+			logger.Debug("Failed to convert resource attribute value to SignalFx dimension value, value is not a string", zap.String("key", k))
+			return
+		}
+		event.Dimensions = append(event.Dimensions, &sfxpb.Dimension{
+			Key:   k,
+			Value: v.StringVal(),
+		})
+	}
+	if len(t.dimensionAttributes) > 0 {
+		for _, k := range t.dimensionAttributes {
+			if v, ok := resourceAttrs.Get(k); ok {
+				addDimension(k, v)
+			}
+		}
+	} else {
+		resourceAttrs.Range(func(k string, v pdata.AttributeValue) bool {
+			addDimension(k, v)
+			return true
+		})
+	}
+
+	if body := tracetranslator.AttributeValueToString(lr.Body()); body != "" {
+		event.Properties = append(event.Properties, stringProperty(logEventMessageProperty, body))
+	}
+	if severity := lr.SeverityText(); severity != "" {
+		event.Properties = append(event.Properties, stringProperty(logEventSeverityProperty, severity))
+	} else if lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+		event.Properties = append(event.Properties, stringProperty(logEventSeverityProperty, strings.TrimPrefix(lr.SeverityNumber().String(), "SEVERITY_NUMBER_")))
+	}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if k == t.eventTypeAttribute {
+			return true
+		}
+		val, err := attributeValToPropertyVal(v)
+		if err != nil {
+			logger.Debug("Failed to convert log record attribute value to SignalFx property value", zap.Error(err), zap.String("key", k))
+			return true
+		}
+		event.Properties = append(event.Properties, &sfxpb.Property{
+			Key:   k,
+			Value: val,
+		})
+		return true
+	})
+
+	return &event
+}
+
+func (t *LogEventsTranslator) eventType(lr pdata.LogRecord) string {
+	if t.eventTypeAttribute != "" {
+		if v, ok := lr.Attributes().Get(t.eventTypeAttribute); ok {
+			if eventType := tracetranslator.AttributeValueToString(v); eventType != "" {
+				return eventType
+			}
+		}
+	}
+	if lr.Name() != "" {
+		return lr.Name()
+	}
+	return t.defaultEventType
+}
+
+func (t *LogEventsTranslator) matchRule(attrs, resourceAttrs pdata.AttributeMap) (logEventRule, bool) {
+	for _, rule := range t.rules {
+		v, ok := attrs.Get(rule.attribute)
+		if !ok {
+			v, ok = resourceAttrs.Get(rule.attribute)
+		}
+		if !ok {
+			continue
+		}
+		if rule.values == nil || rule.values[tracetranslator.AttributeValueToString(v)] {
+			return rule, true
+		}
+	}
+	return logEventRule{}, false
+}
+
+func stringProperty(key, value string) *sfxpb.Property {
+	return &sfxpb.Property{
+		Key:   key,
+		Value: &sfxpb.PropertyValue{StrValue: &value},
+	}
+}
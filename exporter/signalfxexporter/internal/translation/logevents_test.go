@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func TestNewLogEventsTranslator(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     LogEventsConfig
+		wantNil bool
+		wantErr string
+	}{
+		{
+			name:    "disabled",
+			cfg:     LogEventsConfig{DefaultCategory: "invalid"},
+			wantNil: true,
+		},
+		{
+			name: "valid",
+			cfg: LogEventsConfig{
+				Enabled:         true,
+				DefaultCategory: "agent",
+				Rules: []LogEventRule{
+					{Attribute: "k8s.event.reason", Values: []string{"BackOff"}, Category: "ALERT"},
+				},
+			},
+		},
+		{
+			name:    "invalid default category",
+			cfg:     LogEventsConfig{Enabled: true, DefaultCategory: "invalid"},
+			wantErr: `invalid "default_category": unknown event category "invalid"`,
+		},
+		{
+			name: "rule without attribute",
+			cfg: LogEventsConfig{
+				Enabled: true,
+				Rules:   []LogEventRule{{Category: "ALERT"}},
+			},
+			wantErr: `field "attribute" is required for log event rule 0`,
+		},
+		{
+			name: "rule without category and event type",
+			cfg: LogEventsConfig{
+				Enabled: true,
+				Rules:   []LogEventRule{{Attribute: "k8s.event.reason"}},
+			},
+			wantErr: `field "category" or "event_type" is required for log event rule 0`,
+		},
+		{
+			name: "rule with invalid category",
+			cfg: LogEventsConfig{
+				Enabled: true,
+				Rules:   []LogEventRule{{Attribute: "k8s.event.reason", Category: "invalid"}},
+			},
+			wantErr: `invalid "category" for log event rule 0: unknown event category "invalid"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator, err := NewLogEventsTranslator(tt.cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, translator)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, translator == nil)
+		})
+	}
+}
+
+func TestLogSliceToSignalFxV2WithLogEventsTranslator(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	msec := now.UnixNano() / 1e6
+
+	buildLogs := func(fn func(lr pdata.LogRecord)) (pdata.LogSlice, pdata.AttributeMap) {
+		logs := pdata.NewLogs()
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("k8s.cluster.name", "test-cluster")
+		rl.Resource().Attributes().InsertString("k8s.namespace.name", "default")
+		rl.Resource().Attributes().InsertInt("k8s.node.count", 3)
+		lrs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(pdata.TimestampFromTime(now))
+		lr.Body().SetStringVal("Back-off restarting failed container")
+		lr.SetSeverityText("Warning")
+		lr.Attributes().InsertString("k8s.event.reason", "BackOff")
+		lr.Attributes().InsertInt("k8s.event.count", 2)
+		fn(lr)
+		return lrs, rl.Resource().Attributes()
+	}
+
+	category := func(c sfxpb.EventCategory) *sfxpb.EventCategory {
+		return &c
+	}
+
+	defaultProperties := mapToEventProps(map[string]interface{}{
+		"message":          "Back-off restarting failed container",
+		"severity":         "Warning",
+		"k8s.event.reason": "BackOff",
+		"k8s.event.count":  2,
+	})
+
+	tests := []struct {
+		name      string
+		cfg       LogEventsConfig
+		setRecord func(lr pdata.LogRecord)
+		want      *sfxpb.Event
+	}{
+		{
+			name:      "defaults",
+			cfg:       LogEventsConfig{Enabled: true},
+			setRecord: func(lr pdata.LogRecord) {},
+			want: &sfxpb.Event{
+				EventType: "log",
+				Category:  category(sfxpb.EventCategory_USER_DEFINED),
+				Timestamp: msec,
+				Dimensions: []*sfxpb.Dimension{
+					{Key: "k8s.cluster.name", Value: "test-cluster"},
+					{Key: "k8s.namespace.name", Value: "default"},
+				},
+				Properties: defaultProperties,
+			},
+		},
+		{
+			name: "event type from attribute",
+			cfg: LogEventsConfig{
+				Enabled:             true,
+				DefaultCategory:     "agent",
+				EventTypeAttribute:  "k8s.event.reason",
+				DimensionAttributes: []string{"k8s.cluster.name", "missing"},
+			},
+			setRecord: func(lr pdata.LogRecord) {
+				lr.SetName("ignored")
+			},
+			want: &sfxpb.Event{
+				EventType: "BackOff",
+				Category:  category(sfxpb.EventCategory_AGENT),
+				Timestamp: msec,
+				Dimensions: []*sfxpb.Dimension{
+					{Key: "k8s.cluster.name", Value: "test-cluster"},
+				},
+				Properties: mapToEventProps(map[string]interface{}{
+					"message":         "Back-off restarting failed container",
+					"severity":        "Warning",
+					"k8s.event.count": 2,
+				}),
+			},
+		},
+		{
+			name: "event type from name and matching rule",
+			cfg: LogEventsConfig{
+				Enabled:             true,
+				DimensionAttributes: []string{"k8s.namespace.name"},
+				Rules: []LogEventRule{
+					{Attribute: "k8s.event.reason", Values: []string{"Pulled"}, Category: "JOB"},
+					{Attribute: "k8s.namespace.name", Category: "ALERT", EventType: "namespace event"},
+					{Attribute: "k8s.event.reason", Category: "AUDIT"},
+				},
+			},
+			setRecord: func(lr pdata.LogRecord) {
+				lr.SetName("k8s event")
+				lr.SetSeverityText("")
+				lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+			},
+			want: &sfxpb.Event{
+				EventType: "namespace event",
+				Category:  category(sfxpb.EventCategory_ALERT),
+				Timestamp: msec,
+				Dimensions: []*sfxpb.Dimension{
+					{Key: "k8s.namespace.name", Value: "default"},
+				},
+				Properties: mapToEventProps(map[string]interface{}{
+					"message":          "Back-off restarting failed container",
+					"severity":         "WARN",
+					"k8s.event.reason": "BackOff",
+					"k8s.event.count":  2,
+				}),
+			},
+		},
+		{
+			name: "matching rule keeps event type",
+			cfg: LogEventsConfig{
+				Enabled: true,
+				Rules: []LogEventRule{
+					{Attribute: "k8s.event.reason", Values: []string{"Failed", "BackOff"}, Category: "ALERT"},
+				},
+			},
+			setRecord: func(lr pdata.LogRecord) {
+				lr.SetName("k8s event")
+			},
+			want: &sfxpb.Event{
+				EventType: "k8s event",
+				Category:  category(sfxpb.EventCategory_ALERT),
+				Timestamp: msec,
+				Dimensions: []*sfxpb.Dimension{
+					{Key: "k8s.cluster.name", Value: "test-cluster"},
+					{Key: "k8s.namespace.name", Value: "default"},
+				},
+				Properties: defaultProperties,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator, err := NewLogEventsTranslator(tt.cfg)
+			require.NoError(t, err)
+
+			logSlice, resourceAttrs := buildLogs(tt.setRecord)
+			events, dropped := LogSliceToSignalFxV2(zap.NewNop(), logSlice, resourceAttrs, translator)
+			assert.Equal(t, 0, dropped)
+			require.Len(t, events, 1)
+			sortEvent(events[0])
+			sortEvent(tt.want)
+			assert.Equal(t, tt.want, events[0])
+		})
+	}
+}
+
+func TestLogSliceToSignalFxV2WithoutLogEventsTranslator(t *testing.T) {
+	lrs := pdata.NewLogSlice()
+	lrs.AppendEmpty().Body().SetStringVal("not an event")
+
+	events, dropped := LogSliceToSignalFxV2(zap.NewNop(), lrs, pdata.NewAttributeMap(), nil)
+	assert.Empty(t, events)
+	assert.Equal(t, 1, dropped)
+}
+
+func sortEvent(e *sfxpb.Event) {
+	sort.Slice(e.Properties, func(i, j int) bool {
+		return e.Properties[i].Key < e.Properties[j].Key
+	})
+	sort.Slice(e.Dimensions, func(i, j int) bool {
+		return e.Dimensions[i].Key < e.Dimensions[j].Key
+	})
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+const (
+	// ackPath is the path of the HEC indexer acknowledgement endpoint, relative to the HEC path.
+	ackPath = "ack"
+	// channelHeader is the header holding the channel GUID the acknowledgement IDs are scoped to.
+	channelHeader = "X-Splunk-Request-Channel"
+)
+
+// ackResponse is the response of the HEC event endpoint when indexer acknowledgement is enabled.
+type ackResponse struct {
+	Text  string  `json:"text"`
+	Code  int     `json:"code"`
+	AckID *uint64 `json:"ackId"`
+}
+
+// ackRequest is the body of the requests polling the HEC ack endpoint.
+type ackRequest struct {
+	Acks []uint64 `json:"acks"`
+}
+
+// ackStatusResponse is the response of the HEC ack endpoint.
+type ackStatusResponse struct {
+	Acks map[string]bool `json:"acks"`
+}
+
+// ackTracker tracks the outstanding acknowledgement IDs of the events sent with indexer
+// acknowledgement enabled, polling their status from the HEC ack endpoint in batches.
+type ackTracker struct {
+	url       *url.URL
+	client    *http.Client
+	headers   map[string]string
+	logger    *zap.Logger
+	settings  IndexerAckSettings
+	mu        sync.Mutex
+	pending   map[uint64]chan struct{}
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	startOnce sync.Once
+}
+
+func newAckTracker(hecURL *url.URL, client *http.Client, headers map[string]string, settings IndexerAckSettings, logger *zap.Logger) *ackTracker {
+	return &ackTracker{
+		url:      getAckURL(hecURL),
+		client:   client,
+		headers:  headers,
+		logger:   logger,
+		settings: settings,
+		pending:  make(map[uint64]chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// getAckURL returns the URL of the HEC ack endpoint, which is next to the event and raw endpoints.
+func getAckURL(hecURL *url.URL) *url.URL {
+	ackURL := *hecURL
+	ackURL.RawQuery = ""
+	if i := strings.Index(ackURL.Path, hecPath); i >= 0 {
+		ackURL.Path = path.Join(ackURL.Path[:i+len(hecPath)], ackPath)
+	} else {
+		ackURL.Path = path.Join(ackURL.Path, ackPath)
+	}
+	return &ackURL
+}
+
+// newChannelID returns a random (version 4) UUID identifying the HEC channel of the exporter.
+func newChannelID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// start starts polling the status of the outstanding acknowledgement IDs.
+func (t *ackTracker) start() {
+	t.startOnce.Do(func() {
+		t.wg.Add(1)
+		go t.pollLoop()
+	})
+}
+
+// stop stops polling, the events waiting for their acknowledgement time out.
+func (t *ackTracker) stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopCh)
+	})
+	t.wg.Wait()
+}
+
+// waitForAck waits for the acknowledgement ID to be acknowledged, returning a retryable
+// error if it is not within the configured timeout.
+func (t *ackTracker) waitForAck(ctx context.Context, ackID uint64) error {
+	acked := make(chan struct{})
+	t.mu.Lock()
+	t.pending[ackID] = acked
+	t.mu.Unlock()
+
+	timer := time.NewTimer(t.settings.Timeout)
+	defer timer.Stop()
+
+	select {
+	case <-acked:
+		return nil
+	case <-timer.C:
+		t.remove(ackID)
+		return fmt.Errorf("timed out waiting for the indexer acknowledgement of ack ID %d", ackID)
+	case <-ctx.Done():
+		t.remove(ackID)
+		return ctx.Err()
+	}
+}
+
+func (t *ackTracker) remove(ackID uint64) {
+	t.mu.Lock()
+	delete(t.pending, ackID)
+	t.mu.Unlock()
+}
+
+func (t *ackTracker) pollLoop() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.settings.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case <-ticker.C:
+			if err := t.poll(context.Background()); err != nil {
+				t.logger.Debug("Failed to poll the indexer acknowledgement status", zap.Error(err))
+			}
+		}
+	}
+}
+
+// poll queries the status of the outstanding acknowledgement IDs, in batches of up to
+// PollBatchSize IDs, and releases the events that are acknowledged.
+func (t *ackTracker) poll(ctx context.Context) error {
+	t.mu.Lock()
+	ackIDs := make([]uint64, 0, len(t.pending))
+	for ackID := range t.pending {
+		ackIDs = append(ackIDs, ackID)
+	}
+	t.mu.Unlock()
+
+	for len(ackIDs) > 0 {
+		n := len(ackIDs)
+		if n > int(t.settings.PollBatchSize) {
+			n = int(t.settings.PollBatchSize)
+		}
+		status, err := t.queryStatus(ctx, ackIDs[:n])
+		if err != nil {
+			return err
+		}
+		ackIDs = ackIDs[n:]
+
+		t.mu.Lock()
+		for id, acked := range status.Acks {
+			ackID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil || !acked {
+				continue
+			}
+			if ch, ok := t.pending[ackID]; ok {
+				close(ch)
+				delete(t.pending, ackID)
+			}
+		}
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+func (t *ackTracker) queryStatus(ctx context.Context, ackIDs []uint64) (*ackStatusResponse, error) {
+	body, err := json.Marshal(ackRequest{Acks: ackIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = splunk.HandleHTTPCode(resp); err != nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil, err
+	}
+
+	var status ackStatusResponse
+	if err = json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode the indexer acknowledgement status: %w", err)
+	}
+	return &status, nil
+}
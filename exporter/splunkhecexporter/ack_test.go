@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// fakeHEC is a HEC server with indexer acknowledgement enabled.
+type fakeHEC struct {
+	mu       sync.Mutex
+	nextAck  uint64
+	ack      bool
+	noAckID  bool
+	channels map[string]bool
+	polls    [][]uint64
+}
+
+func (f *fakeHEC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.channels[r.Header.Get(channelHeader)] = true
+
+	if strings.HasSuffix(r.URL.Path, "/ack") {
+		var req ackRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.polls = append(f.polls, req.Acks)
+		acks := map[string]bool{}
+		for _, id := range req.Acks {
+			acks[fmt.Sprint(id)] = f.ack
+		}
+		json.NewEncoder(w).Encode(ackStatusResponse{Acks: acks})
+		return
+	}
+
+	if f.noAckID {
+		w.Write([]byte(`{"text":"Success","code":0}`))
+		return
+	}
+	fmt.Fprintf(w, `{"text":"Success","code":0,"ackId":%d}`, f.nextAck)
+	f.nextAck++
+}
+
+func newAckClient(t *testing.T, hec *fakeHEC, settings IndexerAckSettings) *client {
+	server := httptest.NewServer(hec)
+	t.Cleanup(server.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/services/collector"
+	cfg.Token = "1234-1234"
+	cfg.IndexerAck = settings
+	cfg.IndexerAck.Enabled = true
+
+	options, err := cfg.getOptionsFromConfig()
+	require.NoError(t, err)
+	c, err := buildClient(options, cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, c.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, c.stop(context.Background()))
+	})
+	return c
+}
+
+func TestIndexerAck(t *testing.T) {
+	hec := &fakeHEC{ack: true, channels: map[string]bool{}}
+	c := newAckClient(t, hec, IndexerAckSettings{
+		PollInterval:  10 * time.Millisecond,
+		PollBatchSize: 10,
+		Timeout:       5 * time.Second,
+	})
+
+	require.NoError(t, c.pushTraceData(context.Background(), createTraceData(3)))
+	require.NoError(t, c.pushMetricsData(context.Background(), createMetricsData(3)))
+
+	hec.mu.Lock()
+	defer hec.mu.Unlock()
+	assert.Len(t, hec.channels, 1)
+	for channel := range hec.channels {
+		assert.Len(t, channel, 36)
+	}
+	assert.NotEmpty(t, hec.polls)
+}
+
+func TestIndexerAckTimeout(t *testing.T) {
+	hec := &fakeHEC{ack: false, channels: map[string]bool{}}
+	c := newAckClient(t, hec, IndexerAckSettings{
+		PollInterval:  10 * time.Millisecond,
+		PollBatchSize: 10,
+		Timeout:       100 * time.Millisecond,
+	})
+
+	err := c.pushTraceData(context.Background(), createTraceData(3))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "timed out waiting for the indexer acknowledgement of ack ID 0")
+
+	c.acks.mu.Lock()
+	defer c.acks.mu.Unlock()
+	assert.Empty(t, c.acks.pending)
+}
+
+func TestIndexerAckNotEnabledOnToken(t *testing.T) {
+	hec := &fakeHEC{noAckID: true, channels: map[string]bool{}}
+	c := newAckClient(t, hec, IndexerAckSettings{
+		PollInterval:  10 * time.Millisecond,
+		PollBatchSize: 10,
+		Timeout:       time.Second,
+	})
+
+	err := c.pushTraceData(context.Background(), createTraceData(3))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestAckTrackerPollBatches(t *testing.T) {
+	hec := &fakeHEC{ack: true, channels: map[string]bool{}}
+	server := httptest.NewServer(hec)
+	defer server.Close()
+
+	hecURL, err := url.Parse(server.URL + "/services/collector/event")
+	require.NoError(t, err)
+	tracker := newAckTracker(hecURL, http.DefaultClient, map[string]string{}, IndexerAckSettings{
+		PollInterval:  time.Hour,
+		PollBatchSize: 2,
+		Timeout:       time.Hour,
+	}, zap.NewNop())
+
+	acked := make([]chan struct{}, 5)
+	for i := range acked {
+		acked[i] = make(chan struct{})
+		tracker.pending[uint64(i)] = acked[i]
+	}
+
+	require.NoError(t, tracker.poll(context.Background()))
+
+	assert.Empty(t, tracker.pending)
+	for _, ch := range acked {
+		select {
+		case <-ch:
+		default:
+			t.Fatal("ack not released")
+		}
+	}
+	require.Len(t, hec.polls, 3)
+	for _, poll := range hec.polls {
+		assert.LessOrEqual(t, len(poll), 2)
+	}
+}
+
+func TestGetAckURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "https://splunk:8088/services/collector", want: "https://splunk:8088/services/collector/ack"},
+		{endpoint: "https://splunk:8088/services/collector/event", want: "https://splunk:8088/services/collector/ack"},
+		{endpoint: "https://splunk:8088/services/collector/raw?channel=1", want: "https://splunk:8088/services/collector/ack"},
+		{endpoint: "https://splunk:8088/custom", want: "https://splunk:8088/custom/ack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			u, err := url.Parse(tt.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, getAckURL(u).String())
+		})
+	}
+}
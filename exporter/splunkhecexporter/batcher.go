@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+// A guesstimated value > length of bytes of a single event.
+// Added to buffer capacity so that buffer is likely to grow by reslicing when buf.Len() > bufCap.
+const bufCapPadding = uint(4096)
+
+// Composite index of a record (log record, metric or span) in pdata.Logs, pdata.Metrics or pdata.Traces.
+type recordIndex struct {
+	// Index in orig list (i.e. root parent index).
+	resource int
+	// Index in InstrumentationLibrary(Logs|Metrics|Spans) list (i.e. immediate parent index).
+	library int
+	// Index in Logs, Metrics or Spans list (i.e. the record index).
+	record int
+}
+
+func (i *recordIndex) zero() bool {
+	return i.resource == 0 && i.library == 0 && i.record == 0
+}
+
+// eventBatcher buffers JSON encoded Splunk events and sends them in batches whose content
+// length is restricted to bufCap.
+type eventBatcher struct {
+	// Kind of the records the events are parsed from, used in error messages.
+	kind string
+	// Buffer capacity.
+	// 0 capacity is interpreted as unknown/unbound consistent with ContentLength in http.Request.
+	bufCap uint
+	// Buffer of JSON encoded Splunk events.
+	// Expected to grow more than bufCap then truncated to bufLen.
+	buf     *bytes.Buffer
+	encoder *json.Encoder
+	// Length of retained bytes in buffer after truncation.
+	bufLen int
+	// Buffer of the event bytes over capacity.
+	tmpBuf *bytes.Buffer
+	// Index of the record of the first event in buffer.
+	bufFront *recordIndex
+	// Callback when each batch is to be sent.
+	send func(context.Context, *bytes.Buffer) error
+	// Errors of the events dropped because they could not be encoded or are larger than bufCap.
+	permanentErrors []error
+}
+
+func newEventBatcher(kind string, bufCap uint, send func(context.Context, *bytes.Buffer) error) *eventBatcher {
+	buf := bytes.NewBuffer(make([]byte, 0, bufCap+bufCapPadding))
+	return &eventBatcher{
+		kind:    kind,
+		bufCap:  bufCap,
+		buf:     buf,
+		encoder: json.NewEncoder(buf),
+		tmpBuf:  bytes.NewBuffer(make([]byte, 0, bufCapPadding)),
+		send:    send,
+	}
+}
+
+// add encodes the event parsed from the record at index idx to the buffer. Once the buffer
+// exceeds its capacity, the events below capacity are sent and the event over capacity starts
+// the next batch. The error of the send callback is returned as is, bufFront then being the
+// index of the first record not sent.
+func (b *eventBatcher) add(ctx context.Context, idx recordIndex, event *splunk.Event) error {
+	if b.bufFront == nil {
+		b.bufFront = &idx
+	}
+
+	// JSON encoding event and writing to buffer.
+	if err := b.encoder.Encode(event); err != nil {
+		b.permanentErrors = append(b.permanentErrors, consumererror.Permanent(fmt.Errorf("dropped %s event: %v, error: %v", b.kind, event, err)))
+		return nil
+	}
+
+	// Continue adding events to buffer up to capacity.
+	if b.buf.Len() <= int(b.bufCap) || b.bufCap == 0 {
+		// Tracking length of event bytes below capacity in buffer.
+		b.bufLen = b.buf.Len()
+		return nil
+	}
+
+	b.tmpBuf.Reset()
+	// Storing event bytes over capacity in buffer before truncating.
+	if over := b.buf.Len() - b.bufLen; over <= int(b.bufCap) {
+		b.tmpBuf.Write(b.buf.Bytes()[b.bufLen:b.buf.Len()])
+	} else {
+		b.permanentErrors = append(b.permanentErrors, consumererror.Permanent(
+			fmt.Errorf("dropped %s event: %s, error: event size %d bytes larger than configured max content length %d bytes", b.kind, string(b.buf.Bytes()[b.bufLen:b.buf.Len()]), over, b.bufCap)))
+	}
+
+	// Truncating buffer at tracked length below capacity and sending.
+	b.buf.Truncate(b.bufLen)
+	if b.buf.Len() > 0 {
+		if err := b.send(ctx, b.buf); err != nil {
+			return err
+		}
+	}
+	b.buf.Reset()
+
+	// Writing truncated bytes back to buffer.
+	b.tmpBuf.WriteTo(b.buf)
+
+	b.bufFront, b.bufLen = nil, b.buf.Len()
+	if b.bufLen > 0 {
+		// The next batch starts with the event over capacity.
+		b.bufFront = &idx
+	}
+	return nil
+}
+
+// flush sends the events remaining in the buffer.
+func (b *eventBatcher) flush(ctx context.Context) error {
+	if b.buf.Len() == 0 {
+		return nil
+	}
+	return b.send(ctx, b.buf)
+}
@@ -19,6 +19,7 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -43,6 +44,7 @@ type client struct {
 	zippers sync.Pool
 	wg      sync.WaitGroup
 	headers map[string]string
+	acks    *ackTracker
 }
 
 // Minimum number of bytes to compress. 1500 is the MTU of an ethernet frame.
@@ -55,38 +57,10 @@ func (c *client) pushMetricsData(
 	c.wg.Add(1)
 	defer c.wg.Done()
 
-	splunkDataPoints, _ := metricDataToSplunk(c.logger, md, c.config)
-	if len(splunkDataPoints) == 0 {
-		return nil
-	}
-
-	body, compressed, err := encodeBodyEvents(&c.zippers, splunkDataPoints, c.config.DisableCompression)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), body)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
-
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
-
-	if compressed {
-		req.Header.Set("Content-Encoding", "gzip")
-	}
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
-	}
-
-	io.Copy(ioutil.Discard, resp.Body)
-	resp.Body.Close()
+	gzipWriter := c.zippers.Get().(*gzip.Writer)
+	defer c.zippers.Put(gzipWriter)
 
-	return splunk.HandleHTTPCode(resp)
+	return c.pushMetricsDataInBatches(ctx, md, c.newBatchSender(gzipWriter, c.config.MaxContentLengthMetrics))
 }
 
 func (c *client) pushTraceData(
@@ -96,21 +70,10 @@ func (c *client) pushTraceData(
 	c.wg.Add(1)
 	defer c.wg.Done()
 
-	splunkEvents, _ := traceDataToSplunk(c.logger, td, c.config)
-	if len(splunkEvents) == 0 {
-		return nil
-	}
-
-	return c.sendSplunkEvents(ctx, splunkEvents)
-}
-
-func (c *client) sendSplunkEvents(ctx context.Context, splunkEvents []*splunk.Event) error {
-	body, compressed, err := encodeBodyEvents(&c.zippers, splunkEvents, c.config.DisableCompression)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
+	gzipWriter := c.zippers.Get().(*gzip.Writer)
+	defer c.zippers.Put(gzipWriter)
 
-	return c.postEvents(ctx, body, compressed)
+	return c.pushTracesDataInBatches(ctx, td, c.newBatchSender(gzipWriter, c.config.MaxContentLengthTraces))
 }
 
 func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
@@ -120,11 +83,16 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 	gzipWriter := c.zippers.Get().(*gzip.Writer)
 	defer c.zippers.Put(gzipWriter)
 
-	gzipBuffer := bytes.NewBuffer(make([]byte, 0, c.config.MaxContentLengthLogs))
+	return c.pushLogDataInBatches(ctx, ld, c.newBatchSender(gzipWriter, c.config.MaxContentLengthLogs))
+}
+
+// newBatchSender returns the callback sending each batch, gzip compressed unless compression
+// is disabled or the batch is shorter than minCompressionLen.
+func (c *client) newBatchSender(gzipWriter *gzip.Writer, maxContentLength uint) func(context.Context, *bytes.Buffer) error {
+	gzipBuffer := bytes.NewBuffer(make([]byte, 0, maxContentLength))
 	gzipWriter.Reset(gzipBuffer)
 
-	// Callback when each batch is to be sent.
-	send := func(ctx context.Context, buf *bytes.Buffer) (err error) {
+	return func(ctx context.Context, buf *bytes.Buffer) (err error) {
 		shouldCompress := buf.Len() >= minCompressionLen && !c.config.DisableCompression
 
 		if shouldCompress {
@@ -144,33 +112,13 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 
 		return c.postEvents(ctx, buf, shouldCompress)
 	}
-
-	return c.pushLogDataInBatches(ctx, ld, send)
 }
 
 // pushLogDataInBatches sends batches of Splunk events in JSON format.
 // The batch content length is restricted to MaxContentLengthLogs.
 // ld log records are parsed to Splunk events.
 func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send func(context.Context, *bytes.Buffer) error) error {
-	// Length of retained bytes in buffer after truncation.
-	var bufLen int
-	// Buffer capacity.
-	var bufCap = c.config.MaxContentLengthLogs
-	// A guesstimated value > length of bytes of a single event.
-	// Added to buffer capacity so that buffer is likely to grow by reslicing when buf.Len() > bufCap.
-	const bufCapPadding = uint(4096)
-
-	// Buffer of JSON encoded Splunk events.
-	// Expected to grow more than bufCap then truncated to bufLen.
-	var buf = bytes.NewBuffer(make([]byte, 0, bufCap+bufCapPadding))
-	var encoder = json.NewEncoder(buf)
-
-	var tmpBuf = bytes.NewBuffer(make([]byte, 0, bufCapPadding))
-
-	// Index of the log record of the first event in buffer.
-	var bufFront *logIndex
-
-	var permanentErrors []error
+	batcher := newEventBatcher("log", c.config.MaxContentLengthLogs, send)
 
 	var rls = ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
@@ -179,61 +127,79 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send f
 		for j := 0; j < ills.Len(); j++ {
 			logs := ills.At(j).Logs()
 			for k := 0; k < logs.Len(); k++ {
-				if bufFront == nil {
-					bufFront = &logIndex{resource: i, library: j, record: k}
-				}
-
 				// Parsing log record to Splunk event.
 				event := mapLogRecordToSplunkEvent(res, logs.At(k), c.config, c.logger)
-				// JSON encoding event and writing to buffer.
-				if err := encoder.Encode(event); err != nil {
-					permanentErrors = append(permanentErrors, consumererror.Permanent(fmt.Errorf("dropped log event: %v, error: %v", event, err)))
-					continue
+				if err := batcher.add(ctx, recordIndex{resource: i, library: j, record: k}, event); err != nil {
+					return consumererror.NewLogs(err, *subLogs(&ld, batcher.bufFront))
 				}
+			}
+		}
+	}
 
-				// Continue adding events to buffer up to capacity.
-				// 0 capacity is interpreted as unknown/unbound consistent with ContentLength in http.Request.
-				if buf.Len() <= int(bufCap) || bufCap == 0 {
-					// Tracking length of event bytes below capacity in buffer.
-					bufLen = buf.Len()
-					continue
-				}
+	if err := batcher.flush(ctx); err != nil {
+		return consumererror.NewLogs(err, *subLogs(&ld, batcher.bufFront))
+	}
 
-				tmpBuf.Reset()
-				// Storing event bytes over capacity in buffer before truncating.
-				if bufCap > 0 {
-					if over := buf.Len() - bufLen; over <= int(bufCap) {
-						tmpBuf.Write(buf.Bytes()[bufLen:buf.Len()])
-					} else {
-						permanentErrors = append(permanentErrors, consumererror.Permanent(
-							fmt.Errorf("dropped log event: %s, error: event size %d bytes larger than configured max content length %d bytes", string(buf.Bytes()[bufLen:buf.Len()]), over, bufCap)))
-					}
-				}
+	return consumererror.Combine(batcher.permanentErrors)
+}
 
-				// Truncating buffer at tracked length below capacity and sending.
-				buf.Truncate(bufLen)
-				if buf.Len() > 0 {
-					if err := send(ctx, buf); err != nil {
-						return consumererror.NewLogs(err, *subLogs(&ld, bufFront))
+// pushMetricsDataInBatches sends batches of Splunk events in JSON format.
+// The batch content length is restricted to MaxContentLengthMetrics.
+// md metrics are parsed to Splunk events.
+func (c *client) pushMetricsDataInBatches(ctx context.Context, md pdata.Metrics, send func(context.Context, *bytes.Buffer) error) error {
+	batcher := newEventBatcher("metric", c.config.MaxContentLengthMetrics, send)
+
+	var rms = md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		res := rms.At(i).Resource()
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				// Parsing metric to Splunk events, one per data point, bucket or quantile.
+				for _, event := range mapMetricToSplunkEvent(res, metrics.At(k), c.config, c.logger) {
+					if err := batcher.add(ctx, recordIndex{resource: i, library: j, record: k}, event); err != nil {
+						return consumererror.NewMetrics(err, *subMetrics(&md, batcher.bufFront))
 					}
 				}
-				buf.Reset()
+			}
+		}
+	}
 
-				// Writing truncated bytes back to buffer.
-				tmpBuf.WriteTo(buf)
+	if err := batcher.flush(ctx); err != nil {
+		return consumererror.NewMetrics(err, *subMetrics(&md, batcher.bufFront))
+	}
+
+	return consumererror.Combine(batcher.permanentErrors)
+}
 
-				bufFront, bufLen = nil, buf.Len()
+// pushTracesDataInBatches sends batches of Splunk events in JSON format.
+// The batch content length is restricted to MaxContentLengthTraces.
+// td spans are parsed to Splunk events.
+func (c *client) pushTracesDataInBatches(ctx context.Context, td pdata.Traces, send func(context.Context, *bytes.Buffer) error) error {
+	batcher := newEventBatcher("span", c.config.MaxContentLengthTraces, send)
+
+	var rss = td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		res := rss.At(i).Resource()
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				// Parsing span to Splunk event.
+				event := mapSpanToSplunkEvent(res, spans.At(k), c.config, c.logger)
+				if err := batcher.add(ctx, recordIndex{resource: i, library: j, record: k}, event); err != nil {
+					return consumererror.NewTraces(err, *subTraces(&td, batcher.bufFront))
+				}
 			}
 		}
 	}
 
-	if buf.Len() > 0 {
-		if err := send(ctx, buf); err != nil {
-			return consumererror.NewLogs(err, *subLogs(&ld, bufFront))
-		}
+	if err := batcher.flush(ctx); err != nil {
+		return consumererror.NewTraces(err, *subTraces(&td, batcher.bufFront))
 	}
 
-	return consumererror.Combine(permanentErrors)
+	return consumererror.Combine(batcher.permanentErrors)
 }
 
 func (c *client) postEvents(ctx context.Context, events io.Reader, compressed bool) error {
@@ -258,13 +224,24 @@ func (c *client) postEvents(ctx context.Context, events io.Reader, compressed bo
 
 	err = splunk.HandleHTTPCode(resp)
 
-	io.Copy(ioutil.Discard, resp.Body)
+	if err != nil || c.acks == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
 
-	return err
+	var ackResp ackResponse
+	if err = json.NewDecoder(resp.Body).Decode(&ackResp); err != nil {
+		return fmt.Errorf("failed to decode the HEC response: %w", err)
+	}
+	if ackResp.AckID == nil {
+		return consumererror.Permanent(errors.New("no ack ID in the HEC response, indexer acknowledgement must be enabled on the HEC token"))
+	}
+
+	return c.acks.waitForAck(ctx, *ackResp.AckID)
 }
 
 // subLogs returns a subset of `ld` starting from index `from` to the end.
-func subLogs(ld *pdata.Logs, from *logIndex) *pdata.Logs {
+func subLogs(ld *pdata.Logs, from *recordIndex) *pdata.Logs {
 	if ld == nil || from == nil || from.zero() {
 		return ld
 	}
@@ -309,42 +286,109 @@ func subLogs(ld *pdata.Logs, from *logIndex) *pdata.Logs {
 	return &subset
 }
 
-func encodeBodyEvents(zippers *sync.Pool, evs []*splunk.Event, disableCompression bool) (bodyReader io.Reader, compressed bool, err error) {
-	buf := new(bytes.Buffer)
-	encoder := json.NewEncoder(buf)
-	for _, e := range evs {
-		err := encoder.Encode(e)
-		if err != nil {
-			return nil, false, err
+// subMetrics returns a subset of `md` starting from index `from` to the end.
+func subMetrics(md *pdata.Metrics, from *recordIndex) *pdata.Metrics {
+	if md == nil || from == nil || from.zero() {
+		return md
+	}
+
+	subset := pdata.NewMetrics()
+
+	resources := md.ResourceMetrics()
+	resourcesSub := subset.ResourceMetrics()
+
+	for i := from.resource; i < resources.Len(); i++ {
+		resourcesSub.AppendEmpty()
+		resources.At(i).Resource().CopyTo(resourcesSub.At(i - from.resource).Resource())
+
+		libraries := resources.At(i).InstrumentationLibraryMetrics()
+		librariesSub := resourcesSub.At(i - from.resource).InstrumentationLibraryMetrics()
+
+		j := 0
+		if i == from.resource {
+			j = from.library
+		}
+		for jSub := 0; j < libraries.Len(); j++ {
+			librariesSub.AppendEmpty()
+			libraries.At(j).InstrumentationLibrary().CopyTo(librariesSub.At(jSub).InstrumentationLibrary())
+
+			metrics := libraries.At(j).Metrics()
+			metricsSub := librariesSub.At(jSub).Metrics()
+			jSub++
+
+			k := 0
+			if i == from.resource && j == from.library {
+				k = from.record
+			}
+
+			for kSub := 0; k < metrics.Len(); k++ { //revive:disable-line:var-naming
+				metricsSub.AppendEmpty()
+				metrics.At(k).CopyTo(metricsSub.At(kSub))
+				kSub++
+			}
 		}
 	}
-	return getReader(zippers, buf, disableCompression)
+
+	return &subset
 }
 
-// avoid attempting to compress things that fit into a single ethernet frame
-func getReader(zippers *sync.Pool, b *bytes.Buffer, disableCompression bool) (io.Reader, bool, error) {
-	var err error
-	if !disableCompression && b.Len() > minCompressionLen {
-		buf := new(bytes.Buffer)
-		w := zippers.Get().(*gzip.Writer)
-		defer zippers.Put(w)
-		w.Reset(buf)
-		_, err = w.Write(b.Bytes())
-		if err == nil {
-			err = w.Close()
-			if err == nil {
-				return buf, true, nil
+// subTraces returns a subset of `td` starting from index `from` to the end.
+func subTraces(td *pdata.Traces, from *recordIndex) *pdata.Traces {
+	if td == nil || from == nil || from.zero() {
+		return td
+	}
+
+	subset := pdata.NewTraces()
+
+	resources := td.ResourceSpans()
+	resourcesSub := subset.ResourceSpans()
+
+	for i := from.resource; i < resources.Len(); i++ {
+		resourcesSub.AppendEmpty()
+		resources.At(i).Resource().CopyTo(resourcesSub.At(i - from.resource).Resource())
+
+		libraries := resources.At(i).InstrumentationLibrarySpans()
+		librariesSub := resourcesSub.At(i - from.resource).InstrumentationLibrarySpans()
+
+		j := 0
+		if i == from.resource {
+			j = from.library
+		}
+		for jSub := 0; j < libraries.Len(); j++ {
+			librariesSub.AppendEmpty()
+			libraries.At(j).InstrumentationLibrary().CopyTo(librariesSub.At(jSub).InstrumentationLibrary())
+
+			spans := libraries.At(j).Spans()
+			spansSub := librariesSub.At(jSub).Spans()
+			jSub++
+
+			k := 0
+			if i == from.resource && j == from.library {
+				k = from.record
+			}
+
+			for kSub := 0; k < spans.Len(); k++ { //revive:disable-line:var-naming
+				spansSub.AppendEmpty()
+				spans.At(k).CopyTo(spansSub.At(kSub))
+				kSub++
 			}
 		}
 	}
-	return b, false, err
+
+	return &subset
 }
 
 func (c *client) stop(context.Context) error {
 	c.wg.Wait()
+	if c.acks != nil {
+		c.acks.stop()
+	}
 	return nil
 }
 
 func (c *client) start(context.Context, component.Host) (err error) {
+	if c.acks != nil {
+		c.acks.start()
+	}
 	return nil
 }
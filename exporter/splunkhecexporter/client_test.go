@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
@@ -552,17 +553,17 @@ func TestInvalidJson(t *testing.T) {
 	badEvent := badJSON{
 		Foo: math.Inf(1),
 	}
-	syncPool := sync.Pool{New: func() interface{} {
-		return gzip.NewWriter(nil)
-	}}
-	evs := []*splunk.Event{
-		{
-			Event: badEvent,
-		},
-		nil,
-	}
-	reader, _, err := encodeBodyEvents(&syncPool, evs, false)
-	assert.Error(t, err, reader)
+	sent := false
+	batcher := newEventBatcher("log", 0, func(context.Context, *bytes.Buffer) error {
+		sent = true
+		return nil
+	})
+	require.NoError(t, batcher.add(context.Background(), recordIndex{}, &splunk.Event{Event: badEvent}))
+	require.NoError(t, batcher.flush(context.Background()))
+	assert.False(t, sent)
+	require.Len(t, batcher.permanentErrors, 1)
+	assert.True(t, consumererror.IsPermanent(batcher.permanentErrors[0]))
+	assert.Contains(t, batcher.permanentErrors[0].Error(), "json: unsupported value: +Inf")
 }
 
 func TestStartAlwaysReturnsNil(t *testing.T) {
@@ -572,35 +573,29 @@ func TestStartAlwaysReturnsNil(t *testing.T) {
 }
 
 func TestInvalidJsonClient(t *testing.T) {
-	badEvent := badJSON{
-		Foo: math.Inf(1),
-	}
-	evs := []*splunk.Event{
-		{
-			Event: badEvent,
-		},
-		nil,
-	}
+	ld := pdata.NewLogs()
+	logRecord := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	logRecord.Body().SetDoubleVal(math.Inf(1))
 	c := client{
 		url: nil,
 		zippers: sync.Pool{New: func() interface{} {
 			return gzip.NewWriter(nil)
 		}},
 		config: &Config{},
+		logger: zap.NewNop(),
 	}
-	err := c.sendSplunkEvents(context.Background(), evs)
-	assert.EqualError(t, err, "Permanent error: json: unsupported value: +Inf")
+	err := c.pushLogData(context.Background(), ld)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "json: unsupported value: +Inf")
 }
 
 func TestInvalidURLClient(t *testing.T) {
 	c := client{
-		url: &url.URL{Host: "in va lid"},
-		zippers: sync.Pool{New: func() interface{} {
-			return gzip.NewWriter(nil)
-		}},
+		url:    &url.URL{Host: "in va lid"},
 		config: &Config{},
 	}
-	err := c.sendSplunkEvents(context.Background(), []*splunk.Event{})
+	err := c.postEvents(context.Background(), bytes.NewBuffer(nil), false)
 	assert.EqualError(t, err, "Permanent error: parse \"//in%20va%20lid\": invalid URL escape \"%20\"")
 }
 
@@ -788,7 +783,7 @@ func TestSubLogs(t *testing.T) {
 	logs := createLogData(2, 2, 3)
 
 	// Logs subset from leftmost index (resource 0, library 0, record 0).
-	_0_0_0 := &logIndex{resource: 0, library: 0, record: 0} //revive:disable-line:var-naming
+	_0_0_0 := &recordIndex{resource: 0, library: 0, record: 0} //revive:disable-line:var-naming
 	got := subLogs(&logs, _0_0_0)
 
 	// Number of logs in subset should equal original logs.
@@ -800,7 +795,7 @@ func TestSubLogs(t *testing.T) {
 	assert.Equal(t, "1_1_2", got.ResourceLogs().At(1).InstrumentationLibraryLogs().At(1).Logs().At(2).Name())
 
 	// Logs subset from some mid index (resource 0, library 1, log 2).
-	_0_1_2 := &logIndex{resource: 0, library: 1, record: 2} //revive:disable-line:var-naming
+	_0_1_2 := &recordIndex{resource: 0, library: 1, record: 2} //revive:disable-line:var-naming
 	got = subLogs(&logs, _0_1_2)
 
 	assert.Equal(t, 7, got.LogRecordCount())
@@ -811,7 +806,7 @@ func TestSubLogs(t *testing.T) {
 	assert.Equal(t, "1_1_2", got.ResourceLogs().At(1).InstrumentationLibraryLogs().At(1).Logs().At(2).Name())
 
 	// Logs subset from rightmost index (resource 1, library 1, log 2).
-	_1_1_2 := &logIndex{resource: 1, library: 1, record: 2} //revive:disable-line:var-naming
+	_1_1_2 := &recordIndex{resource: 1, library: 1, record: 2} //revive:disable-line:var-naming
 	got = subLogs(&logs, _1_1_2)
 
 	// Number of logs in subset should be 1.
@@ -821,6 +816,126 @@ func TestSubLogs(t *testing.T) {
 	assert.Equal(t, "1_1_2", got.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 }
 
+func Test_pushMetricsData_Batches(t *testing.T) {
+	c := client{
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+		config: NewFactory().CreateDefaultConfig().(*Config),
+	}
+	// Each gauge data point is encoded to a ~230 bytes event.
+	c.config.MaxContentLengthMetrics = 500
+
+	md := createMetricsData(10)
+
+	var batches []int
+	send := func(_ context.Context, buf *bytes.Buffer) error {
+		assert.LessOrEqual(t, buf.Len(), 500)
+		if len(batches) == 2 {
+			return errors.New("failed to send")
+		}
+		batches = append(batches, bytes.Count(buf.Bytes(), []byte("\n")))
+		return nil
+	}
+
+	err := c.pushMetricsDataInBatches(context.Background(), md, send)
+	require.Error(t, err)
+	assert.IsType(t, consumererror.Metrics{}, err)
+	require.Equal(t, []int{2, 2}, batches)
+
+	// Only the metrics of the failed batch onwards are retried.
+	failed := err.(consumererror.Metrics).GetMetrics()
+	assert.Equal(t, 6, failed.MetricCount())
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(4, 4*time.Millisecond.Nanoseconds())),
+		failed.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Timestamp())
+}
+
+func Test_pushTraceData_Batches(t *testing.T) {
+	c := client{
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+		config: NewFactory().CreateDefaultConfig().(*Config),
+	}
+	// Each span is encoded to a ~300 bytes event.
+	c.config.MaxContentLengthTraces = 700
+
+	td := createTraceData(10)
+
+	var batches []int
+	send := func(_ context.Context, buf *bytes.Buffer) error {
+		assert.LessOrEqual(t, buf.Len(), 700)
+		if len(batches) == 3 {
+			return errors.New("failed to send")
+		}
+		batches = append(batches, bytes.Count(buf.Bytes(), []byte("\n")))
+		return nil
+	}
+
+	err := c.pushTracesDataInBatches(context.Background(), td, send)
+	require.Error(t, err)
+	assert.IsType(t, consumererror.Traces{}, err)
+	require.Equal(t, []int{2, 2, 2}, batches)
+
+	// Only the spans of the failed batch onwards are retried.
+	failed := err.(consumererror.Traces).GetTraces()
+	assert.Equal(t, 4, failed.SpanCount())
+	assert.Equal(t, pdata.Timestamp(7e9),
+		failed.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).StartTimestamp())
+}
+
+func Test_pushMetricsData_Small_MaxContentLength(t *testing.T) {
+	c := client{
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+		config: NewFactory().CreateDefaultConfig().(*Config),
+	}
+	c.config.MaxContentLengthMetrics = 1
+
+	md := createMetricsData(10)
+
+	for _, disable := range []bool{true, false} {
+		c.config.DisableCompression = disable
+
+		err := c.pushMetricsData(context.Background(), md)
+		require.Error(t, err)
+
+		assert.True(t, consumererror.IsPermanent(err))
+		assert.Contains(t, err.Error(), "dropped metric event")
+	}
+}
+
+func TestSubMetrics(t *testing.T) {
+	// Creating 4 metrics in 1 resource and 4 libraries.
+	md := createMetricsData(4)
+
+	got := subMetrics(&md, &recordIndex{resource: 0, library: 0, record: 0})
+	assert.Equal(t, md, *got)
+
+	got = subMetrics(&md, &recordIndex{resource: 0, library: 2, record: 0})
+	assert.Equal(t, 2, got.MetricCount())
+	k0, _ := got.ResourceMetrics().At(0).Resource().Attributes().Get("k0")
+	assert.Equal(t, "v0", k0.StringVal())
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(2, 2*time.Millisecond.Nanoseconds())),
+		got.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Timestamp())
+}
+
+func TestSubTraces(t *testing.T) {
+	// Creating 4 spans in 1 resource and 1 library.
+	td := createTraceData(4)
+
+	got := subTraces(&td, &recordIndex{resource: 0, library: 0, record: 0})
+	assert.Equal(t, td, *got)
+
+	got = subTraces(&td, &recordIndex{resource: 0, library: 0, record: 3})
+	assert.Equal(t, 1, got.SpanCount())
+	res, _ := got.ResourceSpans().At(0).Resource().Attributes().Get("resource")
+	assert.Equal(t, "R1", res.StringVal())
+	assert.Equal(t, pdata.Timestamp(4e9),
+		got.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).StartTimestamp())
+}
+
 // validateCompressedEqual validates that GZipped `got` contains `expected` string
 func validateCompressedEqual(t *testing.T, expected string, got []byte) {
 	z, err := gzip.NewReader(bytes.NewReader(got))
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -27,8 +28,10 @@ import (
 
 const (
 	// hecPath is the default HEC path on the Splunk instance.
-	hecPath                   = "services/collector"
-	maxContentLengthLogsLimit = 2 * 1024 * 1024
+	hecPath                      = "services/collector"
+	maxContentLengthLogsLimit    = 2 * 1024 * 1024
+	maxContentLengthMetricsLimit = 2 * 1024 * 1024
+	maxContentLengthTracesLimit  = 2 * 1024 * 1024
 )
 
 // Config defines configuration for Splunk exporter.
@@ -63,6 +66,12 @@ type Config struct {
 	// Maximum log data size in bytes per HTTP post. Defaults to the backend limit of 2097152 bytes (2MiB).
 	MaxContentLengthLogs uint `mapstructure:"max_content_length_logs"`
 
+	// Maximum metric data size in bytes per HTTP post. Defaults to the backend limit of 2097152 bytes (2MiB).
+	MaxContentLengthMetrics uint `mapstructure:"max_content_length_metrics"`
+
+	// Maximum trace data size in bytes per HTTP post. Defaults to the backend limit of 2097152 bytes (2MiB).
+	MaxContentLengthTraces uint `mapstructure:"max_content_length_traces"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.TLSClientSetting `mapstructure:",squash"`
 
@@ -71,6 +80,25 @@ type Config struct {
 
 	// App version is used to track telemetry information for Splunk App's using HEC by App version. Defaults to the current OpenTelemetry Collector Contrib build version.
 	SplunkAppVersion string `mapstructure:"splunk_app_version"`
+
+	// IndexerAck configures the HEC indexer acknowledgement: https://docs.splunk.com/Documentation/Splunk/latest/Data/AboutHECIDXAck.
+	IndexerAck IndexerAckSettings `mapstructure:"indexer_ack"`
+}
+
+// IndexerAckSettings defines the settings of the HEC indexer acknowledgement.
+type IndexerAckSettings struct {
+	// Enabled makes the exporter wait for the data to be indexed before reporting it as sent.
+	// Requires indexer acknowledgement to be enabled on the HEC token. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// PollInterval is the interval between two polls of the acknowledgement status. Defaults to 1s.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// PollBatchSize is the maximum number of acknowledgement IDs queried per poll. Defaults to 1000.
+	PollBatchSize uint `mapstructure:"poll_batch_size"`
+
+	// Timeout is the time to wait for an acknowledgement before retrying the data. Defaults to 30s.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -102,6 +130,26 @@ func (cfg *Config) validateConfig() error {
 		return fmt.Errorf(`requires "max_content_length_logs" <= %d`, maxContentLengthLogsLimit)
 	}
 
+	if cfg.MaxContentLengthMetrics > maxContentLengthMetricsLimit {
+		return fmt.Errorf(`requires "max_content_length_metrics" <= %d`, maxContentLengthMetricsLimit)
+	}
+
+	if cfg.MaxContentLengthTraces > maxContentLengthTracesLimit {
+		return fmt.Errorf(`requires "max_content_length_traces" <= %d`, maxContentLengthTracesLimit)
+	}
+
+	if cfg.IndexerAck.Enabled {
+		if cfg.IndexerAck.PollInterval <= 0 {
+			return errors.New(`requires "indexer_ack.poll_interval" > 0`)
+		}
+		if cfg.IndexerAck.PollBatchSize == 0 {
+			return errors.New(`requires "indexer_ack.poll_batch_size" > 0`)
+		}
+		if cfg.IndexerAck.Timeout <= 0 {
+			return errors.New(`requires "indexer_ack.timeout" > 0`)
+		}
+	}
+
 	return nil
 }
 
@@ -51,16 +51,18 @@ func TestLoadConfig(t *testing.T) {
 
 	e1 := cfg.Exporters[config.NewIDWithName(typeStr, "allsettings")]
 	expectedCfg := Config{
-		ExporterSettings:     config.NewExporterSettings(config.NewIDWithName(typeStr, "allsettings")),
-		Token:                "00000000-0000-0000-0000-0000000000000",
-		Endpoint:             "https://splunk:8088/services/collector",
-		Source:               "otel",
-		SourceType:           "otel",
-		Index:                "metrics",
-		SplunkAppName:        "OpenTelemetry-Collector Splunk Exporter",
-		SplunkAppVersion:     "v0.0.1",
-		MaxConnections:       100,
-		MaxContentLengthLogs: 2 * 1024 * 1024,
+		ExporterSettings:        config.NewExporterSettings(config.NewIDWithName(typeStr, "allsettings")),
+		Token:                   "00000000-0000-0000-0000-0000000000000",
+		Endpoint:                "https://splunk:8088/services/collector",
+		Source:                  "otel",
+		SourceType:              "otel",
+		Index:                   "metrics",
+		SplunkAppName:           "OpenTelemetry-Collector Splunk Exporter",
+		SplunkAppVersion:        "v0.0.1",
+		MaxConnections:          100,
+		MaxContentLengthLogs:    2 * 1024 * 1024,
+		MaxContentLengthMetrics: 1024 * 1024,
+		MaxContentLengthTraces:  1024 * 1024,
 		TimeoutSettings: exporterhelper.TimeoutSettings{
 			Timeout: 10 * time.Second,
 		},
@@ -83,6 +85,12 @@ func TestLoadConfig(t *testing.T) {
 			},
 			InsecureSkipVerify: false,
 		},
+		IndexerAck: IndexerAckSettings{
+			Enabled:       true,
+			PollInterval:  2 * time.Second,
+			PollBatchSize: 100,
+			Timeout:       time.Minute,
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -94,12 +102,15 @@ func TestLoadConfig(t *testing.T) {
 
 func TestConfig_getOptionsFromConfig(t *testing.T) {
 	type fields struct {
-		Endpoint             string
-		Token                string
-		Source               string
-		SourceType           string
-		Index                string
-		MaxContentLengthLogs uint
+		Endpoint                string
+		Token                   string
+		Source                  string
+		SourceType              string
+		Index                   string
+		MaxContentLengthLogs    uint
+		MaxContentLengthMetrics uint
+		MaxContentLengthTraces  uint
+		IndexerAck              IndexerAckSettings
 	}
 	tests := []struct {
 		name    string
@@ -154,16 +165,69 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test max content length metrics greater than limit",
+			fields: fields{
+				Token:                   "1234",
+				Endpoint:                "https://example.com:8000",
+				MaxContentLengthMetrics: maxContentLengthMetricsLimit + 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test max content length traces greater than limit",
+			fields: fields{
+				Token:                  "1234",
+				Endpoint:               "https://example.com:8000",
+				MaxContentLengthTraces: maxContentLengthTracesLimit + 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test indexer ack without poll interval",
+			fields: fields{
+				Token:      "1234",
+				Endpoint:   "https://example.com:8000",
+				IndexerAck: IndexerAckSettings{Enabled: true, PollBatchSize: 10, Timeout: time.Second},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test indexer ack without poll batch size",
+			fields: fields{
+				Token:      "1234",
+				Endpoint:   "https://example.com:8000",
+				IndexerAck: IndexerAckSettings{Enabled: true, PollInterval: time.Second, Timeout: time.Second},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test indexer ack without timeout",
+			fields: fields{
+				Token:      "1234",
+				Endpoint:   "https://example.com:8000",
+				IndexerAck: IndexerAckSettings{Enabled: true, PollInterval: time.Second, PollBatchSize: 10},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := &Config{
-				Token:                tt.fields.Token,
-				Endpoint:             tt.fields.Endpoint,
-				Source:               tt.fields.Source,
-				SourceType:           tt.fields.SourceType,
-				Index:                tt.fields.Index,
-				MaxContentLengthLogs: tt.fields.MaxContentLengthLogs,
+				Token:                   tt.fields.Token,
+				Endpoint:                tt.fields.Endpoint,
+				Source:                  tt.fields.Source,
+				SourceType:              tt.fields.SourceType,
+				Index:                   tt.fields.Index,
+				MaxContentLengthLogs:    tt.fields.MaxContentLengthLogs,
+				MaxContentLengthMetrics: tt.fields.MaxContentLengthMetrics,
+				MaxContentLengthTraces:  tt.fields.MaxContentLengthTraces,
+				IndexerAck:              tt.fields.IndexerAck,
 			}
 			got, err := cfg.getOptionsFromConfig()
 			if (err != nil) != tt.wantErr {
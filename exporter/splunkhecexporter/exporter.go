@@ -96,35 +96,50 @@ func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) (
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve TLS config for Splunk HEC Exporter: %w", err)
 	}
-	return &client{
-		url: options.url,
-		client: &http.Client{
-			Timeout: config.Timeout,
-			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   dialerTimeout,
-					KeepAlive: dialerKeepAlive,
-				}).DialContext,
-				MaxIdleConns:        int(config.MaxConnections),
-				MaxIdleConnsPerHost: int(config.MaxConnections),
-				IdleConnTimeout:     idleConnTimeout,
-				TLSHandshakeTimeout: tlsHandshakeTimeout,
-				TLSClientConfig:     tlsCfg,
-			},
+	headers := map[string]string{
+		"Connection":           "keep-alive",
+		"Content-Type":         "application/json",
+		"User-Agent":           config.SplunkAppName + "/" + config.SplunkAppVersion,
+		"Authorization":        splunk.HECTokenHeader + " " + config.Token,
+		"__splunk_app_name":    config.SplunkAppName,
+		"__splunk_app_version": config.SplunkAppVersion,
+	}
+
+	httpClient := &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   dialerTimeout,
+				KeepAlive: dialerKeepAlive,
+			}).DialContext,
+			MaxIdleConns:        int(config.MaxConnections),
+			MaxIdleConnsPerHost: int(config.MaxConnections),
+			IdleConnTimeout:     idleConnTimeout,
+			TLSHandshakeTimeout: tlsHandshakeTimeout,
+			TLSClientConfig:     tlsCfg,
 		},
+	}
+
+	var acks *ackTracker
+	if config.IndexerAck.Enabled {
+		channel, err := newChannelID()
+		if err != nil {
+			return nil, fmt.Errorf("could not generate the HEC channel ID: %w", err)
+		}
+		headers[channelHeader] = channel
+		acks = newAckTracker(options.url, httpClient, headers, config.IndexerAck, logger)
+	}
+
+	return &client{
+		url:    options.url,
+		client: httpClient,
 		logger: logger,
 		zippers: sync.Pool{New: func() interface{} {
 			return gzip.NewWriter(nil)
 		}},
-		headers: map[string]string{
-			"Connection":           "keep-alive",
-			"Content-Type":         "application/json",
-			"User-Agent":           config.SplunkAppName + "/" + config.SplunkAppVersion,
-			"Authorization":        splunk.HECTokenHeader + " " + config.Token,
-			"__splunk_app_name":    config.SplunkAppName,
-			"__splunk_app_version": config.SplunkAppVersion,
-		},
-		config: config,
+		headers: headers,
+		acks:    acks,
+		config:  config,
 	}, nil
 }
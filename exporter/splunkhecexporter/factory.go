@@ -29,6 +29,10 @@ const (
 	typeStr            = "splunk_hec"
 	defaultMaxIdleCons = 100
 	defaultHTTPTimeout = 10 * time.Second
+
+	defaultAckPollInterval  = time.Second
+	defaultAckPollBatchSize = 1000
+	defaultAckTimeout       = 30 * time.Second
 )
 
 // NewFactory creates a factory for Splunk HEC exporter.
@@ -47,11 +51,18 @@ func createDefaultConfig() config.Exporter {
 		TimeoutSettings: exporterhelper.TimeoutSettings{
 			Timeout: defaultHTTPTimeout,
 		},
-		RetrySettings:        exporterhelper.DefaultRetrySettings(),
-		QueueSettings:        exporterhelper.DefaultQueueSettings(),
-		DisableCompression:   false,
-		MaxConnections:       defaultMaxIdleCons,
-		MaxContentLengthLogs: maxContentLengthLogsLimit,
+		RetrySettings:           exporterhelper.DefaultRetrySettings(),
+		QueueSettings:           exporterhelper.DefaultQueueSettings(),
+		DisableCompression:      false,
+		MaxConnections:          defaultMaxIdleCons,
+		MaxContentLengthLogs:    maxContentLengthLogsLimit,
+		MaxContentLengthMetrics: maxContentLengthMetricsLimit,
+		MaxContentLengthTraces:  maxContentLengthTracesLimit,
+		IndexerAck: IndexerAckSettings{
+			PollInterval:  defaultAckPollInterval,
+			PollBatchSize: defaultAckPollBatchSize,
+			Timeout:       defaultAckTimeout,
+		},
 	}
 }
 
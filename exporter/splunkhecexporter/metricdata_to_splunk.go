@@ -41,178 +41,162 @@ const (
 	bucketSuffix = "_bucket"
 )
 
-func metricDataToSplunk(logger *zap.Logger, data pdata.Metrics, config *Config) ([]*splunk.Event, int) {
-	numDroppedTimeSeries := 0
-	splunkMetrics := make([]*splunk.Event, 0, data.DataPointCount())
-	rms := data.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
-		rm := rms.At(i)
-		host := unknownHostName
-		source := config.Source
-		sourceType := config.SourceType
-		index := config.Index
-		commonFields := map[string]interface{}{}
-		resource := rm.Resource()
-		attributes := resource.Attributes()
-		if conventionHost, isSet := attributes.Get(conventions.AttributeHostName); isSet {
-			host = conventionHost.StringVal()
-		}
-		if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
-			source = sourceSet.StringVal()
+// mapMetricToSplunkEvent returns the Splunk events of the data points of the metric, nil if the
+// metric type is not supported.
+func mapMetricToSplunkEvent(res pdata.Resource, tm pdata.Metric, config *Config, logger *zap.Logger) []*splunk.Event {
+	host := unknownHostName
+	source := config.Source
+	sourceType := config.SourceType
+	index := config.Index
+	commonFields := map[string]interface{}{}
+	attributes := res.Attributes()
+	if conventionHost, isSet := attributes.Get(conventions.AttributeHostName); isSet {
+		host = conventionHost.StringVal()
+	}
+	if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
+		source = sourceSet.StringVal()
+	}
+	if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
+		sourceType = sourcetypeSet.StringVal()
+	}
+	if indexSet, isSet := attributes.Get(splunk.IndexLabel); isSet {
+		index = indexSet.StringVal()
+	}
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		commonFields[k] = tracetranslator.AttributeValueToString(v)
+		return true
+	})
+
+	splunkMetrics := []*splunk.Event{}
+	metricFieldName := splunkMetricValue + ":" + tm.Name()
+	switch tm.DataType() {
+	case pdata.MetricDataTypeGauge:
+		pts := tm.Gauge().DataPoints()
+		for gi := 0; gi < pts.Len(); gi++ {
+			dataPt := pts.At(gi)
+			fields := cloneMap(commonFields)
+			populateLabels(fields, dataPt.LabelsMap())
+			switch dataPt.Type() {
+			case pdata.MetricValueTypeInt:
+				fields[metricFieldName] = dataPt.IntVal()
+			case pdata.MetricValueTypeDouble:
+				fields[metricFieldName] = dataPt.DoubleVal()
+			}
+			fields[splunkMetricTypeKey] = pdata.MetricDataTypeGauge.String()
+			sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+			splunkMetrics = append(splunkMetrics, sm)
 		}
-		if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
-			sourceType = sourcetypeSet.StringVal()
+	case pdata.MetricDataTypeHistogram:
+		pts := tm.Histogram().DataPoints()
+		for gi := 0; gi < pts.Len(); gi++ {
+			dataPt := pts.At(gi)
+			bounds := dataPt.ExplicitBounds()
+			counts := dataPt.BucketCounts()
+			// first, add one event for sum, and one for count
+			{
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				fields[metricFieldName+sumSuffix] = dataPt.Sum()
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
+			}
+			{
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				fields[metricFieldName+countSuffix] = dataPt.Count()
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
+			}
+			// Spec says counts is optional but if present it must have one more
+			// element than the bounds array.
+			if len(counts) == 0 || len(counts) != len(bounds)+1 {
+				continue
+			}
+			value := uint64(0)
+			// now create buckets for each bound.
+			for bi := 0; bi < len(bounds); bi++ {
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				fields["le"] = float64ToDimValue(bounds[bi])
+				value += counts[bi]
+				fields[metricFieldName+bucketSuffix] = value
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
+			}
+			// add an upper bound for +Inf
+			{
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				fields["le"] = float64ToDimValue(math.Inf(1))
+				fields[metricFieldName+bucketSuffix] = value + counts[len(counts)-1]
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
+			}
 		}
-		if indexSet, isSet := attributes.Get(splunk.IndexLabel); isSet {
-			index = indexSet.StringVal()
+	case pdata.MetricDataTypeSum:
+		pts := tm.Sum().DataPoints()
+		for gi := 0; gi < pts.Len(); gi++ {
+			dataPt := pts.At(gi)
+			fields := cloneMap(commonFields)
+			populateLabels(fields, dataPt.LabelsMap())
+			switch dataPt.Type() {
+			case pdata.MetricValueTypeInt:
+				fields[metricFieldName] = dataPt.IntVal()
+			case pdata.MetricValueTypeDouble:
+				fields[metricFieldName] = dataPt.DoubleVal()
+			}
+			fields[splunkMetricTypeKey] = pdata.MetricDataTypeSum.String()
+			sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+			splunkMetrics = append(splunkMetrics, sm)
 		}
-		attributes.Range(func(k string, v pdata.AttributeValue) bool {
-			commonFields[k] = tracetranslator.AttributeValueToString(v)
-			return true
-		})
-
-		rm.Resource().Attributes().Range(func(k string, v pdata.AttributeValue) bool {
-			commonFields[k] = tracetranslator.AttributeValueToString(v)
-			return true
-		})
-		ilms := rm.InstrumentationLibraryMetrics()
-		for ilmi := 0; ilmi < ilms.Len(); ilmi++ {
-			ilm := ilms.At(ilmi)
-			metrics := ilm.Metrics()
-			for tmi := 0; tmi < metrics.Len(); tmi++ {
-				tm := metrics.At(tmi)
-				metricFieldName := splunkMetricValue + ":" + tm.Name()
-				switch tm.DataType() {
-				case pdata.MetricDataTypeGauge:
-					pts := tm.Gauge().DataPoints()
-					for gi := 0; gi < pts.Len(); gi++ {
-						dataPt := pts.At(gi)
-						fields := cloneMap(commonFields)
-						populateLabels(fields, dataPt.LabelsMap())
-						switch dataPt.Type() {
-						case pdata.MetricValueTypeInt:
-							fields[metricFieldName] = dataPt.IntVal()
-						case pdata.MetricValueTypeDouble:
-							fields[metricFieldName] = dataPt.DoubleVal()
-						}
-						fields[splunkMetricTypeKey] = pdata.MetricDataTypeGauge.String()
-						sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-						splunkMetrics = append(splunkMetrics, sm)
-					}
-				case pdata.MetricDataTypeHistogram:
-					pts := tm.Histogram().DataPoints()
-					for gi := 0; gi < pts.Len(); gi++ {
-						dataPt := pts.At(gi)
-						bounds := dataPt.ExplicitBounds()
-						counts := dataPt.BucketCounts()
-						// first, add one event for sum, and one for count
-						{
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							fields[metricFieldName+sumSuffix] = dataPt.Sum()
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
-						{
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							fields[metricFieldName+countSuffix] = dataPt.Count()
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
-						// Spec says counts is optional but if present it must have one more
-						// element than the bounds array.
-						if len(counts) == 0 || len(counts) != len(bounds)+1 {
-							continue
-						}
-						value := uint64(0)
-						// now create buckets for each bound.
-						for bi := 0; bi < len(bounds); bi++ {
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							fields["le"] = float64ToDimValue(bounds[bi])
-							value += counts[bi]
-							fields[metricFieldName+bucketSuffix] = value
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
-						// add an upper bound for +Inf
-						{
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							fields["le"] = float64ToDimValue(math.Inf(1))
-							fields[metricFieldName+bucketSuffix] = value + counts[len(counts)-1]
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeHistogram.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
-					}
-				case pdata.MetricDataTypeSum:
-					pts := tm.Sum().DataPoints()
-					for gi := 0; gi < pts.Len(); gi++ {
-						dataPt := pts.At(gi)
-						fields := cloneMap(commonFields)
-						populateLabels(fields, dataPt.LabelsMap())
-						switch dataPt.Type() {
-						case pdata.MetricValueTypeInt:
-							fields[metricFieldName] = dataPt.IntVal()
-						case pdata.MetricValueTypeDouble:
-							fields[metricFieldName] = dataPt.DoubleVal()
-						}
-						fields[splunkMetricTypeKey] = pdata.MetricDataTypeSum.String()
-						sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-						splunkMetrics = append(splunkMetrics, sm)
-					}
-				case pdata.MetricDataTypeSummary:
-					pts := tm.Summary().DataPoints()
-					for gi := 0; gi < pts.Len(); gi++ {
-						dataPt := pts.At(gi)
-						// first, add one event for sum, and one for count
-						{
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							fields[metricFieldName+sumSuffix] = dataPt.Sum()
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeSummary.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
-						{
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							fields[metricFieldName+countSuffix] = dataPt.Count()
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeSummary.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
+	case pdata.MetricDataTypeSummary:
+		pts := tm.Summary().DataPoints()
+		for gi := 0; gi < pts.Len(); gi++ {
+			dataPt := pts.At(gi)
+			// first, add one event for sum, and one for count
+			{
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				fields[metricFieldName+sumSuffix] = dataPt.Sum()
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeSummary.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
+			}
+			{
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				fields[metricFieldName+countSuffix] = dataPt.Count()
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeSummary.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
+			}
 
-						// now create values for each quantile.
-						for bi := 0; bi < dataPt.QuantileValues().Len(); bi++ {
-							fields := cloneMap(commonFields)
-							populateLabels(fields, dataPt.LabelsMap())
-							dp := dataPt.QuantileValues().At(bi)
-							fields["qt"] = float64ToDimValue(dp.Quantile())
-							fields[metricFieldName+"_"+strconv.FormatFloat(dp.Quantile(), 'f', -1, 64)] = dp.Value()
-							fields[splunkMetricTypeKey] = pdata.MetricDataTypeSummary.String()
-							sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-							splunkMetrics = append(splunkMetrics, sm)
-						}
-					}
-				case pdata.MetricDataTypeNone:
-					fallthrough
-				default:
-					logger.Warn(
-						"Point with unsupported type",
-						zap.Any("metric", rm))
-					numDroppedTimeSeries++
-				}
+			// now create values for each quantile.
+			for bi := 0; bi < dataPt.QuantileValues().Len(); bi++ {
+				fields := cloneMap(commonFields)
+				populateLabels(fields, dataPt.LabelsMap())
+				dp := dataPt.QuantileValues().At(bi)
+				fields["qt"] = float64ToDimValue(dp.Quantile())
+				fields[metricFieldName+"_"+strconv.FormatFloat(dp.Quantile(), 'f', -1, 64)] = dp.Value()
+				fields[splunkMetricTypeKey] = pdata.MetricDataTypeSummary.String()
+				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+				splunkMetrics = append(splunkMetrics, sm)
 			}
 		}
+	case pdata.MetricDataTypeNone:
+		fallthrough
+	default:
+		logger.Warn(
+			"Point with unsupported type",
+			zap.String("metric", tm.Name()))
+		return nil
 	}
 
-	return splunkMetrics, numDroppedTimeSeries
+	return splunkMetrics
 }
 
 func createEvent(timestamp pdata.Timestamp, host string, source string, sourceType string, index string, fields map[string]interface{}) *splunk.Event {
@@ -448,7 +448,23 @@ func Test_metricDataToSplunk(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			md := tt.metricsDataFn()
-			gotMetrics, gotNumDroppedTimeSeries := metricDataToSplunk(logger, md, &Config{})
+			var gotMetrics []*splunk.Event
+			gotNumDroppedTimeSeries := 0
+			rms := md.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				ilms := rms.At(i).InstrumentationLibraryMetrics()
+				for j := 0; j < ilms.Len(); j++ {
+					metrics := ilms.At(j).Metrics()
+					for k := 0; k < metrics.Len(); k++ {
+						events := mapMetricToSplunkEvent(rms.At(i).Resource(), metrics.At(k), &Config{}, logger)
+						if events == nil {
+							gotNumDroppedTimeSeries++
+							continue
+						}
+						gotMetrics = append(gotMetrics, events...)
+					}
+				}
+			}
 			assert.Equal(t, tt.wantNumDroppedTimeseries, gotNumDroppedTimeSeries)
 			for i, want := range tt.wantSplunkMetrics {
 				assert.Equal(t, want, gotMetrics[i])
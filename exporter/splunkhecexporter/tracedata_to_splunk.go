@@ -59,56 +59,40 @@ type hecSpan struct {
 	Links      []hecLink              `json:"links,omitempty"`
 }
 
-func traceDataToSplunk(logger *zap.Logger, data pdata.Traces, config *Config) ([]*splunk.Event, int) {
-	numDroppedSpans := 0
-	splunkEvents := make([]*splunk.Event, 0, data.SpanCount())
-	rss := data.ResourceSpans()
-	for i := 0; i < rss.Len(); i++ {
-		rs := rss.At(i)
-		host := unknownHostName
-		source := config.Source
-		sourceType := config.SourceType
-		index := config.Index
-		commonFields := map[string]interface{}{}
-		resource := rs.Resource()
-		attributes := resource.Attributes()
-		if conventionHost, isSet := attributes.Get(conventions.AttributeHostName); isSet {
-			host = conventionHost.StringVal()
-		}
-		if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
-			source = sourceSet.StringVal()
-		}
-		if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
-			sourceType = sourcetypeSet.StringVal()
-		}
-		if indexSet, isSet := attributes.Get(splunk.IndexLabel); isSet {
-			index = indexSet.StringVal()
-		}
-		attributes.Range(func(k string, v pdata.AttributeValue) bool {
-			commonFields[k] = tracetranslator.AttributeValueToString(v)
-			return true
-		})
-		ilss := rs.InstrumentationLibrarySpans()
-		for sils := 0; sils < ilss.Len(); sils++ {
-			ils := ilss.At(sils)
-			spans := ils.Spans()
-			for si := 0; si < spans.Len(); si++ {
-				span := spans.At(si)
-				se := &splunk.Event{
-					Time:       timestampToSecondsWithMillisecondPrecision(span.StartTimestamp()),
-					Host:       host,
-					Source:     source,
-					SourceType: sourceType,
-					Index:      index,
-					Event:      toHecSpan(logger, span),
-					Fields:     commonFields,
-				}
-				splunkEvents = append(splunkEvents, se)
-			}
-		}
+// mapSpanToSplunkEvent returns the Splunk event of the span.
+func mapSpanToSplunkEvent(resource pdata.Resource, span pdata.Span, config *Config, logger *zap.Logger) *splunk.Event {
+	host := unknownHostName
+	source := config.Source
+	sourceType := config.SourceType
+	index := config.Index
+	commonFields := map[string]interface{}{}
+	attributes := resource.Attributes()
+	if conventionHost, isSet := attributes.Get(conventions.AttributeHostName); isSet {
+		host = conventionHost.StringVal()
 	}
+	if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
+		source = sourceSet.StringVal()
+	}
+	if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
+		sourceType = sourcetypeSet.StringVal()
+	}
+	if indexSet, isSet := attributes.Get(splunk.IndexLabel); isSet {
+		index = indexSet.StringVal()
+	}
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		commonFields[k] = tracetranslator.AttributeValueToString(v)
+		return true
+	})
 
-	return splunkEvents, numDroppedSpans
+	return &splunk.Event{
+		Time:       timestampToSecondsWithMillisecondPrecision(span.StartTimestamp()),
+		Host:       host,
+		Source:     source,
+		SourceType: sourceType,
+		Index:      index,
+		Event:      toHecSpan(logger, span),
+		Fields:     commonFields,
+	}
 }
 
 func toHecSpan(logger *zap.Logger, span pdata.Span) hecSpan {
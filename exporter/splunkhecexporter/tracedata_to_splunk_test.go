@@ -31,10 +31,9 @@ func Test_traceDataToSplunk(t *testing.T) {
 	ts := pdata.Timestamp(123)
 
 	tests := []struct {
-		name                string
-		traceDataFn         func() pdata.Traces
-		wantSplunkEvents    []*splunk.Event
-		wantNumDroppedSpans int
+		name             string
+		traceDataFn      func() pdata.Traces
+		wantSplunkEvents []*splunk.Event
 	}{
 		{
 			name: "valid",
@@ -52,7 +51,6 @@ func Test_traceDataToSplunk(t *testing.T) {
 			wantSplunkEvents: []*splunk.Event{
 				commonSplunkEvent("myspan", ts),
 			},
-			wantNumDroppedSpans: 0,
 		},
 		{
 			name: "empty_rs",
@@ -61,8 +59,7 @@ func Test_traceDataToSplunk(t *testing.T) {
 				traces.ResourceSpans().AppendEmpty()
 				return traces
 			},
-			wantSplunkEvents:    []*splunk.Event{},
-			wantNumDroppedSpans: 0,
+			wantSplunkEvents: []*splunk.Event{},
 		},
 		{
 			name: "empty_ils",
@@ -75,16 +72,24 @@ func Test_traceDataToSplunk(t *testing.T) {
 				rs.InstrumentationLibrarySpans().AppendEmpty()
 				return traces
 			},
-			wantSplunkEvents:    []*splunk.Event{},
-			wantNumDroppedSpans: 0,
+			wantSplunkEvents: []*splunk.Event{},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			traces := tt.traceDataFn()
 
-			gotEvents, gotNumDroppedSpans := traceDataToSplunk(logger, traces, &Config{})
-			assert.Equal(t, tt.wantNumDroppedSpans, gotNumDroppedSpans)
+			gotEvents := []*splunk.Event{}
+			rss := traces.ResourceSpans()
+			for i := 0; i < rss.Len(); i++ {
+				ilss := rss.At(i).InstrumentationLibrarySpans()
+				for j := 0; j < ilss.Len(); j++ {
+					spans := ilss.At(j).Spans()
+					for k := 0; k < spans.Len(); k++ {
+						gotEvents = append(gotEvents, mapSpanToSplunkEvent(rss.At(i).Resource(), spans.At(k), &Config{}, logger))
+					}
+				}
+			}
 			require.Equal(t, len(tt.wantSplunkEvents), len(gotEvents))
 			for i, want := range tt.wantSplunkEvents {
 				assert.EqualValues(t, want, gotEvents[i])
@@ -43,8 +43,8 @@ type Config struct {
 	LogFormat LogFormatType `mapstructure:"log_format"`
 
 	// Metrics related configuration
-	// The format of metrics you will be sending, either graphite or carbon2 or prometheus (Default is prometheus)
-	// Possible values are `carbon2` and `prometheus`
+	// The format of metrics you will be sending, either graphite or carbon2 or prometheus or otlp (Default is prometheus)
+	// Possible values are `graphite`, `carbon2`, `prometheus` and `otlp`
 	MetricFormat MetricFormatType `mapstructure:"metric_format"`
 	// Graphite template.
 	// Placeholders `%{attr_name}` will be replaced with attribute value for attr_name.
@@ -100,6 +100,8 @@ const (
 	Carbon2Format MetricFormatType = "carbon2"
 	// PrometheusFormat represents metric_format: json
 	PrometheusFormat MetricFormatType = "prometheus"
+	// OTLPMetricFormat represents metric_format: otlp
+	OTLPMetricFormat MetricFormatType = "otlp"
 	// GZIPCompression represents compress_encoding: gzip
 	GZIPCompression CompressEncodingType = "gzip"
 	// DeflateCompression represents compress_encoding: deflate
@@ -110,6 +112,8 @@ const (
 	MetricsPipeline PipelineType = "metrics"
 	// LogsPipeline represents metrics pipeline
 	LogsPipeline PipelineType = "logs"
+	// TracesPipeline represents traces pipeline
+	TracesPipeline PipelineType = "traces"
 	// defaultTimeout
 	defaultTimeout time.Duration = 5 * time.Second
 	// DefaultCompress defines default Compress
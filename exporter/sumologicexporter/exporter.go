@@ -47,6 +47,7 @@ func initExporter(cfg *Config) (*sumologicexporter, error) {
 	case GraphiteFormat:
 	case Carbon2Format:
 	case PrometheusFormat:
+	case OTLPMetricFormat:
 	default:
 		return nil, fmt.Errorf("unexpected metric format: %s", cfg.MetricFormat)
 	}
@@ -138,6 +139,28 @@ func newMetricsExporter(
 	)
 }
 
+func newTracesExporter(
+	cfg *Config,
+	set component.ExporterCreateSettings,
+) (component.TracesExporter, error) {
+	se, err := initExporter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the traces exporter: %w", err)
+	}
+
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		se.pushTracesData,
+		// Disable exporterhelper Timeout, since we are using a custom mechanism
+		// within exporter itself
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(cfg.RetrySettings),
+		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithStart(se.start),
+	)
+}
+
 // start starts the exporter
 func (se *sumologicexporter) start(_ context.Context, host component.Host) (err error) {
 	client, err := se.config.HTTPClientSettings.ToClient(host.GetExtensions())
@@ -295,6 +318,7 @@ func (se *sumologicexporter) pushMetricsData(ctx context.Context, md pdata.Metri
 				mp := metricPair{
 					metric:     m,
 					attributes: attributes,
+					library:    ilm.InstrumentationLibrary(),
 				}
 
 				currentMetadata = sdr.filter.filterIn(attributes)
@@ -333,14 +357,12 @@ func (se *sumologicexporter) pushMetricsData(ctx context.Context, md pdata.Metri
 	if len(droppedRecords) > 0 {
 		// Move all dropped records to Metrics
 		droppedMetrics := pdata.NewMetrics()
-		rms := droppedMetrics.ResourceMetrics()
-		rms.EnsureCapacity(len(droppedRecords))
-		for _, record := range droppedRecords {
-			rm := droppedMetrics.ResourceMetrics().AppendEmpty()
-			record.attributes.CopyTo(rm.Resource().Attributes())
-
-			ilms := rm.InstrumentationLibraryMetrics()
-			record.metric.CopyTo(ilms.AppendEmpty().Metrics().AppendEmpty())
+		for i := range droppedRecords {
+			var prev *metricPair
+			if i > 0 {
+				prev = &droppedRecords[i-1]
+			}
+			appendMetricPair(droppedMetrics, droppedRecords[i], prev)
 		}
 
 		return consumererror.NewMetrics(consumererror.Combine(errs), droppedMetrics)
@@ -348,3 +370,81 @@ func (se *sumologicexporter) pushMetricsData(ctx context.Context, md pdata.Metri
 
 	return nil
 }
+
+// pushTracesData groups resource spans with common metadata and sends them as separate batched
+// requests in the OTLP format. It returns an error which contains a list of dropped resource spans
+// so they can be handled by the OTC retry mechanism
+func (se *sumologicexporter) pushTracesData(ctx context.Context, td pdata.Traces) error {
+	var (
+		currentMetadata  fields = newFields(pdata.NewAttributeMap())
+		previousMetadata fields = newFields(pdata.NewAttributeMap())
+		errs             []error
+		droppedRecords   []pdata.ResourceSpans
+		err              error
+	)
+
+	c, err := newCompressor(se.config.CompressEncoding)
+	if err != nil {
+		return consumererror.NewTraces(fmt.Errorf("failed to initialize compressor: %w", err), td)
+	}
+	sdr := newSender(
+		se.config,
+		se.client,
+		se.filter,
+		se.sources,
+		c,
+		se.prometheusFormatter,
+		se.graphiteFormatter,
+	)
+
+	// Iterate over ResourceSpans
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+
+		currentMetadata = sdr.filter.filterIn(rs.Resource().Attributes())
+
+		// If metadata differs from currently buffered, flush the buffer
+		if currentMetadata.string() != previousMetadata.string() && sdr.countTraces() > 0 {
+			var dropped []pdata.ResourceSpans
+			dropped, err = sdr.sendTraces(ctx, previousMetadata)
+			if err != nil {
+				errs = append(errs, err)
+				droppedRecords = append(droppedRecords, dropped...)
+			}
+			sdr.cleanTraceBuffer()
+		}
+
+		// assign metadata
+		previousMetadata = currentMetadata
+
+		// add resource spans to the buffer
+		var dropped []pdata.ResourceSpans
+		dropped, err = sdr.batchTrace(ctx, rs, previousMetadata)
+		if err != nil {
+			droppedRecords = append(droppedRecords, dropped...)
+			errs = append(errs, err)
+		}
+	}
+
+	// Flush pending traces
+	dropped, err := sdr.sendTraces(ctx, previousMetadata)
+	if err != nil {
+		droppedRecords = append(droppedRecords, dropped...)
+		errs = append(errs, err)
+	}
+
+	if len(droppedRecords) > 0 {
+		// Move all dropped records to Traces
+		droppedTraces := pdata.NewTraces()
+		rss := droppedTraces.ResourceSpans()
+		rss.EnsureCapacity(len(droppedRecords))
+		for _, record := range droppedRecords {
+			record.CopyTo(rss.AppendEmpty())
+		}
+
+		return consumererror.NewTraces(consumererror.Combine(errs), droppedTraces)
+	}
+
+	return nil
+}
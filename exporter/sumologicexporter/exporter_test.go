@@ -485,3 +485,63 @@ func TestPushMetricsFailedBatch(t *testing.T) {
 	err := test.exp.pushMetricsData(context.Background(), metrics)
 	assert.EqualError(t, err, "error during sending data: 500 Internal Server Error")
 }
+
+func TestAllTracesSuccess(t *testing.T) {
+	traces := exampleTraces()
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, traces, extractTraces(t, req))
+			assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	err := test.exp.pushTracesData(context.Background(), traces)
+	assert.NoError(t, err)
+}
+
+func TestAllTracesFailed(t *testing.T) {
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(500)
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	traces := exampleTraces()
+	err := test.exp.pushTracesData(context.Background(), traces)
+	assert.EqualError(t, err, "error during sending data: 500 Internal Server Error")
+
+	var partial consumererror.Traces
+	require.True(t, consumererror.AsTraces(err, &partial))
+	assert.Equal(t, traces, partial.GetTraces())
+}
+
+func TestTracesDifferentMetadata(t *testing.T) {
+	traces := exampleTraces()
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(500)
+
+			assert.Equal(t, resourceSpansToTraces([]pdata.ResourceSpans{traces.ResourceSpans().At(0)}), extractTraces(t, req))
+			assert.Equal(t, "category/value1", req.Header.Get("X-Sumo-Category"))
+		},
+		func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, resourceSpansToTraces([]pdata.ResourceSpans{traces.ResourceSpans().At(1)}), extractTraces(t, req))
+			assert.Equal(t, "category/", req.Header.Get("X-Sumo-Category"))
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	f, err := newFilter([]string{`key\d`})
+	require.NoError(t, err)
+	test.exp.filter = f
+	test.exp.sources.category = getTestSourceFormat(t, "category/%{key1}")
+
+	err = test.exp.pushTracesData(context.Background(), traces)
+	assert.EqualError(t, err, "error during sending data: 500 Internal Server Error")
+
+	var partial consumererror.Traces
+	require.True(t, consumererror.AsTraces(err, &partial))
+	assert.Equal(t, resourceSpansToTraces([]pdata.ResourceSpans{traces.ResourceSpans().At(0)}), partial.GetTraces())
+}
@@ -35,6 +35,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		exporterhelper.WithLogs(createLogsExporter),
 		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithTraces(createTracesExporter),
 	)
 }
 
@@ -86,3 +87,16 @@ func createMetricsExporter(
 
 	return exp, nil
 }
+
+func createTracesExporter(
+	_ context.Context,
+	params component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	exp, err := newTracesExporter(cfg.(*Config), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the traces exporter: %w", err)
+	}
+
+	return exp, nil
+}
@@ -17,6 +17,7 @@ package sumologicexporter
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -177,6 +178,11 @@ func (f *prometheusFormatter) sumMetric(name string) string {
 	return fmt.Sprintf("%s_sum", name)
 }
 
+// bucketMetric returns _bucket suffixed metric name
+func (f *prometheusFormatter) bucketMetric(name string) string {
+	return fmt.Sprintf("%s_bucket", name)
+}
+
 // countMetric returns _count suffixed metric name
 func (f *prometheusFormatter) countMetric(name string) string {
 	return fmt.Sprintf("%s_count", name)
@@ -272,7 +278,8 @@ func (f *prometheusFormatter) summary2Strings(record metricPair) []string {
 }
 
 // histogram2Strings converts Histogram record to a list of strings,
-// (n+1) where n is number of bounds plus two for sum and count per each data point
+// (n+1) _bucket lines where n is number of bounds plus two for _sum and _count per each data point.
+// Exemplars are added to the _bucket lines of the buckets their value falls into
 func (f *prometheusFormatter) histogram2Strings(record metricPair) []string {
 	dps := record.metric.Histogram().DataPoints()
 	var lines []string
@@ -281,33 +288,37 @@ func (f *prometheusFormatter) histogram2Strings(record metricPair) []string {
 		dp := dps.At(i)
 
 		explicitBounds := dp.ExplicitBounds()
-		var cumulative uint64
-		additionalAttributes := pdata.NewAttributeMap()
-
-		for i, bound := range explicitBounds {
-			cumulative += dp.BucketCounts()[i]
-			additionalAttributes.UpsertDouble(prometheusLeTag, bound)
-
+		bucketCounts := dp.BucketCounts()
+		if len(bucketCounts) == len(explicitBounds)+1 {
+			exemplars := f.bucketExemplars(explicitBounds, dp.Exemplars())
+			var cumulative uint64
+			additionalAttributes := pdata.NewAttributeMap()
+
+			for i, bound := range explicitBounds {
+				cumulative += bucketCounts[i]
+				additionalAttributes.UpsertDouble(prometheusLeTag, bound)
+
+				line := f.uintValueLine(
+					f.bucketMetric(record.metric.Name()),
+					cumulative,
+					dp,
+					f.mergeAttributes(record.attributes, additionalAttributes),
+				)
+				lines = append(lines, line+f.exemplar2String(exemplars, i))
+			}
+
+			cumulative += bucketCounts[len(explicitBounds)]
+			additionalAttributes.UpsertString(prometheusLeTag, prometheusInfValue)
 			line := f.uintValueLine(
-				record.metric.Name(),
+				f.bucketMetric(record.metric.Name()),
 				cumulative,
 				dp,
 				f.mergeAttributes(record.attributes, additionalAttributes),
 			)
-			lines = append(lines, line)
+			lines = append(lines, line+f.exemplar2String(exemplars, len(explicitBounds)))
 		}
 
-		cumulative += dp.BucketCounts()[len(explicitBounds)]
-		additionalAttributes.UpsertString(prometheusLeTag, prometheusInfValue)
-		line := f.uintValueLine(
-			record.metric.Name(),
-			cumulative,
-			dp,
-			f.mergeAttributes(record.attributes, additionalAttributes),
-		)
-		lines = append(lines, line)
-
-		line = f.doubleValueLine(
+		line := f.doubleValueLine(
 			f.sumMetric(record.metric.Name()),
 			dp.Sum(),
 			dp,
@@ -327,6 +338,54 @@ func (f *prometheusFormatter) histogram2Strings(record metricPair) []string {
 	return lines
 }
 
+// bucketExemplars returns the most recent exemplar of each bucket, indexed by the bucket index
+func (f *prometheusFormatter) bucketExemplars(explicitBounds []float64, exemplars pdata.ExemplarSlice) map[int]pdata.Exemplar {
+	if exemplars.Len() == 0 {
+		return nil
+	}
+
+	bucketExemplars := make(map[int]pdata.Exemplar, exemplars.Len())
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		value := exemplarValue(exemplar)
+
+		bucket := sort.SearchFloat64s(explicitBounds, value)
+		if current, ok := bucketExemplars[bucket]; ok && current.Timestamp() > exemplar.Timestamp() {
+			continue
+		}
+		bucketExemplars[bucket] = exemplar
+	}
+	return bucketExemplars
+}
+
+// exemplar2String returns the OpenMetrics exemplar suffix of the line of a bucket, for example:
+// ` # {trace_id="abc"} 0.67 1618124444.169`, or an empty string if the bucket has no exemplar
+func (f *prometheusFormatter) exemplar2String(exemplars map[int]pdata.Exemplar, bucket int) string {
+	exemplar, ok := exemplars[bucket]
+	if !ok {
+		return ""
+	}
+
+	labels := f.tags2String(pdata.NewAttributeMap(), exemplar.FilteredLabels())
+	if labels == "" {
+		labels = "{}"
+	}
+
+	line := fmt.Sprintf(" # %s %g", labels, exemplarValue(exemplar))
+	if exemplar.Timestamp() != 0 {
+		line += fmt.Sprintf(" %.3f", float64(exemplar.Timestamp())/float64(time.Second))
+	}
+	return line
+}
+
+// exemplarValue returns the value of the exemplar as float64
+func exemplarValue(exemplar pdata.Exemplar) float64 {
+	if exemplar.Type() == pdata.MetricValueTypeInt {
+		return float64(exemplar.IntVal())
+	}
+	return exemplar.DoubleVal()
+}
+
 // metric2String returns stringified metricPair
 func (f *prometheusFormatter) metric2String(record metricPair) string {
 	var lines []string
@@ -135,21 +135,61 @@ func TestPrometheusMetricDataTypeHistogram(t *testing.T) {
 	metric := exampleHistogramMetric()
 
 	result := f.metric2String(metric)
-	expected := `histogram_metric_double_test{bar="foo",le="0.1",container="dolor",branch="sumologic"} 0 1618124444169
-histogram_metric_double_test{bar="foo",le="0.2",container="dolor",branch="sumologic"} 12 1618124444169
-histogram_metric_double_test{bar="foo",le="0.5",container="dolor",branch="sumologic"} 19 1618124444169
-histogram_metric_double_test{bar="foo",le="0.8",container="dolor",branch="sumologic"} 24 1618124444169
-histogram_metric_double_test{bar="foo",le="1",container="dolor",branch="sumologic"} 32 1618124444169
-histogram_metric_double_test{bar="foo",le="+Inf",container="dolor",branch="sumologic"} 45 1618124444169
+	expected := `histogram_metric_double_test_bucket{bar="foo",le="0.1",container="dolor",branch="sumologic"} 0 1618124444169
+histogram_metric_double_test_bucket{bar="foo",le="0.2",container="dolor",branch="sumologic"} 12 1618124444169
+histogram_metric_double_test_bucket{bar="foo",le="0.5",container="dolor",branch="sumologic"} 19 1618124444169
+histogram_metric_double_test_bucket{bar="foo",le="0.8",container="dolor",branch="sumologic"} 24 1618124444169
+histogram_metric_double_test_bucket{bar="foo",le="1",container="dolor",branch="sumologic"} 32 1618124444169
+histogram_metric_double_test_bucket{bar="foo",le="+Inf",container="dolor",branch="sumologic"} 45 1618124444169
 histogram_metric_double_test_sum{bar="foo",container="dolor",branch="sumologic"} 45.6 1618124444169
 histogram_metric_double_test_count{bar="foo",container="dolor",branch="sumologic"} 7 1618124444169
-histogram_metric_double_test{bar="foo",le="0.1",container="sit",branch="main"} 0 1608424699186
-histogram_metric_double_test{bar="foo",le="0.2",container="sit",branch="main"} 10 1608424699186
-histogram_metric_double_test{bar="foo",le="0.5",container="sit",branch="main"} 11 1608424699186
-histogram_metric_double_test{bar="foo",le="0.8",container="sit",branch="main"} 12 1608424699186
-histogram_metric_double_test{bar="foo",le="1",container="sit",branch="main"} 16 1608424699186
-histogram_metric_double_test{bar="foo",le="+Inf",container="sit",branch="main"} 22 1608424699186
+histogram_metric_double_test_bucket{bar="foo",le="0.1",container="sit",branch="main"} 0 1608424699186
+histogram_metric_double_test_bucket{bar="foo",le="0.2",container="sit",branch="main"} 10 1608424699186
+histogram_metric_double_test_bucket{bar="foo",le="0.5",container="sit",branch="main"} 11 1608424699186
+histogram_metric_double_test_bucket{bar="foo",le="0.8",container="sit",branch="main"} 12 1608424699186
+histogram_metric_double_test_bucket{bar="foo",le="1",container="sit",branch="main"} 16 1608424699186
+histogram_metric_double_test_bucket{bar="foo",le="+Inf",container="sit",branch="main"} 22 1608424699186
 histogram_metric_double_test_sum{bar="foo",container="sit",branch="main"} 54.1 1608424699186
 histogram_metric_double_test_count{bar="foo",container="sit",branch="main"} 98 1608424699186`
 	assert.Equal(t, expected, result)
 }
+
+func TestPrometheusMetricDataTypeHistogramWithExemplars(t *testing.T) {
+	f, err := newPrometheusFormatter()
+	require.NoError(t, err)
+
+	metric := metricPair{
+		attributes: pdata.NewAttributeMap(),
+		metric:     pdata.NewMetric(),
+	}
+	metric.metric.SetDataType(pdata.MetricDataTypeHistogram)
+	metric.metric.SetName("histogram_metric_double_test")
+
+	dp := metric.metric.Histogram().DataPoints().AppendEmpty()
+	dp.SetBucketCounts([]uint64{1, 2, 1})
+	dp.SetExplicitBounds([]float64{0.1, 0.5})
+	dp.SetTimestamp(1618124444.169 * 1e9)
+	dp.SetSum(1.3)
+	dp.SetCount(4)
+
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetDoubleVal(0.3)
+	exemplar.SetTimestamp(1618124443.5 * 1e9)
+	exemplar.FilteredLabels().Insert("trace_id", "abc")
+
+	// Older exemplar of the same bucket is ignored
+	exemplar = dp.Exemplars().AppendEmpty()
+	exemplar.SetDoubleVal(0.2)
+	exemplar.SetTimestamp(1618124442 * 1e9)
+
+	exemplar = dp.Exemplars().AppendEmpty()
+	exemplar.SetIntVal(3)
+
+	result := f.metric2String(metric)
+	expected := `histogram_metric_double_test_bucket{le="0.1"} 1 1618124444169
+histogram_metric_double_test_bucket{le="0.5"} 3 1618124444169 # {trace_id="abc"} 0.3 1618124443.500
+histogram_metric_double_test_bucket{le="+Inf"} 4 1618124444169 # {} 3
+histogram_metric_double_test_sum 1.3 1618124444169
+histogram_metric_double_test_count 4 1618124444169`
+	assert.Equal(t, expected, result)
+}
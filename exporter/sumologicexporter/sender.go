@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
 	"go.opentelemetry.io/collector/model/pdata"
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 )
@@ -39,12 +40,30 @@ type appendResponse struct {
 // metricPair represents information required to send one metric to the Sumo Logic
 type metricPair struct {
 	attributes pdata.AttributeMap
+	library    pdata.InstrumentationLibrary
 	metric     pdata.Metric
 }
 
+// appendMetricPair appends the metric of the record to md, sharing the resource and instrumentation
+// library of the previous record if they are the same. The pdata wrappers are equal if they point to
+// the same data, so that the records of a resource or library are grouped.
+func appendMetricPair(md pdata.Metrics, record metricPair, prev *metricPair) {
+	rms := md.ResourceMetrics()
+	newResource := prev == nil || record.attributes != prev.attributes
+	if newResource {
+		record.attributes.CopyTo(rms.AppendEmpty().Resource().Attributes())
+	}
+	ilms := rms.At(rms.Len() - 1).InstrumentationLibraryMetrics()
+	if newResource || record.library != prev.library {
+		record.library.CopyTo(ilms.AppendEmpty().InstrumentationLibrary())
+	}
+	record.metric.CopyTo(ilms.At(ilms.Len() - 1).Metrics().AppendEmpty())
+}
+
 type sender struct {
 	logBuffer           []pdata.LogRecord
 	metricBuffer        []metricPair
+	traceBuffer         []pdata.ResourceSpans
 	config              *Config
 	client              *http.Client
 	filter              filter
@@ -52,6 +71,8 @@ type sender struct {
 	compressor          compressor
 	prometheusFormatter prometheusFormatter
 	graphiteFormatter   graphiteFormatter
+	metricsMarshaler    pdata.MetricsMarshaler
+	tracesMarshaler     pdata.TracesMarshaler
 }
 
 const (
@@ -71,6 +92,7 @@ const (
 	contentTypePrometheus string = "application/vnd.sumologic.prometheus"
 	contentTypeCarbon2    string = "application/vnd.sumologic.carbon2"
 	contentTypeGraphite   string = "application/vnd.sumologic.graphite"
+	contentTypeOTLP       string = "application/x-protobuf"
 
 	contentEncodingGzip    string = "gzip"
 	contentEncodingDeflate string = "deflate"
@@ -99,6 +121,8 @@ func newSender(
 		compressor:          c,
 		prometheusFormatter: pf,
 		graphiteFormatter:   gf,
+		metricsMarshaler:    otlp.NewProtobufMetricsMarshaler(),
+		tracesMarshaler:     otlp.NewProtobufTracesMarshaler(),
 	}
 }
 
@@ -150,9 +174,13 @@ func (s *sender) send(ctx context.Context, pipeline PipelineType, body io.Reader
 			req.Header.Add(headerContentType, contentTypeCarbon2)
 		case GraphiteFormat:
 			req.Header.Add(headerContentType, contentTypeGraphite)
+		case OTLPMetricFormat:
+			req.Header.Add(headerContentType, contentTypeOTLP)
 		default:
 			return fmt.Errorf("unsupported metrics format: %s", s.config.MetricFormat)
 		}
+	case TracesPipeline:
+		req.Header.Add(headerContentType, contentTypeOTLP)
 	default:
 		return errors.New("unexpected pipeline")
 	}
@@ -253,6 +281,10 @@ func (s *sender) sendLogs(ctx context.Context, flds fields) ([]pdata.LogRecord,
 
 // sendMetrics sends metrics in right format basing on the s.config.MetricFormat
 func (s *sender) sendMetrics(ctx context.Context, flds fields) ([]metricPair, error) {
+	if s.config.MetricFormat == OTLPMetricFormat {
+		return s.sendOTLPMetrics(ctx, flds)
+	}
+
 	var (
 		body           strings.Builder
 		errs           []error
@@ -317,6 +349,129 @@ func (s *sender) sendMetrics(ctx context.Context, flds fields) ([]metricPair, er
 	return droppedRecords, nil
 }
 
+// sendOTLPMetrics sends metrics from the metricBuffer in the OTLP protobuf format, grouped by
+// resource and instrumentation library, and as the result of execution returns array of records
+// which has not been sent correctly and error
+func (s *sender) sendOTLPMetrics(ctx context.Context, flds fields) ([]metricPair, error) {
+	var prev *metricPair
+	currentMetrics := pdata.NewMetrics()
+	dropped, err := s.sendOTLP(
+		len(s.metricBuffer),
+		func(i int) int {
+			// An upper bound, as the resource and library may be shared with the previous metric
+			metrics := pdata.NewMetrics()
+			appendMetricPair(metrics, s.metricBuffer[i], nil)
+			return metrics.OtlpProtoSize()
+		},
+		func(i int) {
+			appendMetricPair(currentMetrics, s.metricBuffer[i], prev)
+			prev = &s.metricBuffer[i]
+		},
+		func() error {
+			err := s.sendOTLPMetricsRequest(ctx, currentMetrics, flds)
+			currentMetrics = pdata.NewMetrics()
+			prev = nil
+			return err
+		},
+	)
+
+	var droppedRecords []metricPair
+	for _, i := range dropped {
+		droppedRecords = append(droppedRecords, s.metricBuffer[i])
+	}
+	return droppedRecords, err
+}
+
+// sendOTLPMetricsRequest marshals metrics to the OTLP protobuf format and sends them
+func (s *sender) sendOTLPMetricsRequest(ctx context.Context, md pdata.Metrics, flds fields) error {
+	body, err := s.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, MetricsPipeline, bytes.NewReader(body), flds)
+}
+
+// sendTraces sends the resource spans from the traceBuffer in the OTLP protobuf format,
+// in requests of up to MaxRequestBodySize bytes before compression, and as the result of
+// execution returns array of resource spans which has not been sent correctly and error
+func (s *sender) sendTraces(ctx context.Context, flds fields) ([]pdata.ResourceSpans, error) {
+	currentTraces := pdata.NewTraces()
+	dropped, err := s.sendOTLP(
+		len(s.traceBuffer),
+		func(i int) int {
+			traces := pdata.NewTraces()
+			s.traceBuffer[i].CopyTo(traces.ResourceSpans().AppendEmpty())
+			return traces.OtlpProtoSize()
+		},
+		func(i int) {
+			s.traceBuffer[i].CopyTo(currentTraces.ResourceSpans().AppendEmpty())
+		},
+		func() error {
+			err := s.sendOTLPTraces(ctx, currentTraces, flds)
+			currentTraces = pdata.NewTraces()
+			return err
+		},
+	)
+
+	var droppedRecords []pdata.ResourceSpans
+	for _, i := range dropped {
+		droppedRecords = append(droppedRecords, s.traceBuffer[i])
+	}
+	return droppedRecords, err
+}
+
+// sendOTLP sends the n records of a buffer in OTLP requests of up to MaxRequestBodySize bytes
+// before compression, and returns the indexes of the records which have not been sent correctly.
+// sizeOf returns the size of a record in a request of its own, add adds a record to the pending
+// request, and send sends the pending request and starts the next one.
+func (s *sender) sendOTLP(n int, sizeOf func(i int) int, add func(i int), send func() error) ([]int, error) {
+	var (
+		errs           []error
+		droppedRecords []int
+		currentRecords []int
+		currentSize    int
+	)
+
+	for i := 0; i < n; i++ {
+		// The size of the OTLP request is the sum of the sizes of its records
+		size := sizeOf(i)
+
+		if len(currentRecords) > 0 && currentSize+size >= s.config.MaxRequestBodySize {
+			if err := send(); err != nil {
+				errs = append(errs, err)
+				droppedRecords = append(droppedRecords, currentRecords...)
+			}
+			currentRecords = currentRecords[:0]
+			currentSize = 0
+		}
+
+		add(i)
+		currentRecords = append(currentRecords, i)
+		currentSize += size
+	}
+
+	if len(currentRecords) > 0 {
+		if err := send(); err != nil {
+			errs = append(errs, err)
+			droppedRecords = append(droppedRecords, currentRecords...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return droppedRecords, consumererror.Combine(errs)
+	}
+	return droppedRecords, nil
+}
+
+// sendOTLPTraces marshals traces to the OTLP protobuf format and sends them
+func (s *sender) sendOTLPTraces(ctx context.Context, td pdata.Traces, flds fields) error {
+	body, err := s.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, TracesPipeline, bytes.NewReader(body), flds)
+}
+
 // appendAndSend appends line to the request body that will be sent and sends
 // the accumulated data if the internal logBuffer has been filled (with maxBufferSize elements).
 // It returns appendResponse
@@ -407,3 +562,27 @@ func (s *sender) batchMetric(ctx context.Context, metric metricPair, metadata fi
 func (s *sender) countMetrics() int {
 	return len(s.metricBuffer)
 }
+
+// cleanTraceBuffer zeroes traceBuffer
+func (s *sender) cleanTraceBuffer() {
+	s.traceBuffer = (s.traceBuffer)[:0]
+}
+
+// batchTrace adds resource spans to the traceBuffer and flushes them if traceBuffer is full to avoid overflow
+// returns list of resource spans which were not sent successfully
+func (s *sender) batchTrace(ctx context.Context, rs pdata.ResourceSpans, metadata fields) ([]pdata.ResourceSpans, error) {
+	s.traceBuffer = append(s.traceBuffer, rs)
+
+	if s.countTraces() >= maxBufferSize {
+		dropped, err := s.sendTraces(ctx, metadata)
+		s.cleanTraceBuffer()
+		return dropped, err
+	}
+
+	return nil, nil
+}
+
+// countTraces returns number of resource spans in traceBuffer
+func (s *sender) countTraces() int {
+	return len(s.traceBuffer)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/model/otlp"
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
@@ -860,3 +861,190 @@ gauge_metric_name.. 245 1608124662`
 	_, err = test.s.sendMetrics(context.Background(), flds)
 	assert.NoError(t, err)
 }
+
+func extractTraces(t *testing.T, req *http.Request) pdata.Traces {
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	traces, err := otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces(body)
+	require.NoError(t, err)
+	return traces
+}
+
+func TestSendTraces(t *testing.T) {
+	traces := exampleTraces()
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, traces, extractTraces(t, req))
+			assert.Equal(t, "otelcol", req.Header.Get("X-Sumo-Client"))
+			assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+			assert.Equal(t, "", req.Header.Get("X-Sumo-Fields"))
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	test.s.traceBuffer = []pdata.ResourceSpans{
+		traces.ResourceSpans().At(0),
+		traces.ResourceSpans().At(1),
+	}
+	dropped, err := test.s.sendTraces(context.Background(), newFields(pdata.NewAttributeMap()))
+	assert.NoError(t, err)
+	assert.Empty(t, dropped)
+}
+
+func TestSendTracesSplit(t *testing.T) {
+	traces := exampleTraces()
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, resourceSpansToTraces([]pdata.ResourceSpans{traces.ResourceSpans().At(0)}), extractTraces(t, req))
+		},
+		func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, resourceSpansToTraces([]pdata.ResourceSpans{traces.ResourceSpans().At(1)}), extractTraces(t, req))
+		},
+	})
+	defer func() { test.srv.Close() }()
+	test.s.config.MaxRequestBodySize = 10
+
+	test.s.traceBuffer = []pdata.ResourceSpans{
+		traces.ResourceSpans().At(0),
+		traces.ResourceSpans().At(1),
+	}
+	dropped, err := test.s.sendTraces(context.Background(), newFields(pdata.NewAttributeMap()))
+	assert.NoError(t, err)
+	assert.Empty(t, dropped)
+}
+
+func TestSendTracesSplitFailedOne(t *testing.T) {
+	traces := exampleTraces()
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(500)
+		},
+		func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, resourceSpansToTraces([]pdata.ResourceSpans{traces.ResourceSpans().At(1)}), extractTraces(t, req))
+		},
+	})
+	defer func() { test.srv.Close() }()
+	test.s.config.MaxRequestBodySize = 10
+
+	test.s.traceBuffer = []pdata.ResourceSpans{
+		traces.ResourceSpans().At(0),
+		traces.ResourceSpans().At(1),
+	}
+	dropped, err := test.s.sendTraces(context.Background(), newFields(pdata.NewAttributeMap()))
+	assert.EqualError(t, err, "error during sending data: 500 Internal Server Error")
+	assert.Equal(t, test.s.traceBuffer[0:1], dropped)
+}
+
+func TestTracesBuffer(t *testing.T) {
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){})
+	defer func() { test.srv.Close() }()
+
+	traces := exampleTraces()
+	assert.Equal(t, test.s.countTraces(), 0)
+
+	dropped, err := test.s.batchTrace(context.Background(), traces.ResourceSpans().At(0), newFields(pdata.NewAttributeMap()))
+	require.NoError(t, err)
+	assert.Nil(t, dropped)
+	assert.Equal(t, 1, test.s.countTraces())
+
+	test.s.cleanTraceBuffer()
+	assert.Equal(t, 0, test.s.countTraces())
+}
+
+func TestSendOTLPMetrics(t *testing.T) {
+	records := []metricPair{
+		exampleIntMetric(),
+		exampleHistogramMetric(),
+	}
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			metrics, err := otlp.NewProtobufMetricsUnmarshaler().UnmarshalMetrics(body)
+			require.NoError(t, err)
+
+			assert.Equal(t, metricPairToMetrics(records), metrics)
+			assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	test.s.config.MetricFormat = OTLPMetricFormat
+	test.s.metricBuffer = records
+	dropped, err := test.s.sendMetrics(context.Background(), newFields(pdata.NewAttributeMap()))
+	assert.NoError(t, err)
+	assert.Empty(t, dropped)
+}
+
+func TestSendOTLPMetricsGroupedByResourceAndLibrary(t *testing.T) {
+	attributes := pdata.NewAttributeMap()
+	attributes.InsertString("test", "test_value")
+	library := pdata.NewInstrumentationLibrary()
+	library.SetName("test_library")
+
+	records := []metricPair{
+		exampleIntMetric(),
+		exampleHistogramMetric(),
+		exampleIntGaugeMetric(),
+	}
+	for i := range records {
+		records[i].attributes = attributes
+		records[i].library = library
+	}
+	records[2].library = pdata.NewInstrumentationLibrary()
+
+	expected := pdata.NewMetrics()
+	rm := expected.ResourceMetrics().AppendEmpty()
+	attributes.CopyTo(rm.Resource().Attributes())
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	library.CopyTo(ilm.InstrumentationLibrary())
+	records[0].metric.CopyTo(ilm.Metrics().AppendEmpty())
+	records[1].metric.CopyTo(ilm.Metrics().AppendEmpty())
+	records[2].metric.CopyTo(rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty())
+
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			metrics, err := otlp.NewProtobufMetricsUnmarshaler().UnmarshalMetrics(body)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, metrics)
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	test.s.config.MetricFormat = OTLPMetricFormat
+	test.s.metricBuffer = records
+	dropped, err := test.s.sendMetrics(context.Background(), newFields(pdata.NewAttributeMap()))
+	assert.NoError(t, err)
+	assert.Empty(t, dropped)
+}
+
+func TestSendOTLPMetricsSplitFailedOne(t *testing.T) {
+	records := []metricPair{
+		exampleIntMetric(),
+		exampleHistogramMetric(),
+	}
+	test := prepareSenderTest(t, []func(w http.ResponseWriter, req *http.Request){
+		func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(500)
+		},
+		func(w http.ResponseWriter, req *http.Request) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			metrics, err := otlp.NewProtobufMetricsUnmarshaler().UnmarshalMetrics(body)
+			require.NoError(t, err)
+
+			assert.Equal(t, metricPairToMetrics(records[1:]), metrics)
+		},
+	})
+	defer func() { test.srv.Close() }()
+
+	test.s.config.MetricFormat = OTLPMetricFormat
+	test.s.config.MaxRequestBodySize = 10
+	test.s.metricBuffer = records
+	dropped, err := test.s.sendMetrics(context.Background(), newFields(pdata.NewAttributeMap()))
+	assert.EqualError(t, err, "error during sending data: 500 Internal Server Error")
+	assert.Equal(t, records[0:1], dropped)
+}
@@ -34,12 +34,14 @@ func exampleIntMetric() metricPair {
 	return metricPair{
 		metric:     metric,
 		attributes: attributes,
+		library:    pdata.NewInstrumentationLibrary(),
 	}
 }
 
 func exampleIntGaugeMetric() metricPair {
 	metric := metricPair{
 		attributes: pdata.NewAttributeMap(),
+		library:    pdata.NewInstrumentationLibrary(),
 		metric:     pdata.NewMetric(),
 	}
 
@@ -66,6 +68,7 @@ func exampleIntGaugeMetric() metricPair {
 func exampleDoubleGaugeMetric() metricPair {
 	metric := metricPair{
 		attributes: pdata.NewAttributeMap(),
+		library:    pdata.NewInstrumentationLibrary(),
 		metric:     pdata.NewMetric(),
 	}
 
@@ -92,6 +95,7 @@ func exampleDoubleGaugeMetric() metricPair {
 func exampleIntSumMetric() metricPair {
 	metric := metricPair{
 		attributes: pdata.NewAttributeMap(),
+		library:    pdata.NewInstrumentationLibrary(),
 		metric:     pdata.NewMetric(),
 	}
 
@@ -118,6 +122,7 @@ func exampleIntSumMetric() metricPair {
 func exampleDoubleSumMetric() metricPair {
 	metric := metricPair{
 		attributes: pdata.NewAttributeMap(),
+		library:    pdata.NewInstrumentationLibrary(),
 		metric:     pdata.NewMetric(),
 	}
 
@@ -144,6 +149,7 @@ func exampleDoubleSumMetric() metricPair {
 func exampleSummaryMetric() metricPair {
 	metric := metricPair{
 		attributes: pdata.NewAttributeMap(),
+		library:    pdata.NewInstrumentationLibrary(),
 		metric:     pdata.NewMetric(),
 	}
 
@@ -180,6 +186,7 @@ func exampleSummaryMetric() metricPair {
 func exampleHistogramMetric() metricPair {
 	metric := metricPair{
 		attributes: pdata.NewAttributeMap(),
+		library:    pdata.NewInstrumentationLibrary(),
 		metric:     pdata.NewMetric(),
 	}
 
@@ -228,3 +235,33 @@ func fieldsFromMap(s map[string]string) fields {
 	}
 	return newFields(attrMap)
 }
+
+func exampleTraces() pdata.Traces {
+	traces := pdata.NewTraces()
+
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("key1", "value1")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("first_span")
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2}))
+
+	rs = traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("key2", "value2")
+	span = rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("second_span")
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{3, 4}))
+
+	return traces
+}
+
+func resourceSpansToTraces(rss []pdata.ResourceSpans) pdata.Traces {
+	traces := pdata.NewTraces()
+	traces.ResourceSpans().EnsureCapacity(len(rss))
+	for _, rs := range rss {
+		rs.CopyTo(traces.ResourceSpans().AppendEmpty())
+	}
+
+	return traces
+}
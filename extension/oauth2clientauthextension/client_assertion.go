@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// clientAssertionType is the value of the client_assertion_type parameter for JWT assertions.
+	// See https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	defaultAssertionAlgorithm = jose.RS256
+	defaultAssertionLifetime  = 5 * time.Minute
+)
+
+// clientAssertion creates the signed JWTs used by the client to authenticate to the token endpoint.
+type clientAssertion struct {
+	keyFile   string
+	keyID     string
+	algorithm jose.SignatureAlgorithm
+	clientID  string
+	audience  string
+	lifetime  time.Duration
+}
+
+func newClientAssertion(cfg *ClientAssertionSettings, clientID string, tokenURL string) (*clientAssertion, error) {
+	ca := &clientAssertion{
+		keyFile:   cfg.KeyFile,
+		keyID:     cfg.KeyID,
+		algorithm: defaultAssertionAlgorithm,
+		clientID:  clientID,
+		audience:  tokenURL,
+		lifetime:  defaultAssertionLifetime,
+	}
+	if cfg.Algorithm != "" {
+		ca.algorithm = jose.SignatureAlgorithm(cfg.Algorithm)
+	}
+	if cfg.Audience != "" {
+		ca.audience = cfg.Audience
+	}
+	if cfg.Lifetime > 0 {
+		ca.lifetime = cfg.Lifetime
+	}
+
+	// Fail early on an invalid key or algorithm rather than on the first token request
+	if _, err := ca.signer(); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// sign returns a new assertion, valid from now and for the configured lifetime.
+func (ca *clientAssertion) sign(now time.Time) (string, error) {
+	signer, err := ca.signer()
+	if err != nil {
+		return "", err
+	}
+
+	jti := make([]byte, 16)
+	if _, err = rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	claims := jwt.Claims{
+		Issuer:   ca.clientID,
+		Subject:  ca.clientID,
+		Audience: jwt.Audience{ca.audience},
+		ID:       hex.EncodeToString(jti),
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(ca.lifetime)),
+	}
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
+
+func (ca *clientAssertion) signer() (jose.Signer, error) {
+	key, err := readPrivateKey(ca.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if ca.keyID != "" {
+		opts = opts.WithHeader("kid", ca.keyID)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: ca.algorithm, Key: key}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client assertion signer: %w", err)
+	}
+	return signer, nil
+}
+
+// readPrivateKey reads a PKCS#8, PKCS#1 or SEC 1 private key from a PEM file.
+func readPrivateKey(path string) (interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client assertion key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode client assertion key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("failed to parse client assertion key: unsupported key type")
+}
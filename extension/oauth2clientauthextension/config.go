@@ -16,6 +16,7 @@ package oauth2clientauthextension
 
 import (
 	"errors"
+	"net/url"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -26,6 +27,8 @@ var (
 	errNoClientIDProvided     = errors.New("no ClientID provided in the OAuth2 exporter configuration")
 	errNoTokenURLProvided     = errors.New("no TokenURL provided in OAuth Client Credentials configuration")
 	errNoClientSecretProvided = errors.New("no ClientSecret provided in OAuth Client Credentials configuration")
+	errMultipleClientSecrets  = errors.New("only one of ClientSecret, ClientSecretFile and ClientAssertion can be provided in OAuth Client Credentials configuration")
+	errNoKeyFileProvided      = errors.New("no KeyFile provided in OAuth Client Assertion configuration")
 )
 
 // Config stores the configuration for OAuth2 Client Credentials (2-legged OAuth2 flow) setup.
@@ -40,6 +43,20 @@ type Config struct {
 	// See https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
 	ClientSecret string `mapstructure:"client_secret"`
 
+	// ClientSecretFile is the path to a file containing the application's secret.
+	// The file is read every time a token is requested, so the secret can be rotated
+	// without restarting the collector.
+	ClientSecretFile string `mapstructure:"client_secret_file"`
+
+	// ClientAssertion configures the authentication of the client with a signed JWT
+	// instead of a shared secret ("private_key_jwt" method).
+	// See https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+	ClientAssertion *ClientAssertionSettings `mapstructure:"client_assertion"`
+
+	// EndpointParams specifies additional parameters for requests to the token endpoint,
+	// such as `audience` or `resource`.
+	EndpointParams url.Values `mapstructure:"endpoint_params"`
+
 	// TokenURL is the resource server's token endpoint
 	// URL. This is a constant specific to each server.
 	// See https://datatracker.ietf.org/doc/html/rfc6749#section-3.2
@@ -57,6 +74,26 @@ type Config struct {
 	Timeout time.Duration `mapstructure:"timeout,omitempty"`
 }
 
+// ClientAssertionSettings configures the JWT used by the client to authenticate to the token endpoint.
+type ClientAssertionSettings struct {
+	// KeyFile is the path to the PEM encoded private key used to sign the assertion.
+	// The file is read every time a token is requested, so the key can be rotated
+	// without restarting the collector.
+	KeyFile string `mapstructure:"key_file"`
+
+	// KeyID is the optional identifier of the key, set as the `kid` header of the assertion.
+	KeyID string `mapstructure:"key_id"`
+
+	// Algorithm is the JWS algorithm used to sign the assertion, e.g. RS256 or ES256.
+	Algorithm string `mapstructure:"algorithm,omitempty"`
+
+	// Audience is the `aud` claim of the assertion. Defaults to the TokenURL.
+	Audience string `mapstructure:"audience,omitempty"`
+
+	// Lifetime is the validity duration of each assertion.
+	Lifetime time.Duration `mapstructure:"lifetime,omitempty"`
+}
+
 var _ config.Extension = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
@@ -64,11 +101,36 @@ func (cfg *Config) Validate() error {
 	if cfg.ClientID == "" {
 		return errNoClientIDProvided
 	}
-	if cfg.ClientSecret == "" {
-		return errNoClientSecretProvided
+	if err := cfg.validateClientSecret(); err != nil {
+		return err
 	}
 	if cfg.TokenURL == "" {
 		return errNoTokenURLProvided
 	}
 	return nil
 }
+
+func (cfg *Config) validateClientSecret() error {
+	count := 0
+	if cfg.ClientSecret != "" {
+		count++
+	}
+	if cfg.ClientSecretFile != "" {
+		count++
+	}
+	if cfg.ClientAssertion != nil {
+		count++
+		if cfg.ClientAssertion.KeyFile == "" {
+			return errNoKeyFileProvided
+		}
+	}
+
+	switch count {
+	case 0:
+		return errNoClientSecretProvided
+	case 1:
+		return nil
+	default:
+		return errMultipleClientSecrets
+	}
+}
@@ -15,6 +15,7 @@
 package oauth2clientauthextension
 
 import (
+	"net/url"
 	"path"
 	"testing"
 	"time"
@@ -56,8 +57,38 @@ func TestLoadConfig(t *testing.T) {
 		},
 		ext)
 
-	assert.Equal(t, 2, len(cfg.Service.Extensions))
+	assert.Equal(t, 4, len(cfg.Service.Extensions))
 	assert.Equal(t, config.NewIDWithName(typeStr, "1"), cfg.Service.Extensions[0])
+
+	ext = cfg.Extensions[config.NewIDWithName(typeStr, "withendpointparams")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "withendpointparams")),
+			ClientID:          "someclientid3",
+			ClientSecretFile:  "/var/lib/otelcol/secret",
+			TokenURL:          "https://example3.com/oauth2/default/v1/token",
+			EndpointParams: url.Values{
+				"audience": []string{"someaudience"},
+				"resource": []string{"someresource", "otherresource"},
+			},
+		},
+		ext)
+
+	ext = cfg.Extensions[config.NewIDWithName(typeStr, "withclientassertion")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "withclientassertion")),
+			ClientID:          "someclientid4",
+			TokenURL:          "https://example4.com/oauth2/default/v1/token",
+			ClientAssertion: &ClientAssertionSettings{
+				KeyFile:   "/var/lib/otelcol/key.pem",
+				KeyID:     "somekeyid",
+				Algorithm: "ES256",
+				Audience:  "https://example4.com",
+				Lifetime:  time.Minute,
+			},
+		},
+		ext)
 }
 
 func TestConfigTLSSettings(t *testing.T) {
@@ -106,6 +137,14 @@ func TestLoadConfigError(t *testing.T) {
 			"missingsecret",
 			errNoClientSecretProvided,
 		},
+		{
+			"multiplesecrets",
+			errMultipleClientSecrets,
+		},
+		{
+			"missingkeyfile",
+			errNoKeyFileProvided,
+		},
 	}
 	for _, tt := range tests {
 		factory := NewFactory()
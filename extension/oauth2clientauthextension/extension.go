@@ -16,8 +16,15 @@ package oauth2clientauthextension
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.uber.org/zap"
@@ -31,8 +38,13 @@ import (
 // workflow for both gRPC and HTTP clients.
 type ClientCredentialsAuthenticator struct {
 	clientCredentials *clientcredentials.Config
+	clientSecretFile  string
+	clientAssertion   *clientAssertion
 	logger            *zap.Logger
 	client            *http.Client
+	// tokenSource caches the token until it expires, and is shared by all the clients using the extension
+	tokenSource oauth2.TokenSource
+	metricsCtx  context.Context
 }
 
 // ClientCredentialsAuthenticator implements both HTTPClientAuth and GRPCClientAuth
@@ -45,8 +57,8 @@ func newClientCredentialsExtension(cfg *Config, logger *zap.Logger) (*ClientCred
 	if cfg.ClientID == "" {
 		return nil, errNoClientIDProvided
 	}
-	if cfg.ClientSecret == "" {
-		return nil, errNoClientSecretProvided
+	if err := cfg.validateClientSecret(); err != nil {
+		return nil, err
 	}
 	if cfg.TokenURL == "" {
 		return nil, errNoTokenURLProvided
@@ -60,19 +72,37 @@ func newClientCredentialsExtension(cfg *Config, logger *zap.Logger) (*ClientCred
 	}
 	transport.TLSClientConfig = tlsCfg
 
-	return &ClientCredentialsAuthenticator{
+	var assertion *clientAssertion
+	if cfg.ClientAssertion != nil {
+		if assertion, err = newClientAssertion(cfg.ClientAssertion, cfg.ClientID, cfg.TokenURL); err != nil {
+			return nil, err
+		}
+	}
+
+	metricsCtx, err := tag.New(context.Background(), tag.Upsert(tagExtensionKey, cfg.ID().String()))
+	if err != nil {
+		return nil, err
+	}
+
+	o := &ClientCredentialsAuthenticator{
 		clientCredentials: &clientcredentials.Config{
-			ClientID:     cfg.ClientID,
-			ClientSecret: cfg.ClientSecret,
-			TokenURL:     cfg.TokenURL,
-			Scopes:       cfg.Scopes,
+			ClientID:       cfg.ClientID,
+			ClientSecret:   cfg.ClientSecret,
+			TokenURL:       cfg.TokenURL,
+			Scopes:         cfg.Scopes,
+			EndpointParams: cfg.EndpointParams,
 		},
-		logger: logger,
+		clientSecretFile: cfg.ClientSecretFile,
+		clientAssertion:  assertion,
+		logger:           logger,
 		client: &http.Client{
 			Transport: transport,
 			Timeout:   cfg.Timeout,
 		},
-	}, nil
+		metricsCtx: metricsCtx,
+	}
+	o.tokenSource = oauth2.ReuseTokenSource(nil, &clientCredentialsTokenSource{o})
+	return o, nil
 }
 
 // Start for ClientCredentialsAuthenticator extension does nothing
@@ -88,18 +118,71 @@ func (o *ClientCredentialsAuthenticator) Shutdown(_ context.Context) error {
 // RoundTripper returns oauth2.Transport, an http.RoundTripper that performs "client-credential" OAuth flow and
 // also auto refreshes OAuth tokens as needed.
 func (o *ClientCredentialsAuthenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.client)
 	return &oauth2.Transport{
-		Source: o.clientCredentials.TokenSource(ctx),
+		Source: o.tokenSource,
 		Base:   base,
 	}, nil
 }
 
 // PerRPCCredentials returns gRPC PerRPCCredentials that supports "client-credential" OAuth flow. The underneath
-// oauth2.clientcredentials.Config instance will manage tokens performing auto refresh as necessary.
+// token source will manage tokens performing auto refresh as necessary.
 func (o *ClientCredentialsAuthenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.client)
 	return grpcOAuth.TokenSource{
-		TokenSource: o.clientCredentials.TokenSource(ctx),
+		TokenSource: o.tokenSource,
 	}, nil
 }
+
+// fetchToken requests a new token from the token endpoint, reading the current client secret
+// or signing a new client assertion as configured.
+func (o *ClientCredentialsAuthenticator) fetchToken() (*oauth2.Token, error) {
+	stats.Record(o.metricsCtx, mTokenRequests.M(1))
+
+	token, err := o.requestToken()
+	if err != nil {
+		stats.Record(o.metricsCtx, mTokenRequestFailures.M(1))
+		return nil, err
+	}
+	return token, nil
+}
+
+func (o *ClientCredentialsAuthenticator) requestToken() (*oauth2.Token, error) {
+	cfg := *o.clientCredentials
+
+	if o.clientSecretFile != "" {
+		secret, err := ioutil.ReadFile(o.clientSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client secret file: %w", err)
+		}
+		cfg.ClientSecret = strings.TrimSpace(string(secret))
+	}
+
+	if o.clientAssertion != nil {
+		assertion, err := o.clientAssertion.sign(time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign client assertion: %w", err)
+		}
+
+		params := url.Values{}
+		for k, v := range o.clientCredentials.EndpointParams {
+			params[k] = v
+		}
+		params.Set("client_assertion_type", clientAssertionType)
+		params.Set("client_assertion", assertion)
+		cfg.EndpointParams = params
+		// The client is authenticated by the assertion, so the client ID is sent in the request body
+		cfg.AuthStyle = oauth2.AuthStyleInParams
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.client)
+	return cfg.Token(ctx)
+}
+
+// clientCredentialsTokenSource fetches a new token every time it is called,
+// it is wrapped in an oauth2.ReuseTokenSource to cache tokens until they expire.
+type clientCredentialsTokenSource struct {
+	authenticator *ClientCredentialsAuthenticator
+}
+
+func (ts *clientCredentialsTokenSource) Token() (*oauth2.Token, error) {
+	return ts.authenticator.fetchToken()
+}
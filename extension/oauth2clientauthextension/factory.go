@@ -17,6 +17,7 @@ package oauth2clientauthextension
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/extension/extensionhelper"
@@ -29,6 +30,9 @@ const (
 
 // NewFactory creates a factory for the OIDC Authenticator extension.
 func NewFactory() component.ExtensionFactory {
+	// TODO: Handle this err
+	_ = view.Register(MetricViews()...)
+
 	return extensionhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -4,8 +4,10 @@ go 1.16
 
 require (
 	github.com/stretchr/testify v1.7.0
+	go.opencensus.io v0.23.0
 	go.opentelemetry.io/collector v0.31.0
 	go.uber.org/zap v1.18.1
 	golang.org/x/oauth2 v0.0.0-20210615190721-d04028783cf1
 	google.golang.org/grpc v1.39.0
+	gopkg.in/square/go-jose.v2 v2.5.1
 )
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagExtensionKey = tag.MustNewKey("extension")
+
+	mTokenRequests        = stats.Int64("oauth2client_token_requests", "Number of token requests sent to the token endpoint", stats.UnitDimensionless)
+	mTokenRequestFailures = stats.Int64("oauth2client_token_request_failures", "Number of token requests that failed", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to fetching and refreshing tokens.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagExtensionKey}
+
+	return []*view.View{
+		{
+			Name:        mTokenRequests.Name(),
+			Measure:     mTokenRequests,
+			Description: mTokenRequests.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mTokenRequestFailures.Name(),
+			Measure:     mTokenRequestFailures,
+			Description: mTokenRequestFailures.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+	grpcOAuth "google.golang.org/grpc/credentials/oauth"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// newTokenServer returns a token endpoint that validates each request with the given function
+// and responds with a token valid for the given number of seconds.
+func newTokenServer(t *testing.T, expiresIn int, validate func(*http.Request)) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&requests, 1)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		validate(r)
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token" + string(rune('0'+count)),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		}))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestTokenIsCachedAndShared(t *testing.T) {
+	server, requests := newTokenServer(t, 3600, func(r *http.Request) {
+		assert.Equal(t, "someaudience", r.PostForm.Get("audience"))
+		assert.Equal(t, []string{"res1", "res2"}, r.PostForm["resource"])
+	})
+
+	o, err := newClientCredentialsExtension(&Config{
+		ClientID:     "testclientid",
+		ClientSecret: "testsecret",
+		TokenURL:     server.URL,
+		EndpointParams: url.Values{
+			"audience": []string{"someaudience"},
+			"resource": []string{"res1", "res2"},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	rt, err := o.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	creds, err := o.PerRPCCredentials()
+	require.NoError(t, err)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token1", r.Header.Get("Authorization"))
+	}))
+	defer backend.Close()
+
+	client := &http.Client{Transport: rt}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(backend.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	token, err := creds.(grpcOAuth.TokenSource).Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token1", token.AccessToken)
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+}
+
+func TestClientSecretFileIsReloaded(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("secret1\n"), 0600))
+
+	var secrets []string
+	// Tokens expiring within a few seconds are refreshed on every use
+	server, _ := newTokenServer(t, 1, func(r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "testclientid", id)
+		secrets = append(secrets, secret)
+	})
+
+	o, err := newClientCredentialsExtension(&Config{
+		ClientID:         "testclientid",
+		ClientSecretFile: secretFile,
+		TokenURL:         server.URL,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = o.tokenSource.Token()
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("secret2"), 0600))
+	_, err = o.tokenSource.Token()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"secret1", "secret2"}, secrets)
+}
+
+func TestClientAssertion(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	var tokenURL string
+	server, requests := newTokenServer(t, 3600, func(r *http.Request) {
+		_, _, ok := r.BasicAuth()
+		assert.False(t, ok)
+		assert.Equal(t, "testclientid", r.PostForm.Get("client_id"))
+		assert.Empty(t, r.PostForm.Get("client_secret"))
+		assert.Equal(t, "someaudience", r.PostForm.Get("audience"))
+		assert.Equal(t, clientAssertionType, r.PostForm.Get("client_assertion_type"))
+
+		assertion, err := jwt.ParseSigned(r.PostForm.Get("client_assertion"))
+		require.NoError(t, err)
+		require.Len(t, assertion.Headers, 1)
+		assert.Equal(t, "somekeyid", assertion.Headers[0].KeyID)
+		assert.Equal(t, "ES256", assertion.Headers[0].Algorithm)
+
+		claims := jwt.Claims{}
+		require.NoError(t, assertion.Claims(&key.PublicKey, &claims))
+		assert.NoError(t, claims.Validate(jwt.Expected{
+			Issuer:   "testclientid",
+			Subject:  "testclientid",
+			Audience: jwt.Audience{tokenURL},
+			Time:     time.Now(),
+		}))
+		assert.NotEmpty(t, claims.ID)
+		assert.Equal(t, time.Minute, claims.Expiry.Time().Sub(claims.IssuedAt.Time()))
+	})
+	tokenURL = server.URL
+
+	o, err := newClientCredentialsExtension(&Config{
+		ClientID: "testclientid",
+		TokenURL: server.URL,
+		EndpointParams: url.Values{
+			"audience": []string{"someaudience"},
+		},
+		ClientAssertion: &ClientAssertionSettings{
+			KeyFile:   keyFile,
+			KeyID:     "somekeyid",
+			Algorithm: "ES256",
+			Lifetime:  time.Minute,
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	token, err := o.tokenSource.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token1", token.AccessToken)
+	assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+}
+
+func TestClientAssertionInvalidKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("not a key"), 0600))
+
+	tests := []struct {
+		name        string
+		settings    *ClientAssertionSettings
+		expectedErr string
+	}{
+		{
+			name:        "missing_file",
+			settings:    &ClientAssertionSettings{KeyFile: filepath.Join(t.TempDir(), "missing.pem")},
+			expectedErr: "failed to read client assertion key",
+		},
+		{
+			name:        "invalid_pem",
+			settings:    &ClientAssertionSettings{KeyFile: keyFile},
+			expectedErr: "no PEM data found",
+		},
+		{
+			name:        "algorithm_mismatch",
+			settings:    &ClientAssertionSettings{KeyFile: "testdata/test-key.pem", Algorithm: "ES256"},
+			expectedErr: "failed to create client assertion signer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newClientCredentialsExtension(&Config{
+				ClientID:        "testclientid",
+				TokenURL:        "https://example.com/v1/token",
+				ClientAssertion: tt.settings,
+			}, zap.NewNop())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestTokenRequestMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "metrics")),
+		ClientID:          "testclientid",
+		ClientSecret:      "testsecret",
+		TokenURL:          server.URL,
+	}
+	o, err := newClientCredentialsExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = o.tokenSource.Token()
+	require.Error(t, err)
+	_, err = o.tokenSource.Token()
+	require.Error(t, err)
+
+	for _, name := range []string{mTokenRequests.Name(), mTokenRequestFailures.Name()} {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+
+		var found bool
+		for _, row := range rows {
+			if len(row.Tags) == 1 && row.Tags[0].Value == "oauth2client/metrics" {
+				found = true
+				assert.Equal(t, float64(2), row.Data.(*view.SumData).Value)
+			}
+		}
+		assert.True(t, found)
+	}
+}
@@ -15,11 +15,15 @@
 package filestorage
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.etcd.io/bbolt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 var defaultBucket = []byte(`default`)
@@ -93,6 +97,64 @@ func (c *fileStorageClient) Delete(_ context.Context, key string) error {
 	return c.db.Update(delete)
 }
 
+// Batch executes the specified operations in order, within a single transaction.
+// Get operation results are stored in-place in the Value field of the operation
+func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	batch := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errors.New("storage not initialized")
+		}
+
+		var err error
+		for _, op := range ops {
+			switch op.Type {
+			case storage.Get:
+				// bbolt values are only valid for the life of the transaction
+				op.Value = nil
+				if value := bucket.Get([]byte(op.Key)); value != nil {
+					op.Value = make([]byte, len(value))
+					copy(op.Value, value)
+				}
+			case storage.Set:
+				err = bucket.Put([]byte(op.Key), op.Value)
+			case storage.Delete:
+				err = bucket.Delete([]byte(op.Key))
+			default:
+				return fmt.Errorf("wrong operation type: %d", op.Type)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.db.Update(batch)
+}
+
+// Iterate calls fn for each key that begins with the specified prefix
+func (c *fileStorageClient) Iterate(_ context.Context, prefix string, fn storage.IterateFunc) error {
+	iterate := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errors.New("storage not initialized")
+		}
+
+		p := []byte(prefix)
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = cursor.Next() {
+			if err := fn(string(k), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.db.View(iterate)
+}
+
 // Close will close the database
 func (c *fileStorageClient) Close(_ context.Context) error {
 	return c.db.Close()
@@ -16,6 +16,7 @@ package filestorage
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -24,6 +25,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 func TestClientOperations(t *testing.T) {
@@ -61,6 +64,141 @@ func TestClientOperations(t *testing.T) {
 	require.Nil(t, value)
 }
 
+func TestClientBatchOperations(t *testing.T) {
+	tempDir := newTempDir(t)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	testSetEntries := []storage.Operation{
+		storage.SetOperation("testKey1", []byte("testValue1")),
+		storage.SetOperation("testKey2", []byte("testValue2")),
+	}
+
+	testGetEntries := []storage.Operation{
+		storage.GetOperation("testKey1"),
+		storage.GetOperation("testKey2"),
+	}
+
+	// Make sure nothing is there
+	err = client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	for _, op := range testGetEntries {
+		require.Nil(t, op.Value)
+	}
+
+	// Set it
+	err = client.Batch(ctx, testSetEntries...)
+	require.NoError(t, err)
+
+	// Get it back out, make sure it's right
+	err = client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	for i := range testGetEntries {
+		require.Equal(t, testSetEntries[i].Key, testGetEntries[i].Key)
+		require.Equal(t, testSetEntries[i].Value, testGetEntries[i].Value)
+	}
+
+	// Update it (the first entry should be empty and the second one removed)
+	testEntriesUpdate := []storage.Operation{
+		storage.SetOperation("testKey1", []byte{}),
+		storage.DeleteOperation("testKey2"),
+	}
+	err = client.Batch(ctx, testEntriesUpdate...)
+	require.NoError(t, err)
+
+	// Get it back out, make sure it's right
+	err = client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	require.Equal(t, []byte{}, testGetEntries[0].Value)
+	require.Nil(t, testGetEntries[1].Value)
+
+	// Operations are applied in order within the batch
+	getOp := storage.GetOperation("testKey3")
+	err = client.Batch(ctx,
+		storage.SetOperation("testKey3", []byte("testValue3")),
+		getOp,
+		storage.DeleteOperation("testKey1"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue3"), getOp.Value)
+
+	value, err := client.Get(ctx, "testKey1")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientBatchIsAtomic(t *testing.T) {
+	tempDir := newTempDir(t)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// An empty key cannot be stored, so the whole batch must be rolled back
+	err = client.Batch(ctx,
+		storage.SetOperation("testKey1", []byte("testValue1")),
+		storage.SetOperation("", []byte("testValue2")),
+	)
+	require.Error(t, err)
+
+	value, err := client.Get(ctx, "testKey1")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientIterate(t *testing.T) {
+	tempDir := newTempDir(t)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = client.Batch(ctx,
+		storage.SetOperation("a", []byte("0")),
+		storage.SetOperation("queue_2", []byte("2")),
+		storage.SetOperation("queue_1", []byte("1")),
+		storage.SetOperation("queue_3", []byte("3")),
+		storage.SetOperation("z", []byte("4")),
+	)
+	require.NoError(t, err)
+
+	var keys []string
+	var values []string
+	err = client.Iterate(ctx, "queue_", func(key string, value []byte) error {
+		keys = append(keys, key)
+		values = append(values, string(value))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"queue_1", "queue_2", "queue_3"}, keys)
+	require.Equal(t, []string{"1", "2", "3"}, values)
+
+	// An empty prefix matches everything
+	count := 0
+	err = client.Iterate(ctx, "", func(string, []byte) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, count)
+
+	// Iteration stops on the first error
+	stopErr := errors.New("stop")
+	keys = nil
+	err = client.Iterate(ctx, "queue_", func(key string, _ []byte) error {
+		keys = append(keys, key)
+		return stopErr
+	})
+	require.Equal(t, stopErr, err)
+	require.Equal(t, []string{"queue_1"}, keys)
+}
+
 func TestNewClientTransactionErrors(t *testing.T) {
 	timeout := 100 * time.Millisecond
 
@@ -106,6 +244,28 @@ func TestNewClientTransactionErrors(t *testing.T) {
 				require.Equal(t, "storage not initialized", err.Error())
 			},
 		},
+		{
+			name: "batch",
+			setup: func(tx *bbolt.Tx) error {
+				return tx.DeleteBucket(defaultBucket)
+			},
+			validate: func(t *testing.T, c *fileStorageClient) {
+				err := c.Batch(context.Background(), storage.SetOperation(testKey, testValue))
+				require.Error(t, err)
+				require.Equal(t, "storage not initialized", err.Error())
+			},
+		},
+		{
+			name: "iterate",
+			setup: func(tx *bbolt.Tx) error {
+				return tx.DeleteBucket(defaultBucket)
+			},
+			validate: func(t *testing.T, c *fileStorageClient) {
+				err := c.Iterate(context.Background(), "", func(string, []byte) error { return nil })
+				require.Error(t, err)
+				require.Equal(t, "storage not initialized", err.Error())
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3,6 +3,8 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/extension/stora
 go 1.16
 
 require (
+	github.com/alicebob/miniredis/v2 v2.14.3
+	github.com/go-redis/redis/v7 v7.4.0
 	github.com/stretchr/testify v1.7.0
 	go.etcd.io/bbolt v1.3.6
 	go.opentelemetry.io/collector v0.31.0
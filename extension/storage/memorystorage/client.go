@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorystorage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+type memoryClient struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+func newClient() *memoryClient {
+	return &memoryClient{
+		data: make(map[string][]byte),
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return copyValue(c.data[key]), nil
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = copyValue(value)
+	return nil
+}
+
+// Delete will delete data associated with the specified key
+func (c *memoryClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+// Batch executes the specified operations in order, while holding the client lock.
+// Get operation results are stored in-place in the Value field of the operation.
+// If any operation is invalid, none of the operations are applied
+func (c *memoryClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get, storage.Set, storage.Delete:
+		default:
+			return fmt.Errorf("wrong operation type: %d", op.Type)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = copyValue(c.data[op.Key])
+		case storage.Set:
+			c.data[op.Key] = copyValue(op.Value)
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+// Iterate calls fn for each key that begins with the specified prefix
+func (c *memoryClient) Iterate(_ context.Context, prefix string, fn storage.IterateFunc) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.data))
+	for key := range c.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := fn(key, c.data[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close does nothing, the data is kept until the extension is shut down
+func (c *memoryClient) Close(context.Context) error {
+	return nil
+}
+
+func copyValue(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	result := make([]byte, len(value))
+	copy(result, value)
+	return result
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorystorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestClientOperations(t *testing.T) {
+	client := newClient()
+
+	ctx := context.Background()
+	testKey := "testKey"
+	testValue := []byte("testValue")
+
+	// Make sure nothing is there
+	value, err := client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Set it
+	err = client.Set(ctx, testKey, testValue)
+	require.NoError(t, err)
+
+	// Get it back out, make sure it's right
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Equal(t, testValue, value)
+
+	// Modifying the returned value does not modify the stored one
+	value[0] = 'x'
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Equal(t, testValue, value)
+
+	// Delete it
+	err = client.Delete(ctx, testKey)
+	require.NoError(t, err)
+
+	// Make sure it's gone
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientBatchOperations(t *testing.T) {
+	client := newClient()
+	ctx := context.Background()
+
+	getOp1 := storage.GetOperation("testKey1")
+	getOp2 := storage.GetOperation("testKey2")
+	err := client.Batch(ctx,
+		storage.SetOperation("testKey1", []byte("testValue1")),
+		storage.SetOperation("testKey2", []byte("testValue2")),
+		getOp1,
+		storage.DeleteOperation("testKey2"),
+		getOp2,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue1"), getOp1.Value)
+	require.Nil(t, getOp2.Value)
+
+	// An invalid operation prevents the whole batch from being applied
+	invalidOp := storage.GetOperation("testKey1")
+	invalidOp.Type = 42
+	err = client.Batch(ctx, storage.DeleteOperation("testKey1"), invalidOp)
+	require.Error(t, err)
+
+	value, err := client.Get(ctx, "testKey1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue1"), value)
+}
+
+func TestClientIterate(t *testing.T) {
+	client := newClient()
+	ctx := context.Background()
+
+	err := client.Batch(ctx,
+		storage.SetOperation("a", []byte("0")),
+		storage.SetOperation("queue_2", []byte("2")),
+		storage.SetOperation("queue_1", []byte("1")),
+		storage.SetOperation("z", []byte("3")),
+	)
+	require.NoError(t, err)
+
+	var keys []string
+	err = client.Iterate(ctx, "queue_", func(key string, value []byte) error {
+		keys = append(keys, key+"="+string(value))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"queue_1=1", "queue_2=2"}, keys)
+
+	stopErr := errors.New("stop")
+	count := 0
+	err = client.Iterate(ctx, "", func(string, []byte) error {
+		count++
+		return stopErr
+	})
+	require.Equal(t, stopErr, err)
+	require.Equal(t, 1, count)
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorystorage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+type memoryStorage struct {
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	clients map[string]*memoryClient
+}
+
+// Ensure this storage extension implements the appropriate interface
+var _ storage.Extension = (*memoryStorage)(nil)
+
+func newMemoryStorage(logger *zap.Logger, _ *Config) *memoryStorage {
+	return &memoryStorage{
+		logger:  logger,
+		clients: make(map[string]*memoryClient),
+	}
+}
+
+// Start does nothing
+func (ms *memoryStorage) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown discards all stored data
+func (ms *memoryStorage) Shutdown(context.Context) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.clients = make(map[string]*memoryClient)
+	return nil
+}
+
+// GetClient returns a storage client for an individual component.
+// Clients requested for the same component and name share their data,
+// so state survives a component being restarted within the same process
+func (ms *memoryStorage) GetClient(_ context.Context, kind component.Kind, ent config.ComponentID, name string) (storage.Client, error) {
+	var rawName string
+	if name == "" {
+		rawName = fmt.Sprintf("%s_%s_%s", kindString(kind), ent.Type(), ent.Name())
+	} else {
+		rawName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	client, ok := ms.clients[rawName]
+	if !ok {
+		client = newClient()
+		ms.clients[rawName] = client
+	}
+	return client, nil
+}
+
+func kindString(k component.Kind) string {
+	switch k {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	default:
+		return "other" // not expected
+	}
+}
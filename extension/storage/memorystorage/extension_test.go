@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorystorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestClientsAreIsolated(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t)
+
+	client1, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "foo")
+	require.NoError(t, err)
+	client2, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "bar")
+	require.NoError(t, err)
+	client3, err := se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "foo")
+	require.NoError(t, err)
+
+	require.NoError(t, client1.Set(ctx, "key", []byte("value1")))
+	require.NoError(t, client2.Set(ctx, "key", []byte("value2")))
+
+	data, err := client1.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), data)
+
+	data, err = client2.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), data)
+
+	data, err = client3.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestClientDataSurvivesClose(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t)
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	data, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), data)
+
+	// Data is discarded when the extension shuts down
+	require.NoError(t, se.Shutdown(ctx))
+	client, err = se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	data, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func newTestExtension(t *testing.T) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	return se
+}
+
+func newTestEntity(name string) config.ComponentID {
+	return config.NewIDWithName("nop", name)
+}
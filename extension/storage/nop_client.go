@@ -40,6 +40,16 @@ func (c nopClient) Delete(context.Context, string) error {
 	return nil // no problem
 }
 
+// Batch does nothing, and returns nil. Get operations leave their Value as nil
+func (c nopClient) Batch(context.Context, ...Operation) error {
+	return nil // no problem
+}
+
+// Iterate does nothing, and returns nil
+func (c nopClient) Iterate(context.Context, string, IterateFunc) error {
+	return nil // nothing to iterate over
+}
+
 // Close does nothing and returns nil
 func (c nopClient) Close(context.Context) error {
 	return nil
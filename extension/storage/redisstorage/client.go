@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+const (
+	// scanCount is the number of keys requested from the server per SCAN call
+	scanCount = 100
+	// mgetCount is the maximum number of keys retrieved per MGET call while iterating
+	mgetCount = 100
+)
+
+type redisClient struct {
+	client     *redis.Client
+	keyPrefix  string
+	expiration time.Duration
+}
+
+func newClient(client *redis.Client, keyPrefix string, expiration time.Duration) *redisClient {
+	return &redisClient{
+		client:     client,
+		keyPrefix:  keyPrefix,
+		expiration: expiration,
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *redisClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.WithContext(ctx).Get(c.keyPrefix + key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *redisClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.WithContext(ctx).Set(c.keyPrefix+key, value, c.expiration).Err()
+}
+
+// Delete will delete data associated with the specified key
+func (c *redisClient) Delete(ctx context.Context, key string) error {
+	return c.client.WithContext(ctx).Del(c.keyPrefix + key).Err()
+}
+
+// Batch executes the specified operations in order, within a single MULTI/EXEC transaction.
+// Get operation results are stored in-place in the Value field of the operation
+func (c *redisClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get, storage.Set, storage.Delete:
+		default:
+			return fmt.Errorf("wrong operation type: %d", op.Type)
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	getCmds := make(map[storage.Operation]*redis.StringCmd)
+	cmds, err := c.client.WithContext(ctx).TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, op := range ops {
+			switch op.Type {
+			case storage.Get:
+				getCmds[op] = pipe.Get(c.keyPrefix + op.Key)
+			case storage.Set:
+				pipe.Set(c.keyPrefix+op.Key, op.Value, c.expiration)
+			case storage.Delete:
+				pipe.Del(c.keyPrefix + op.Key)
+			}
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	// A missing key is reported as a redis.Nil error, which is not a failure
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && cmdErr != redis.Nil {
+			return cmdErr
+		}
+	}
+
+	for op, cmd := range getCmds {
+		op.Value = nil
+		if value, err := cmd.Bytes(); err == nil {
+			op.Value = value
+		}
+	}
+	return nil
+}
+
+// Iterate calls fn for each key that begins with the specified prefix.
+// Keys are collected with SCAN before the function is called, so the iteration
+// is not a consistent snapshot: keys deleted in the meantime are skipped
+func (c *redisClient) Iterate(ctx context.Context, prefix string, fn storage.IterateFunc) error {
+	client := c.client.WithContext(ctx)
+
+	var keys []string
+	var cursor uint64
+	match := escapePattern(c.keyPrefix+prefix) + "*"
+	for {
+		batch, next, err := client.Scan(cursor, match, scanCount).Result()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, batch...)
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	sort.Strings(keys)
+
+	for start := 0; start < len(keys); start += mgetCount {
+		end := start + mgetCount
+		if end > len(keys) {
+			end = len(keys)
+		}
+		values, err := client.MGet(keys[start:end]...).Result()
+		if err != nil {
+			return err
+		}
+		for i, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				continue // deleted since it was scanned
+			}
+			if err := fn(strings.TrimPrefix(keys[start+i], c.keyPrefix), []byte(s)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close does nothing, connections are shared and closed when the extension shuts down
+func (c *redisClient) Close(context.Context) error {
+	return nil
+}
+
+// escapePattern escapes the glob-style special characters used by the SCAN MATCH option
+func escapePattern(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var errNoEndpointProvided = errors.New("no endpoint provided in the redis storage configuration")
+
+// Config defines configuration for redis storage extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the address of the Redis-compatible server, in the host:port form.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Password is the optional password used to authenticate to the server.
+	// It must match the password specified in the requirepass server configuration option.
+	Password string `mapstructure:"password"`
+
+	// DB is the database to select after connecting to the server.
+	DB int `mapstructure:"db"`
+
+	// Prefix is prepended to all keys written by this extension. Collectors sharing
+	// the same prefix and component IDs share their state.
+	Prefix string `mapstructure:"prefix"`
+
+	// Expiration is the time to live of stored keys. Zero means keys never expire.
+	Expiration time.Duration `mapstructure:"expiration,omitempty"`
+
+	// Timeout is the dial, read and write timeout for server requests.
+	Timeout time.Duration `mapstructure:"timeout,omitempty"`
+
+	// TLSSetting struct exposes TLS client configuration for the connection to the server.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errNoEndpointProvided
+	}
+	if cfg.Expiration < 0 {
+		return errors.New("expiration must not be negative")
+	}
+	return nil
+}
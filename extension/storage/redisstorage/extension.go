@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+type redisStorage struct {
+	client     *redis.Client
+	prefix     string
+	expiration time.Duration
+	logger     *zap.Logger
+}
+
+// Ensure this storage extension implements the appropriate interface
+var _ storage.Extension = (*redisStorage)(nil)
+
+func newRedisStorage(logger *zap.Logger, config *Config) (component.Extension, error) {
+	tlsCfg, err := config.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:         config.Endpoint,
+		Password:     config.Password,
+		DB:           config.DB,
+		DialTimeout:  config.Timeout,
+		ReadTimeout:  config.Timeout,
+		WriteTimeout: config.Timeout,
+		TLSConfig:    tlsCfg,
+	})
+
+	return &redisStorage{
+		client:     client,
+		prefix:     config.Prefix,
+		expiration: config.Expiration,
+		logger:     logger,
+	}, nil
+}
+
+// Start does nothing, connections to the server are established on first use
+func (rs *redisStorage) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown closes the connections to the server
+func (rs *redisStorage) Shutdown(context.Context) error {
+	return rs.client.Close()
+}
+
+// GetClient returns a storage client for an individual component.
+// All keys written by the client are namespaced by the configured prefix
+// and by the component, so replicas using the same configuration share state
+func (rs *redisStorage) GetClient(_ context.Context, kind component.Kind, ent config.ComponentID, name string) (storage.Client, error) {
+	var rawName string
+	if name == "" {
+		rawName = fmt.Sprintf("%s_%s_%s", kindString(kind), ent.Type(), ent.Name())
+	} else {
+		rawName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
+	}
+	if rs.prefix != "" {
+		rawName = rs.prefix + ":" + rawName
+	}
+	return newClient(rs.client, rawName+":", rs.expiration), nil
+}
+
+func kindString(k component.Kind) string {
+	switch k {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	default:
+		return "other" // not expected
+	}
+}
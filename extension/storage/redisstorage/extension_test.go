@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestClientOperations(t *testing.T) {
+	s := newTestServer(t)
+	client := newTestClient(t, s.Addr(), "")
+
+	ctx := context.Background()
+	testKey := "testKey"
+	testValue := []byte("testValue")
+
+	// Make sure nothing is there
+	value, err := client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Set it
+	err = client.Set(ctx, testKey, testValue)
+	require.NoError(t, err)
+	require.True(t, s.Exists("otelcol:receiver_nop_my_component:testKey"))
+
+	// Get it back out, make sure it's right
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Equal(t, testValue, value)
+
+	// Delete it
+	err = client.Delete(ctx, testKey)
+	require.NoError(t, err)
+
+	// Make sure it's gone
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientBatchOperations(t *testing.T) {
+	s := newTestServer(t)
+	client := newTestClient(t, s.Addr(), "")
+	ctx := context.Background()
+
+	getOp1 := storage.GetOperation("testKey1")
+	getOp2 := storage.GetOperation("testKey2")
+	getOp3 := storage.GetOperation("missing")
+	err := client.Batch(ctx,
+		storage.SetOperation("testKey1", []byte("testValue1")),
+		storage.SetOperation("testKey2", []byte("testValue2")),
+		getOp1,
+		storage.DeleteOperation("testKey2"),
+		getOp2,
+		getOp3,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue1"), getOp1.Value)
+	require.Nil(t, getOp2.Value)
+	require.Nil(t, getOp3.Value)
+
+	// An invalid operation prevents the whole batch from being sent
+	invalidOp := storage.GetOperation("testKey1")
+	invalidOp.Type = 42
+	err = client.Batch(ctx, storage.DeleteOperation("testKey1"), invalidOp)
+	require.Error(t, err)
+
+	value, err := client.Get(ctx, "testKey1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue1"), value)
+}
+
+func TestClientIterate(t *testing.T) {
+	s := newTestServer(t)
+	client := newTestClient(t, s.Addr(), "")
+	ctx := context.Background()
+
+	// Keys of other components must not be visible
+	require.NoError(t, s.Set("otelcol:receiver_nop_other:queue_0", "x"))
+
+	err := client.Batch(ctx,
+		storage.SetOperation("a", []byte("0")),
+		storage.SetOperation("queue_2", []byte("2")),
+		storage.SetOperation("queue_1", []byte("1")),
+		storage.SetOperation("queue*", []byte("3")),
+	)
+	require.NoError(t, err)
+
+	var keys []string
+	err = client.Iterate(ctx, "queue_", func(key string, value []byte) error {
+		keys = append(keys, key+"="+string(value))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"queue_1=1", "queue_2=2"}, keys)
+
+	// Glob characters in the prefix are matched literally
+	keys = nil
+	err = client.Iterate(ctx, "queue*", func(key string, value []byte) error {
+		keys = append(keys, key)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"queue*"}, keys)
+
+	stopErr := errors.New("stop")
+	count := 0
+	err = client.Iterate(ctx, "", func(string, []byte) error {
+		count++
+		return stopErr
+	})
+	require.Equal(t, stopErr, err)
+	require.Equal(t, 1, count)
+}
+
+func TestClientExpiration(t *testing.T) {
+	s := newTestServer(t)
+	se := newTestExtension(t, s.Addr(), func(cfg *Config) { cfg.Expiration = time.Minute })
+	client, err := se.GetClient(context.Background(), component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(context.Background(), "key", []byte("value")))
+	s.FastForward(2 * time.Minute)
+
+	value, err := client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestReplicasShareState(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	client1 := newTestClient(t, s.Addr(), "")
+	client2 := newTestClient(t, s.Addr(), "")
+	otherClient := newTestClient(t, s.Addr(), "other")
+
+	require.NoError(t, client1.Set(ctx, "offset", []byte("42")))
+
+	value, err := client2.Get(ctx, "offset")
+	require.NoError(t, err)
+	require.Equal(t, []byte("42"), value)
+
+	value, err = otherClient.Get(ctx, "offset")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientErrorsOnUnavailableServer(t *testing.T) {
+	s := newTestServer(t)
+	client := newTestClient(t, s.Addr(), "")
+	s.Close()
+
+	ctx := context.Background()
+	_, err := client.Get(ctx, "key")
+	require.Error(t, err)
+	require.Error(t, client.Set(ctx, "key", []byte("value")))
+	require.Error(t, client.Delete(ctx, "key"))
+	require.Error(t, client.Batch(ctx, storage.GetOperation("key")))
+	require.Error(t, client.Iterate(ctx, "", func(string, []byte) error { return nil }))
+}
+
+func newTestServer(t *testing.T) *miniredis.Miniredis {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestClient(t *testing.T, endpoint string, name string) storage.Client {
+	se := newTestExtension(t, endpoint, func(*Config) {})
+	client, err := se.GetClient(context.Background(), component.KindReceiver, newTestEntity("my_component"), name)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close(context.Background())) })
+	return client
+}
+
+func newTestExtension(t *testing.T, endpoint string, modify func(*Config)) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Timeout = time.Second
+	modify(cfg)
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, extension.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, extension.Shutdown(context.Background())) })
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	return se
+}
+
+func newTestEntity(name string) config.ComponentID {
+	return config.NewIDWithName("nop", name)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory()
+	require.Equal(t, typeStr, f.Type())
+
+	cfg := f.CreateDefaultConfig().(*Config)
+	require.Equal(t, config.NewID(typeStr), cfg.ID())
+	require.Equal(t, defaultEndpoint, cfg.Endpoint)
+	require.Equal(t, defaultPrefix, cfg.Prefix)
+	require.Equal(t, defaultTimeout, cfg.Timeout)
+
+	e, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	require.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestFactoryErrorsOnInvalidTLS(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.TLSSetting.Insecure = false
+	cfg.TLSSetting.CAFile = "/not/a/file"
+
+	e, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.Error(t, err)
+	require.Nil(t, e)
+}
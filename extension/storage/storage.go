@@ -51,6 +51,71 @@ type Client interface {
 	// Delete will delete data associated with the specified key
 	Delete(context.Context, string) error
 
+	// Batch handles specified operations in batch. Get operation results are put in-place
+	// into the Value field of the operation. All operations are applied atomically:
+	// either all of them succeed or none of them is persisted
+	Batch(context.Context, ...Operation) error
+
+	// Iterate calls the provided function for every key-value pair whose key
+	// begins with the specified prefix, in lexicographical key order.
+	// Iteration stops at the first non-nil error returned by the function,
+	// and that error is returned by Iterate
+	Iterate(context.Context, string, IterateFunc) error
+
 	// Close will release any resources held by the client
 	Close(context.Context) error
 }
+
+// IterateFunc is called by Client.Iterate for each matching key-value pair.
+// The value is only valid for the duration of the call and must be copied
+// if it needs to be retained
+type IterateFunc func(key string, value []byte) error
+
+type opType int
+
+const (
+	// Get is the operation type for Get operations
+	Get opType = iota
+	// Set is the operation type for Set operations
+	Set
+	// Delete is the operation type for Delete operations
+	Delete
+)
+
+type operation struct {
+	// Key specifies key which is going to be get/set/deleted
+	Key string
+	// Value specifies value that is going to be set or holds result of get operation
+	Value []byte
+	// Type describes the operation type
+	Type opType
+}
+
+// Operation describes a single get/set/delete operation performed as part of a Batch
+type Operation *operation
+
+// SetOperation returns an Operation that stores the value under the specified key
+func SetOperation(key string, value []byte) Operation {
+	return &operation{
+		Key:   key,
+		Value: value,
+		Type:  Set,
+	}
+}
+
+// GetOperation returns an Operation that retrieves the value stored under the specified key.
+// After the batch completes, the result is available in the Value field
+func GetOperation(key string) Operation {
+	return &operation{
+		Key:  key,
+		Type: Get,
+	}
+}
+
+// DeleteOperation returns an Operation that deletes the value stored under the specified key
+func DeleteOperation(key string) Operation {
+	return &operation{
+		Key:  key,
+		Type: Delete,
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
@@ -58,6 +59,15 @@ func NewTestExtension(t *testing.T, directory string) storage.Extension {
 	return se
 }
 
+// NewTestClient returns a storage client backed by a file storage extension
+// in the specified directory. The client is closed when the test completes
+func NewTestClient(t *testing.T, directory string) storage.Client {
+	client, err := NewTestExtension(t, directory).GetClient(context.Background(), component.KindReceiver, newTestEntity("client"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	return client
+}
+
 func newTestEntity(name string) config.ComponentID {
 	return config.NewIDWithName("nop", name)
 }
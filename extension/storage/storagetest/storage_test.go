@@ -15,11 +15,14 @@
 package storagetest
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 func TestNewStorageHost(t *testing.T) {
@@ -30,6 +33,22 @@ func TestNewStorageHost(t *testing.T) {
 	require.Equal(t, 2, len(hostWithTwo.GetExtensions()))
 }
 
+func TestNewTestClient(t *testing.T) {
+	client := NewTestClient(t, newTempDir(t))
+	ctx := context.Background()
+
+	getOp := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("key", []byte("value")), getOp))
+	require.Equal(t, []byte("value"), getOp.Value)
+
+	var keys []string
+	require.NoError(t, client.Iterate(ctx, "k", func(key string, _ []byte) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	require.Equal(t, []string{"key"}, keys)
+}
+
 func newTempDir(tb testing.TB) string {
 	tempDir, err := ioutil.TempDir("", "")
 	require.NoError(tb, err)
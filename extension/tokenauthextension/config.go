@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+var (
+	errNoTokenSourceProvided = errors.New("no TokensFile nor OIDC configuration provided for the token authenticator")
+	errNoIssuerURLProvided   = errors.New("no IssuerURL provided for the OIDC configuration")
+	errNoAudienceProvided    = errors.New("no Audience provided for the OIDC configuration")
+)
+
+// Config has the configuration for the token authenticator extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Header is the name of the request header holding the token. Optional, default value: "authorization".
+	Header string `mapstructure:"header"`
+
+	// Scheme is the authentication scheme preceding the token in the header. Optional, default value: "Bearer".
+	// An empty value means the header holds the token only.
+	Scheme string `mapstructure:"scheme"`
+
+	// TokensFile is the path to a YAML file mapping each accepted token to the attributes
+	// added to the requests authenticated with it. The file is reloaded every ReloadInterval.
+	TokensFile string `mapstructure:"tokens_file"`
+
+	// ReloadInterval is the interval at which the TokensFile is reloaded. Zero disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// OIDC configures the validation of tokens as JWTs issued by an OIDC provider.
+	OIDC *OIDCSettings `mapstructure:"oidc"`
+
+	// ClaimsToAttributes maps the name of a JWT claim to the name of the request attribute
+	// the claim value is copied to.
+	ClaimsToAttributes map[string]string `mapstructure:"claims_to_attributes"`
+}
+
+// OIDCSettings configures the validation of JWTs issued by an OIDC provider.
+type OIDCSettings struct {
+	// IssuerURL is the base URL of the OIDC provider. The provider configuration and signing keys
+	// are discovered from it. Required.
+	IssuerURL string `mapstructure:"issuer_url"`
+
+	// Audience is the expected `aud` claim of the tokens. Required.
+	Audience string `mapstructure:"audience"`
+
+	// IssuerCAPath is the local path for the issuer CA's TLS server cert. Optional.
+	IssuerCAPath string `mapstructure:"issuer_ca_path"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.TokensFile == "" && cfg.OIDC == nil {
+		return errNoTokenSourceProvided
+	}
+	if cfg.OIDC != nil {
+		if cfg.OIDC.IssuerURL == "" {
+			return errNoIssuerURLProvided
+		}
+		if cfg.OIDC.Audience == "" {
+			return errNoAudienceProvided
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	ext := cfg.Extensions[config.NewIDWithName(typeStr, "static")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "static")),
+			Header:            defaultHeader,
+			Scheme:            defaultScheme,
+			TokensFile:        "/var/lib/otelcol/tokens.yaml",
+			ReloadInterval:    time.Minute,
+		},
+		ext)
+	assert.NoError(t, ext.Validate())
+
+	ext = cfg.Extensions[config.NewIDWithName(typeStr, "oidc")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "oidc")),
+			Header:            "x-auth-token",
+			Scheme:            "",
+			OIDC: &OIDCSettings{
+				IssuerURL:    "https://auth.example.com/",
+				Audience:     "my-collector",
+				IssuerCAPath: "/etc/pki/issuer.pem",
+			},
+			ClaimsToAttributes: map[string]string{
+				"tenant": "x-tenant",
+				"groups": "x-groups",
+			},
+		},
+		ext)
+	assert.NoError(t, ext.Validate())
+}
+
+func TestLoadConfigError(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		expectedErr error
+	}{
+		{
+			name:        "missingsource",
+			expectedErr: errNoTokenSourceProvided,
+		},
+		{
+			name:        "missingaudience",
+			expectedErr: errNoAudienceProvided,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext := cfg.Extensions[config.NewIDWithName(typeStr, tt.name)]
+			assert.ErrorIs(t, ext.Validate(), tt.expectedErr)
+		})
+	}
+
+	oidcCfg := createDefaultConfig().(*Config)
+	oidcCfg.OIDC = &OIDCSettings{Audience: "my-collector"}
+	assert.ErrorIs(t, oidcCfg.Validate(), errNoIssuerURLProvided)
+}
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type tokenAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	static   *staticTokens
+	verifier *oidc.IDTokenVerifier
+
+	unaryInterceptor configauth.GRPCUnaryInterceptorFunc
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var (
+	_ configauth.ServerAuthenticator = (*tokenAuth)(nil)
+
+	errNotAuthenticated                  = errors.New("authentication didn't succeed")
+	errInvalidAuthenticationHeaderFormat = errors.New("invalid authorization header format")
+	errMetadataNotFound                  = errors.New("no request metadata found")
+)
+
+func newExtension(cfg *Config, logger *zap.Logger) (*tokenAuth, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	e := &tokenAuth{
+		cfg:              cfg,
+		logger:           logger,
+		unaryInterceptor: configauth.DefaultGRPCUnaryServerInterceptor,
+		done:             make(chan struct{}),
+	}
+	if cfg.TokensFile != "" {
+		e.static = newStaticTokens(cfg.TokensFile)
+	}
+	return e, nil
+}
+
+// Start loads the static tokens and discovers the OIDC provider configuration.
+func (e *tokenAuth) Start(ctx context.Context, _ component.Host) error {
+	if e.static != nil {
+		if err := e.static.load(); err != nil {
+			return err
+		}
+		if e.cfg.ReloadInterval > 0 {
+			e.wg.Add(1)
+			go e.reloadLoop()
+		}
+	}
+
+	if e.cfg.OIDC != nil {
+		verifier, err := newVerifier(ctx, e.cfg.OIDC)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration from the auth server: %w", err)
+		}
+		e.verifier = verifier
+	}
+
+	return nil
+}
+
+// Shutdown stops reloading the static tokens.
+func (e *tokenAuth) Shutdown(context.Context) error {
+	close(e.done)
+	e.wg.Wait()
+	return nil
+}
+
+func (e *tokenAuth) reloadLoop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+			// Keep the previously loaded tokens if the file is temporarily unavailable or invalid
+			if err := e.static.load(); err != nil {
+				e.logger.Warn("Failed to reload the tokens file", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Authenticate checks whether the given headers contain a valid token. Successfully authenticated calls return
+// a context whose incoming gRPC metadata holds the attributes associated with the token, so they can be used
+// by other components, such as the routing processor.
+func (e *tokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	token, err := e.extractToken(headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	if e.static != nil {
+		if attributes, ok := e.static.lookup(token); ok {
+			return withAttributes(ctx, toMultiValue(attributes)), nil
+		}
+	}
+
+	if e.verifier != nil {
+		idToken, err := e.verifier.Verify(ctx, token)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to verify token: %w", err)
+		}
+
+		claims := map[string]interface{}{}
+		if err = idToken.Claims(&claims); err != nil {
+			return ctx, fmt.Errorf("failed to get the claims from the token: %w", err)
+		}
+		return withAttributes(ctx, claimsToAttributes(claims, e.cfg.ClaimsToAttributes)), nil
+	}
+
+	return ctx, errNotAuthenticated
+}
+
+// GRPCUnaryServerInterceptor is a helper method to provide a gRPC-compatible UnaryInterceptor, typically calling the authenticator's Authenticate method.
+func (e *tokenAuth) GRPCUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return e.unaryInterceptor(ctx, req, info, handler, e.Authenticate)
+}
+
+// GRPCStreamServerInterceptor is a helper method to provide a gRPC-compatible StreamInterceptor, typically calling the authenticator's Authenticate method.
+// Unlike the default interceptor, the authenticated context is propagated to the stream handler.
+func (e *tokenAuth) GRPCStreamServerInterceptor(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	headers, ok := metadata.FromIncomingContext(stream.Context())
+	if !ok {
+		return errMetadataNotFound
+	}
+
+	ctx, err := e.Authenticate(stream.Context(), headers)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// extractToken returns the token from the configured header, stripping the authentication scheme.
+// Header names are matched case-insensitively, as HTTP and gRPC use different conventions.
+func (e *tokenAuth) extractToken(headers map[string][]string) (string, error) {
+	var values []string
+	for name, v := range headers {
+		if strings.EqualFold(name, e.cfg.Header) {
+			values = v
+			break
+		}
+	}
+	if len(values) == 0 {
+		return "", errNotAuthenticated
+	}
+
+	// we only use the first header, if multiple values exist
+	value := strings.TrimSpace(values[0])
+	if e.cfg.Scheme == "" {
+		return value, nil
+	}
+
+	parts := strings.Fields(value)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], e.cfg.Scheme) {
+		return "", errInvalidAuthenticationHeaderFormat
+	}
+	return parts[1], nil
+}
+
+// withAttributes adds the attributes to the incoming gRPC metadata of the context.
+func withAttributes(ctx context.Context, attributes map[string][]string) context.Context {
+	if len(attributes) == 0 {
+		return ctx
+	}
+
+	md := metadata.MD{}
+	for key, values := range attributes {
+		md.Append(key, values...)
+	}
+	if existing, ok := metadata.FromIncomingContext(ctx); ok {
+		md = metadata.Join(existing, md)
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+func toMultiValue(attributes map[string]string) map[string][]string {
+	result := make(map[string][]string, len(attributes))
+	for key, value := range attributes {
+		result[key] = []string{value}
+	}
+	return result
+}
+
+// authenticatedStream overrides the context of a server stream with the authenticated one.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestStaticTokens(t *testing.T) {
+	tokensFile := writeTokensFile(t, `
+token1:
+  tenant: acme
+token2:
+`)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TokensFile = tokensFile
+	e := startExtension(t, cfg)
+
+	tests := []struct {
+		name        string
+		headers     map[string][]string
+		expectedErr error
+		expectedMD  metadata.MD
+	}{
+		{
+			name:       "token_with_attributes",
+			headers:    map[string][]string{"authorization": {"Bearer token1"}},
+			expectedMD: metadata.MD{"tenant": []string{"acme"}},
+		},
+		{
+			name:    "token_without_attributes",
+			headers: map[string][]string{"Authorization": {"bearer token2"}},
+		},
+		{
+			name:        "unknown_token",
+			headers:     map[string][]string{"authorization": {"Bearer token3"}},
+			expectedErr: errNotAuthenticated,
+		},
+		{
+			name:        "missing_header",
+			headers:     map[string][]string{"other": {"Bearer token1"}},
+			expectedErr: errNotAuthenticated,
+		},
+		{
+			name:        "wrong_scheme",
+			headers:     map[string][]string{"authorization": {"Basic token1"}},
+			expectedErr: errInvalidAuthenticationHeaderFormat,
+		},
+		{
+			name:        "missing_scheme",
+			headers:     map[string][]string{"authorization": {"token1"}},
+			expectedErr: errInvalidAuthenticationHeaderFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := e.Authenticate(context.Background(), tt.headers)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			md, _ := metadata.FromIncomingContext(ctx)
+			assert.Equal(t, tt.expectedMD, md)
+		})
+	}
+}
+
+func TestCustomHeaderWithoutScheme(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TokensFile = writeTokensFile(t, "token1: {}")
+	cfg.Header = "X-SF-Token"
+	cfg.Scheme = ""
+	e := startExtension(t, cfg)
+
+	_, err := e.Authenticate(context.Background(), map[string][]string{"x-sf-token": {"token1"}})
+	assert.NoError(t, err)
+
+	_, err = e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer token1"}})
+	assert.ErrorIs(t, err, errNotAuthenticated)
+}
+
+func TestStaticTokensReload(t *testing.T) {
+	tokensFile := writeTokensFile(t, "token1: {}")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TokensFile = tokensFile
+	cfg.ReloadInterval = 10 * time.Millisecond
+	e := startExtension(t, cfg)
+
+	headers := map[string][]string{"authorization": {"Bearer token2"}}
+	_, err := e.Authenticate(context.Background(), headers)
+	require.ErrorIs(t, err, errNotAuthenticated)
+
+	require.NoError(t, ioutil.WriteFile(tokensFile, []byte("token2: {}"), 0600))
+	assert.Eventually(t, func() bool {
+		_, err = e.Authenticate(context.Background(), headers)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStartFailsOnInvalidTokensFile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TokensFile = writeTokensFile(t, "- not a map")
+
+	e, err := newExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	cfg.TokensFile = filepath.Join(t.TempDir(), "missing.yaml")
+	e, err = newExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, e.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestOIDCTokens(t *testing.T) {
+	issuer := newOIDCServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.OIDC = &OIDCSettings{
+		IssuerURL: issuer.URL,
+		Audience:  "collector",
+	}
+	cfg.ClaimsToAttributes = map[string]string{
+		"tenant": "x-tenant",
+		"groups": "x-groups",
+		"sub":    "x-subject",
+		"other":  "x-other",
+	}
+	e := startExtension(t, cfg)
+
+	token := issuer.token(t, "collector", map[string]interface{}{
+		"tenant": "acme",
+		"groups": []string{"dev", "ops"},
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("existing", "value"))
+	ctx, err := e.Authenticate(ctx, map[string][]string{"authorization": {"Bearer " + token}})
+	require.NoError(t, err)
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, metadata.MD{
+		"existing":  []string{"value"},
+		"x-tenant":  []string{"acme"},
+		"x-groups":  []string{"dev", "ops"},
+		"x-subject": []string{"subject"},
+	}, md)
+
+	// The signing keys are cached
+	_, err = e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + token}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&issuer.jwksRequests))
+
+	// Tokens for another audience are rejected
+	token = issuer.token(t, "other", nil)
+	_, err = e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + token}})
+	assert.Error(t, err)
+
+	// Tokens not issued by the issuer are rejected
+	otherIssuer := newOIDCServer(t)
+	token = otherIssuer.token(t, "collector", nil)
+	_, err = e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + token}})
+	assert.Error(t, err)
+}
+
+func TestStaticTokensTakePrecedenceOverOIDC(t *testing.T) {
+	issuer := newOIDCServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TokensFile = writeTokensFile(t, "token1: {tenant: static}")
+	cfg.OIDC = &OIDCSettings{
+		IssuerURL: issuer.URL,
+		Audience:  "collector",
+	}
+	e := startExtension(t, cfg)
+
+	ctx, err := e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer token1"}})
+	require.NoError(t, err)
+	md, _ := metadata.FromIncomingContext(ctx)
+	assert.Equal(t, []string{"static"}, md.Get("tenant"))
+
+	_, err = e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + issuer.token(t, "collector", nil)}})
+	assert.NoError(t, err)
+
+	_, err = e.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer token2"}})
+	assert.Error(t, err)
+}
+
+func TestStartFailsOnUnreachableIssuer(t *testing.T) {
+	issuer := newOIDCServer(t)
+	issuer.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.OIDC = &OIDCSettings{
+		IssuerURL: issuer.URL,
+		Audience:  "collector",
+	}
+	e, err := newExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, e.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestGRPCInterceptors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TokensFile = writeTokensFile(t, "token1: {tenant: acme}")
+	e := startExtension(t, cfg)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token1"))
+
+	// unary
+	_, err := e.GRPCUnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		assert.Equal(t, []string{"acme"}, md.Get("tenant"))
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = e.GRPCUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Fatal("the handler should not be called")
+		return nil, nil
+	})
+	assert.Error(t, err)
+
+	// stream
+	called := false
+	err = e.GRPCStreamServerInterceptor(nil, &mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		called = true
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		assert.Equal(t, []string{"acme"}, md.Get("tenant"))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	err = e.GRPCStreamServerInterceptor(nil, &mockServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		t.Fatal("the handler should not be called")
+		return nil
+	})
+	assert.ErrorIs(t, err, errMetadataNotFound)
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func writeTokensFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tokens.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func startExtension(t *testing.T, cfg *Config) *tokenAuth {
+	e, err := newExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, e.Shutdown(context.Background())) })
+	return e
+}
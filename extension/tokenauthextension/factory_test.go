@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	expected := &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewID(typeStr)),
+		Header:            defaultHeader,
+		Scheme:            defaultScheme,
+		ReloadInterval:    defaultReloadInterval,
+	}
+	assert.Equal(t, expected, createDefaultConfig())
+}
+
+func TestCreateExtension(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.TokensFile = "/var/lib/otelcol/tokens.yaml"
+
+	ext, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.Implements(t, (*configauth.ServerAuthenticator)(nil), ext)
+
+	// an invalid configuration fails
+	_, err = f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), f.CreateDefaultConfig())
+	assert.ErrorIs(t, err, errNoTokenSourceProvided)
+}
@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/tokenauthextension
+
+go 1.16
+
+require (
+	github.com/coreos/go-oidc v2.2.1+incompatible
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.31.0
+	go.uber.org/zap v1.18.1
+	google.golang.org/grpc v1.39.0
+	gopkg.in/square/go-jose.v2 v2.5.1
+	gopkg.in/yaml.v2 v2.4.0
+)
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/go-oidc"
+)
+
+// newVerifier discovers the configuration of the OIDC provider and returns a verifier for its tokens.
+// The provider signing keys are fetched from its JWKS endpoint and cached by the verifier,
+// which refreshes them when a token signed by an unknown key is received.
+func newVerifier(ctx context.Context, cfg *OIDCSettings) (*oidc.IDTokenVerifier, error) {
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 10 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	cert, err := getIssuerCACertFromPath(cfg.IssuerCAPath)
+	if err != nil {
+		return nil, err // the errors from this path have enough context already
+	}
+
+	if cert != nil {
+		t.TLSClientConfig = &tls.Config{
+			RootCAs: x509.NewCertPool(),
+		}
+		t.TLSClientConfig.RootCAs.AddCert(cert)
+	}
+
+	client := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: t,
+	}
+
+	// The context is kept by the key set to fetch the keys, it must outlive the Start call
+	oidcContext := oidc.ClientContext(context.Background(), client)
+	provider, err := oidc.NewProvider(oidcContext, cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.Verifier(&oidc.Config{
+		ClientID: cfg.Audience,
+	}), nil
+}
+
+func getIssuerCACertFromPath(path string) (*x509.Certificate, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	rawCA, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the CA file %q: %w", path, err)
+	}
+
+	block, _ := pem.Decode(rawCA)
+	if block == nil {
+		return nil, fmt.Errorf("cannot decode the contents of the CA file %q", path)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// claimsToAttributes copies the values of the mapped claims to request attributes.
+// Claims holding a list are copied as multiple values, missing claims are ignored.
+func claimsToAttributes(claims map[string]interface{}, mapping map[string]string) map[string][]string {
+	attributes := make(map[string][]string, len(mapping))
+	for claim, attribute := range mapping {
+		value, ok := claims[claim]
+		if !ok {
+			continue
+		}
+
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				attributes[attribute] = append(attributes[attribute], fmt.Sprintf("%v", item))
+			}
+		case nil:
+		default:
+			attributes[attribute] = append(attributes[attribute], fmt.Sprintf("%v", v))
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenauthextension
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// staticTokens holds the tokens accepted by the authenticator, loaded from a file.
+// Tokens are indexed by their SHA-256 digest, so that looking up a token
+// does not leak its value through timing differences.
+type staticTokens struct {
+	path string
+
+	mu     sync.RWMutex
+	tokens map[[sha256.Size]byte]map[string]string
+}
+
+func newStaticTokens(path string) *staticTokens {
+	return &staticTokens{path: path}
+}
+
+// load reads the tokens file, replacing the previously loaded tokens.
+// The file is a YAML map of tokens to the attributes of the requests authenticated with them.
+func (s *staticTokens) load() error {
+	data, err := ioutil.ReadFile(filepath.Clean(s.path))
+	if err != nil {
+		return fmt.Errorf("could not read the tokens file %q: %w", s.path, err)
+	}
+
+	raw := map[string]map[string]string{}
+	if err = yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("could not parse the tokens file %q: %w", s.path, err)
+	}
+
+	tokens := make(map[[sha256.Size]byte]map[string]string, len(raw))
+	for token, attributes := range raw {
+		if token == "" {
+			continue
+		}
+		tokens[sha256.Sum256([]byte(token))] = attributes
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = tokens
+	return nil
+}
+
+// lookup returns the attributes associated with the token, and whether the token is known.
+func (s *staticTokens) lookup(token string) (map[string]string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	attributes, ok := s.tokens[sha256.Sum256([]byte(token))]
+	return attributes, ok
+}
@@ -63,6 +63,7 @@ type Segment struct {
 	Annotations map[string]interface{}            `json:"annotations,omitempty"`
 	Metadata    map[string]map[string]interface{} `json:"metadata,omitempty"`
 	Subsegments []Segment                         `json:"subsegments,omitempty"`
+	Links       []SpanLinkData                    `json:"links,omitempty"`
 
 	// (for both embedded and independent) subsegment-only (optional) fields.
 	// Please refer to https://docs.aws.amazon.com/xray/latest/devguide/xray-api-segmentdocuments.html#api-segmentdocuments-subsegments
@@ -274,6 +275,15 @@ type SQLData struct {
 	Preparation      *string `json:"preparation,omitempty"` // "statement" / "call"
 }
 
+// SpanLinkData provides the shape for unmarshalling the links field, which
+// relates a segment to segments of the same or other traces, e.g. the producer
+// of a message handled by the segment.
+type SpanLinkData struct {
+	TraceID    *string                `json:"trace_id"`
+	SpanID     *string                `json:"id"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
 // ServiceData provides the shape for unmarshalling the service field.
 type ServiceData struct {
 	Version         *string `json:"version,omitempty"`
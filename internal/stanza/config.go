@@ -27,6 +27,9 @@ type BaseConfig struct {
 	config.ReceiverSettings `mapstructure:",squash"`
 	Operators               OperatorConfigs `mapstructure:"operators"`
 	Converter               ConverterConfig `mapstructure:"converter"`
+	// StorageID is the ID of the storage extension used to persist the receiver state,
+	// e.g. file offsets. When empty, the only configured storage extension is used, if any.
+	StorageID string `mapstructure:"storage"`
 }
 
 // OperatorConfigs is an alias that allows for unmarshaling outside of mapstructure
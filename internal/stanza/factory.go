@@ -16,6 +16,7 @@ package stanza
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/agent"
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
@@ -60,6 +61,15 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 			return nil, err
 		}
 
+		var storageID *config.ComponentID
+		if baseCfg.StorageID != "" {
+			id, idErr := config.NewIDFromString(baseCfg.StorageID)
+			if idErr != nil {
+				return nil, fmt.Errorf("invalid storage extension ID %q: %w", baseCfg.StorageID, idErr)
+			}
+			storageID = &id
+		}
+
 		pipeline := append([]operator.Config{*inputCfg}, operatorCfgs...)
 
 		emitter := NewLogEmitter(params.Logger.Sugar())
@@ -87,6 +97,7 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 
 		return &receiver{
 			id:        cfg.ID(),
+			storageID: storageID,
 			agent:     logAgent,
 			emitter:   emitter,
 			consumer:  nextConsumer,
@@ -17,6 +17,8 @@ package stanza
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,6 +30,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 // This file implements some useful testing components
@@ -192,6 +196,42 @@ func (p *mockClient) Delete(_ context.Context, key string) error {
 	return nil
 }
 
+func (p *mockClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	p.cacheMux.Lock()
+	defer p.cacheMux.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = p.cache[op.Key]
+		case storage.Set:
+			p.cache[op.Key] = op.Value
+		case storage.Delete:
+			delete(p.cache, op.Key)
+		default:
+			return fmt.Errorf("wrong operation type: %d", op.Type)
+		}
+	}
+	return nil
+}
+
+func (p *mockClient) Iterate(_ context.Context, prefix string, fn storage.IterateFunc) error {
+	p.cacheMux.Lock()
+	defer p.cacheMux.Unlock()
+	keys := make([]string, 0, len(p.cache))
+	for key := range p.cache {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(key, p.cache[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *mockClient) Close(_ context.Context) error {
 	p.cacheMux.Lock()
 	defer p.cacheMux.Unlock()
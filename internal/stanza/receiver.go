@@ -30,9 +30,10 @@ import (
 )
 
 type receiver struct {
-	id     config.ComponentID
-	wg     sync.WaitGroup
-	cancel context.CancelFunc
+	id        config.ComponentID
+	storageID *config.ComponentID
+	wg        sync.WaitGroup
+	cancel    context.CancelFunc
 
 	agent         *agent.LogAgent
 	emitter       *LogEmitter
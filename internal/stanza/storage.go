@@ -17,6 +17,7 @@ package stanza
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"go.opentelemetry.io/collector/component"
@@ -25,17 +26,13 @@ import (
 )
 
 func (r *receiver) setStorageClient(ctx context.Context, host component.Host) error {
-	var storageExtension storage.Extension
-	for _, ext := range host.GetExtensions() {
-		if se, ok := ext.(storage.Extension); ok {
-			if storageExtension != nil {
-				return errors.New("multiple storage extensions found")
-			}
-			storageExtension = se
-		}
+	storageExtension, err := r.getStorageExtension(host)
+	if err != nil {
+		return err
 	}
 
 	if storageExtension == nil {
+		r.logger.Info("No storage extension configured, receiver state will not be persisted")
 		r.storageClient = storage.NewNopClient()
 		return nil
 	}
@@ -49,6 +46,36 @@ func (r *receiver) setStorageClient(ctx context.Context, host component.Host) er
 	return nil
 }
 
+// getStorageExtension returns the storage extension selected by the receiver configuration.
+// If no storage extension is selected, the only storage extension found on the host is returned.
+// A nil extension is returned if no storage extension is selected nor found
+func (r *receiver) getStorageExtension(host component.Host) (storage.Extension, error) {
+	extensions := host.GetExtensions()
+
+	if r.storageID != nil {
+		ext, found := extensions[*r.storageID]
+		if !found {
+			return nil, fmt.Errorf("storage extension %q not found", r.storageID)
+		}
+		storageExtension, ok := ext.(storage.Extension)
+		if !ok {
+			return nil, fmt.Errorf("extension %q is not a storage extension", r.storageID)
+		}
+		return storageExtension, nil
+	}
+
+	var storageExtension storage.Extension
+	for _, ext := range extensions {
+		if se, ok := ext.(storage.Extension); ok {
+			if storageExtension != nil {
+				return nil, errors.New("multiple storage extensions found, use the 'storage' setting to select one")
+			}
+			storageExtension = se
+		}
+	}
+	return storageExtension, nil
+}
+
 func (r *receiver) getPersister() operator.Persister {
 	return &persister{r.storageClient}
 }
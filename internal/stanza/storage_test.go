@@ -21,8 +21,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
@@ -82,10 +85,96 @@ func TestFailOnMultipleStorageExtensions(t *testing.T) {
 	host := storagetest.NewStorageHost(t, tempDir, "one", "two")
 	err = r.Start(ctx, host)
 	require.Error(t, err)
-	require.Equal(t, "storage client: multiple storage extensions found", err.Error())
+	require.Equal(t, "storage client: multiple storage extensions found, use the 'storage' setting to select one", err.Error())
+}
+
+func TestSelectStorageExtension(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	host := storagetest.NewStorageHost(t, tempDir, "one", "two")
+
+	cfg := TestReceiverType{}.CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "nop/two"
+	r := createReceiverWithConfig(t, cfg)
+	require.NoError(t, r.Start(ctx, host))
+
+	myBytes := []byte("my_value")
+	require.NoError(t, r.storageClient.Set(ctx, "key", myBytes))
+	require.NoError(t, r.Shutdown(ctx))
+
+	// The value was stored by the selected extension only
+	ext := host.GetExtensions()[config.NewIDWithName("nop", "two")].(storage.Extension)
+	client, err := ext.GetClient(ctx, component.KindReceiver, r.id, "")
+	require.NoError(t, err)
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, myBytes, val)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestFailOnMissingStorageExtension(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	cfg := TestReceiverType{}.CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "nop/three"
+	r := createReceiverWithConfig(t, cfg)
+	host := storagetest.NewStorageHost(t, tempDir, "one", "two")
+	err = r.Start(ctx, host)
+	require.Error(t, err)
+	require.Equal(t, `storage client: storage extension "nop/three" not found`, err.Error())
+}
+
+func TestFailOnNonStorageExtension(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := TestReceiverType{}.CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "nop"
+	r := createReceiverWithConfig(t, cfg)
+
+	f := componenttest.NewNopExtensionFactory()
+	ext, err := f.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), f.CreateDefaultConfig())
+	require.NoError(t, err)
+	host := &nonStorageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewID("nop"): ext},
+	}
+	err = r.Start(ctx, host)
+	require.Error(t, err)
+	require.Equal(t, `storage client: extension "nop" is not a storage extension`, err.Error())
+}
+
+func TestFailOnInvalidStorageID(t *testing.T) {
+	cfg := TestReceiverType{}.CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "/invalid"
+
+	factory := NewFactory(TestReceiverType{})
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		cfg,
+		&mockLogsConsumer{},
+	)
+	require.Error(t, err)
+}
+
+type nonStorageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *nonStorageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
 }
 
 func createReceiver(t *testing.T) *receiver {
+	return createReceiverWithConfig(t, TestReceiverType{}.CreateDefaultConfig())
+}
+
+func createReceiverWithConfig(t *testing.T, cfg config.Receiver) *receiver {
 	params := component.ReceiverCreateSettings{
 		Logger: zaptest.NewLogger(t),
 	}
@@ -96,7 +185,7 @@ func createReceiver(t *testing.T) *receiver {
 	logsReceiver, err := factory.CreateLogsReceiver(
 		context.Background(),
 		params,
-		factory.CreateDefaultConfig(),
+		cfg,
 		&mockConsumer,
 	)
 	require.NoError(t, err, "receiver should successfully build")